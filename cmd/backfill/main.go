@@ -0,0 +1,93 @@
+// backfill downloads the candles missing from the stored history, resuming
+// where a previous run stopped. With -derive only 1m candles are downloaded
+// and the other intervals, custom ones included, are built from them.
+//
+//	backfill -symbols BTCUSDT,ETHUSDT -intervals 1h,4h -from 2020-01-01
+//	backfill -derive -intervals 1m,15m,90m,1d,2d
+package main
+
+import (
+	"context"
+	"cryptoapi/internal/api"
+	"cryptoapi/internal/config"
+	"cryptoapi/internal/logging"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func parseDate(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func list(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func main() {
+	symbols := flag.String("symbols", "", "comma separated symbols, the configured universe when empty")
+	intervals := flag.String("intervals", strings.Join(api.Intervals, ","), "comma separated intervals")
+	fromFlag := flag.String("from", "", "first day (2006-01-02) or time (RFC 3339), the listing when empty")
+	toFlag := flag.String("to", "", "last day or time, now when empty")
+	derive := flag.Bool("derive", false, "download 1m only and derive the other intervals from it")
+	flag.Parse()
+
+	from, err := parseDate(*fromFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	to, err := parseDate(*toFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := config.Create(); err != nil {
+		log.Fatal(err)
+	}
+	logFile, logger, err := logging.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logFile.Close()
+
+	cryptoapi := api.NewHistory(logger)
+	tickers := list(*symbols)
+	if len(tickers) == 0 {
+		if err := cryptoapi.Universe.Refresh(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+		tickers = cryptoapi.Symbols()
+	}
+
+	// Progress is saved as it goes, so stopping midway loses little.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+	if !*derive {
+		if err := cryptoapi.BackfillHistory(ctx, tickers, list(*intervals), from, to); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := cryptoapi.BackfillHistory(ctx, tickers, []string{"1m"}, from, to); err != nil {
+		log.Fatal(err)
+	}
+	cryptoapi.DeriveHistory(tickers, list(*intervals))
+}
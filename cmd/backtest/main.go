@@ -0,0 +1,116 @@
+// backtest runs a rule strategy on the stored candles of a ticker and writes
+// its report next to out: out.json, the trades in out.csv, the equity curve
+// in out-curve.csv and a page with charts in out.html. Costs and sizing come
+// from the backtest section of the config.
+//
+//	backtest -symbol BTCUSDT -interval 2h -entry "rsi(14) crosses_below 30" -exit "rsi(14) crosses_above 70" -stop 0.01
+//	backtest -symbol ETHUSDT -interval 4h -entry "close crosses_above ema(50)" -trail-atr 3 -path worst
+package main
+
+import (
+	"cryptoapi/internal/api"
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/cache"
+	"cryptoapi/internal/config"
+	"cryptoapi/internal/logging"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+func parseDate(v string, empty int64) (int64, error) {
+	if v == "" {
+		return empty, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, v); err != nil {
+			return 0, err
+		}
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+func write(name string, fn func(io.Writer) error) {
+	f, err := os.Create(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := fn(f); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func main() {
+	symbol := flag.String("symbol", "BTCUSDT", "symbol")
+	interval := flag.String("interval", "1h", "interval, virtual ones included")
+	entry := flag.String("entry", "", "condition opening a position")
+	exit := flag.String("exit", "", "condition closing a position")
+	side := flag.String("side", "buy", "buy to go long, sell to go short")
+	max := flag.Int("max", 1, "positions open at once")
+	stop := flag.Float64("stop", 0, "stop loss from the entry, e.g. 0.01 for 1%")
+	target := flag.Float64("target", 0, "take profit from the entry")
+	trail := flag.Float64("trail", 0, "trailing stop from the best price")
+	trailATR := flag.Float64("trail-atr", 0, "trailing stop as a multiple of the 14 candle ATR")
+	path := flag.String("path", "", "intrabar path: ohlc, high-first, low-first, worst or best; the config one when empty")
+	fromFlag := flag.String("from", "", "first day (2006-01-02) or time (RFC 3339), the first candle when empty")
+	toFlag := flag.String("to", "", "last day or time, the last candle when empty")
+	out := flag.String("out", "backtest", "path of the report files, without extension")
+	flag.Parse()
+
+	from, err := parseDate(*fromFlag, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	to, err := parseDate(*toFlag, math.MaxInt64)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := config.Create(); err != nil {
+		log.Fatal(err)
+	}
+	logFile, logger, err := logging.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logFile.Close()
+	cfg, err := backtest.FromConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *path != "" {
+		cfg.Path = backtest.Path(*path)
+	}
+
+	cryptoapi := api.New(logger, cache.New(), 0)
+	strategy := &backtest.RuleStrategy{
+		Entry:        *entry,
+		Exit:         *exit,
+		Side:         backtest.Side(*side),
+		MaxPositions: *max,
+		StopLoss:     *stop,
+		TakeProfit:   *target,
+		Trail:        *trail,
+		TrailATR:     *trailATR,
+	}
+	res, err := cryptoapi.Backtest(*symbol, *interval, from, to, strategy, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	report := backtest.NewReport(res)
+	write(*out+".json", report.WriteJSON)
+	write(*out+".csv", report.WriteCSV)
+	write(*out+"-curve.csv", report.WriteCurveCSV)
+	write(*out+".html", report.WriteHTML)
+
+	m := report.Metrics
+	fmt.Printf("%d trades, return %.2f%% (%.2f%% a year), max drawdown %.2f%%, sharpe %.2f, win rate %.2f%%\n",
+		m.Trades, m.TotalReturn*100, m.AnnualReturn*100, m.MaxDrawdown*100, m.Sharpe, m.WinRate*100)
+}
@@ -0,0 +1,38 @@
+// migrate imports the gob+gzip kline snapshots of the data folder into the
+// kline store.
+//
+//	migrate -dir data -remove
+package main
+
+import (
+	"cryptoapi/internal/api"
+	"cryptoapi/internal/config"
+	"cryptoapi/internal/logging"
+	"flag"
+	"log"
+
+	"github.com/spf13/viper"
+)
+
+func main() {
+	dir := flag.String("dir", "", "folder of the snapshots, the data folder when empty")
+	remove := flag.Bool("remove", false, "remove the snapshots once imported")
+	flag.Parse()
+
+	if err := config.Create(); err != nil {
+		log.Fatal(err)
+	}
+	logFile, logger, err := logging.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logFile.Close()
+
+	if *dir == "" {
+		*dir = viper.GetString("base.data.folder")
+	}
+	cryptoapi := api.NewHistory(logger)
+	if err := cryptoapi.ImportLegacy(*dir, *remove); err != nil {
+		log.Fatal(err)
+	}
+}
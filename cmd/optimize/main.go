@@ -0,0 +1,178 @@
+// optimize searches the parameters of a rule strategy, written as {name}
+// placeholders in its conditions, on the stored candles of a ticker. The
+// study is saved in the data folder; -list compares the saved ones.
+//
+//	optimize -symbol BTCUSDT -interval 2h -entry "rsi(14) crosses_below {buy}" -exit "rsi(14) crosses_above {sell}" -params buy=20:40:5,sell=60:80:5,stop=0:0.05:0.01
+//	optimize ... -samples 200 -objective calmar
+//	optimize ... -in 2000 -out 500
+//	optimize -list
+package main
+
+import (
+	"cryptoapi/internal/api"
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/cache"
+	"cryptoapi/internal/config"
+	"cryptoapi/internal/logging"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+func parseDate(v string, empty int64) (int64, error) {
+	if v == "" {
+		return empty, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, v); err != nil {
+			return 0, err
+		}
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+func formatParams(p backtest.Params) string {
+	var names []string
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var res []string
+	for _, name := range names {
+		res = append(res, fmt.Sprintf("%s=%g", name, p[name]))
+	}
+	return strings.Join(res, " ")
+}
+
+func list() {
+	studies, err := api.Studies()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, s := range studies {
+		when := time.Unix(0, s.Time*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+		switch {
+		case s.WalkForward != nil:
+			fmt.Printf("%s %s_%s %s walk-forward: %d windows, return %.2f%%, efficiency %.2f\n", when, s.Symbol, s.Interval, s.Objective,
+				len(s.WalkForward.Windows), s.WalkForward.Return*100, s.WalkForward.Efficiency)
+		case len(s.Trials) > 0:
+			fmt.Printf("%s %s_%s %s best %.4f with %s\n", when, s.Symbol, s.Interval, s.Objective, s.Trials[0].Score, formatParams(s.Trials[0].Params))
+		}
+		fmt.Printf("\t%s\n", s.Strategy)
+	}
+}
+
+func main() {
+	symbol := flag.String("symbol", "BTCUSDT", "symbol")
+	interval := flag.String("interval", "1h", "interval, virtual ones included")
+	entry := flag.String("entry", "", "condition opening a position, with {name} placeholders")
+	exit := flag.String("exit", "", "condition closing a position, with {name} placeholders")
+	side := flag.String("side", "buy", "buy to go long, sell to go short")
+	max := flag.Int("max", 1, "positions open at once")
+	params := flag.String("params", "", "parameters as name=min:max:step separated by commas, stop, target, trail and trail_atr setting the exits")
+	objective := flag.String("objective", "sharpe", "metric maximized: return, annual_return, sharpe, sortino, calmar, profit_factor, expectancy, win_rate or drawdown")
+	samples := flag.Int("samples", 0, "random draws from the space, the whole grid when zero")
+	seed := flag.Int64("seed", 1, "seed of the random draws")
+	workers := flag.Int("workers", 0, "backtests run at once, one per CPU when zero")
+	in := flag.Int("in", 0, "candles optimized on by each walk-forward window, no walk-forward when zero")
+	out := flag.Int("out", 0, "candles each walk-forward window is tested on")
+	top := flag.Int("top", 10, "trials printed")
+	fromFlag := flag.String("from", "", "first day (2006-01-02) or time (RFC 3339), the first candle when empty")
+	toFlag := flag.String("to", "", "last day or time, the last candle when empty")
+	listFlag := flag.Bool("list", false, "list the saved studies")
+	flag.Parse()
+
+	if err := config.Create(); err != nil {
+		log.Fatal(err)
+	}
+	if *listFlag {
+		list()
+		return
+	}
+	from, err := parseDate(*fromFlag, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	to, err := parseDate(*toFlag, math.MaxInt64)
+	if err != nil {
+		log.Fatal(err)
+	}
+	space, err := backtest.ParseSpace(*params)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logFile, logger, err := logging.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logFile.Close()
+	cfg, err := backtest.FromConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cryptoapi := api.New(logger, cache.New(), 0)
+	src, err := cryptoapi.Candles(*symbol, *interval, from, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opt := backtest.Optimizer{
+		Space:     space,
+		New:       backtest.Rules(*entry, *exit, backtest.Side(*side), *max),
+		Config:    cfg,
+		Objective: backtest.Objective(*objective),
+		Samples:   *samples,
+		Seed:      *seed,
+		Workers:   *workers,
+	}
+	study := &backtest.Study{
+		Symbol:    *symbol,
+		Interval:  *interval,
+		Strategy:  fmt.Sprintf("%s %s, entry %q, exit %q", *side, *symbol, *entry, *exit),
+		Objective: opt.Objective,
+		Space:     space,
+	}
+
+	if *in > 0 {
+		wf := backtest.WalkForward{Optimizer: opt, InSample: *in, OutSample: *out}
+		res, err := wf.Run(src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		study.WalkForward = res
+		for _, w := range res.Windows {
+			fmt.Printf("%s to %s: in sample %.4f with %s, out of sample %.4f, return %.2f%%\n",
+				time.Unix(0, w.From*int64(time.Millisecond)).UTC().Format("2006-01-02"),
+				time.Unix(0, w.To*int64(time.Millisecond)).UTC().Format("2006-01-02"),
+				w.Best.Score, formatParams(w.Best.Params), w.OutScore, w.Out.TotalReturn*100)
+		}
+		fmt.Printf("return out of sample %.2f%%, efficiency %.2f\n", res.Return*100, res.Efficiency)
+	} else {
+		trials, err := opt.Optimize(src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		study.Trials = trials
+		for i, t := range trials {
+			if i == *top {
+				break
+			}
+			if t.Err != "" {
+				fmt.Printf("%s: %s\n", formatParams(t.Params), t.Err)
+				continue
+			}
+			fmt.Printf("%.4f %s: %d trades, return %.2f%%, max drawdown %.2f%%\n",
+				t.Score, formatParams(t.Params), t.Metrics.Trades, t.Metrics.TotalReturn*100, t.Metrics.MaxDrawdown*100)
+		}
+	}
+	name, err := api.SaveStudy(study)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("saved", name)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"cryptoapi/internal/api"
 	"cryptoapi/internal/cache"
 	"cryptoapi/internal/config"
@@ -218,8 +219,14 @@ func main() {
 
 	//cryptoapi.CollectOldData()
 	//cryptoapi.LoadIntoCache()
-	for {
-		cryptoapi.CollectData()
+	ctx := context.Background()
+	go func() {
+		if err := cryptoapi.ServeFromConfig(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	if err := cryptoapi.StartStreaming(ctx); err != nil {
+		log.Fatal(err)
 	}
 	//fmt.Println("test")
 	//fmt.Println("wow")
@@ -230,7 +237,8 @@ func main() {
 	//}
 	//a, b, c := talib.Macd(data.Close, 12, 26, 9)
 
-	//cryptoapi.TestRSI("BTCUSDT_2h", 10000, 10000, 1.00, 30, 70)
-	//cryptoapi.TestEngulfing("BTCUSDT_5m", 1000, 1000, 1.00)
+	//cfg, _ := backtest.FromConfig()
+	//res, err := cryptoapi.Backtest("BTCUSDT", "2h", 0, math.MaxInt64, &backtest.RuleStrategy{
+	//	Entry: "rsi(14) crosses_below 30", Exit: "rsi(14) crosses_above 70", StopLoss: 0.01}, cfg)
 
 }
@@ -0,0 +1,297 @@
+// Package aggregate derives candles of higher timeframes from finer ones,
+// normally 1m, so they need not be fetched from Binance and timeframes
+// Binance does not offer, such as 10m or 2d, can be used.
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+)
+
+const (
+	minute = int64(time.Minute / time.Millisecond)
+	day    = 24 * 60 * minute
+	// weeks start on Mondays, 1970-01-05 being the first
+	weekOrigin = 4 * day
+)
+
+var units = map[byte]int64{
+	'm': minute,
+	'h': 60 * minute,
+	'd': day,
+	'w': 7 * day,
+}
+
+// Interval is a timeframe such as 15m, 90m, 2d, 1w or 1M. Bars of minutes,
+// hours and days are aligned on the Unix epoch, weeks on Mondays and months
+// on the first day of the month, all in UTC as Binance does.
+type Interval struct {
+	n    int
+	unit byte
+}
+
+func ParseInterval(s string) (Interval, error) {
+	if len(s) < 2 {
+		return Interval{}, fmt.Errorf("bad interval %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 1 {
+		return Interval{}, fmt.Errorf("bad interval %q", s)
+	}
+	unit := s[len(s)-1]
+	if _, ok := units[unit]; !ok && unit != 'M' {
+		return Interval{}, fmt.Errorf("bad interval %q", s)
+	}
+	return Interval{n: n, unit: unit}, nil
+}
+
+func (iv Interval) String() string {
+	return strconv.Itoa(iv.n) + string(iv.unit)
+}
+
+// Millis is the length of the interval, zero for months which vary.
+func (iv Interval) Millis() int64 {
+	return int64(iv.n) * units[iv.unit]
+}
+
+// Start is the open time of the bar holding the time t, in milliseconds.
+func (iv Interval) Start(t int64) int64 {
+	if iv.unit == 'M' {
+		u := time.Unix(0, t*int64(time.Millisecond)).UTC()
+		months := (u.Year()-1970)*12 + int(u.Month()) - 1
+		months -= int(mod(int64(months), int64(iv.n)))
+		return millis(time.Date(1970+months/12, time.Month(months%12+1), 1, 0, 0, 0, 0, time.UTC))
+	}
+	var origin int64
+	if iv.unit == 'w' {
+		origin = weekOrigin
+	}
+	return t - mod(t-origin, iv.Millis())
+}
+
+// Next is the open time of the bar after the one opened at start.
+func (iv Interval) Next(start int64) int64 {
+	if iv.unit == 'M' {
+		return millis(time.Unix(0, start*int64(time.Millisecond)).UTC().AddDate(0, iv.n, 0))
+	}
+	return start + iv.Millis()
+}
+
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// Aggregator folds the klines of one symbol into bars of an interval. The
+// klines must come in order, and can be updates of the candle in progress.
+type Aggregator struct {
+	Interval Interval
+
+	start int64
+	// the bar of the closed klines folded in so far
+	base    ingest.Kline
+	hasBase bool
+	// the bar Add has not handed over yet
+	pending    ingest.Kline
+	hasPending bool
+}
+
+func New(iv Interval) *Aggregator {
+	return &Aggregator{Interval: iv, start: -1}
+}
+
+// Update folds k in and returns the bar it belongs to as it stands. The bar
+// is closed once the kline closing is the last one of the bar.
+func (a *Aggregator) Update(k ingest.Kline) ingest.Kline {
+	start := a.Interval.Start(k.Candle.OpenTime)
+	if start != a.start {
+		a.start, a.hasBase = start, false
+	}
+	bar := k
+	if a.hasBase {
+		bar = Combine(a.base, k)
+	}
+	bar.Interval = a.Interval.String()
+	bar.Candle.OpenTime = start
+	bar.CloseTime = a.Interval.Next(start) - 1
+	if k.Closed {
+		a.base, a.hasBase = bar, true
+	}
+	bar.Closed = k.Closed && k.CloseTime >= bar.CloseTime
+	return bar
+}
+
+// Combine folds the candle k into the bar, k coming after the candles already
+// in it. Times are left to the caller.
+func Combine(bar, k ingest.Kline) ingest.Kline {
+	b, c := bar.Candle, k.Candle
+	// A candle without trades repeats the previous close, which Binance
+	// leaves out of the bars above it.
+	switch {
+	case c.Volume == 0:
+		c.Open, c.High, c.Low, c.Close = b.Open, b.High, b.Low, b.Close
+	case b.Volume == 0:
+		b.Open, b.High, b.Low = c.Open, c.High, c.Low
+	}
+	bar.Candle = stream.Candle{
+		Open:   b.Open,
+		High:   math.Max(b.High, c.High),
+		Low:    math.Min(b.Low, c.Low),
+		Close:  c.Close,
+		Volume: b.Volume + c.Volume,
+
+		QuoteVolume:         b.QuoteVolume + c.QuoteVolume,
+		Trades:              b.Trades + c.Trades,
+		TakerBuyVolume:      b.TakerBuyVolume + c.TakerBuyVolume,
+		TakerBuyQuoteVolume: b.TakerBuyQuoteVolume + c.TakerBuyQuoteVolume,
+	}
+	return bar
+}
+
+// Row returns row i of b as a closed kline.
+func Row(b *store.Batch, i int) ingest.Kline {
+	return ingest.Kline{
+		Candle: stream.Candle{
+			OpenTime: b.OpenTime[i],
+			Open:     b.Open[i],
+			High:     b.High[i],
+			Low:      b.Low[i],
+			Close:    b.Close[i],
+			Volume:   b.Volume[i],
+
+			QuoteVolume:         b.QuoteAssetVolume[i],
+			Trades:              b.NumberOfTrades[i],
+			TakerBuyVolume:      b.TakerBuyBaseAssetVolume[i],
+			TakerBuyQuoteVolume: b.TakerBuyQuoteAssetVolume[i],
+		},
+		CloseTime: b.CloseTime[i],
+		Closed:    true,
+	}
+}
+
+// AppendBar adds a bar to b.
+func AppendBar(b *store.Batch, k ingest.Kline) {
+	c := k.Candle
+	b.OpenTime = append(b.OpenTime, c.OpenTime)
+	b.Open = append(b.Open, c.Open)
+	b.High = append(b.High, c.High)
+	b.Low = append(b.Low, c.Low)
+	b.Close = append(b.Close, c.Close)
+	b.Volume = append(b.Volume, c.Volume)
+	b.CloseTime = append(b.CloseTime, k.CloseTime)
+	b.QuoteAssetVolume = append(b.QuoteAssetVolume, c.QuoteVolume)
+	b.NumberOfTrades = append(b.NumberOfTrades, c.Trades)
+	b.TakerBuyBaseAssetVolume = append(b.TakerBuyBaseAssetVolume, c.TakerBuyVolume)
+	b.TakerBuyQuoteAssetVolume = append(b.TakerBuyQuoteAssetVolume, c.TakerBuyQuoteVolume)
+}
+
+// Add folds the closed candles of src in, appending to dst the bars they
+// complete. A bar is handed over once its last candle is in or once a
+// candle of a later bar comes, so bars within a gap of src are missing, as
+// they are on Binance.
+func (a *Aggregator) Add(dst, src *store.Batch) {
+	for i := 0; i < src.Len(); i++ {
+		k := Row(src, i)
+		if a.hasPending && a.Interval.Start(k.Candle.OpenTime) != a.pending.Candle.OpenTime {
+			AppendBar(dst, a.pending)
+		}
+		a.pending = a.Update(k)
+		a.hasPending = !a.pending.Closed
+		if a.pending.Closed {
+			AppendBar(dst, a.pending)
+		}
+	}
+}
+
+// Pending returns the bar Add is still filling, if any.
+func (a *Aggregator) Pending() (ingest.Kline, bool) {
+	return a.pending, a.hasPending
+}
+
+// Aggregate derives the bars of iv from the finer candles of src, sorted by
+// open time and filled. The bars at either end only hold the candles src
+// has; partial tells whether the last one is missing its end.
+func Aggregate(src *store.Batch, iv Interval) (res *store.Batch, partial bool) {
+	res = &store.Batch{}
+	a := New(iv)
+	a.Add(res, src)
+	if bar, ok := a.Pending(); ok {
+		AppendBar(res, bar)
+		partial = true
+	}
+	return res, partial
+}
+
+// Mismatch is a bar of the derived series differing from the native one.
+type Mismatch struct {
+	OpenTime int64
+	Field    string
+	Derived  float64
+	Native   float64
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s %s: derived %v, native %v",
+		time.Unix(0, m.OpenTime*int64(time.Millisecond)).UTC().Format(time.RFC3339), m.Field, m.Derived, m.Native)
+}
+
+var errNoOverlap = errors.New("no bars in common")
+
+// Compare checks the derived bars against the native ones having the same
+// open time, prices and volumes within a relative tolerance, and returns how
+// many were compared. Columns unknown on either side are skipped.
+func Compare(derived, native *store.Batch, tolerance float64) (int, []Mismatch, error) {
+	var res []Mismatch
+	compared := 0
+	j := 0
+	for i := 0; i < derived.Len(); i++ {
+		for j < native.Len() && native.OpenTime[j] < derived.OpenTime[i] {
+			j++
+		}
+		if j == native.Len() {
+			break
+		}
+		if native.OpenTime[j] != derived.OpenTime[i] {
+			continue
+		}
+		compared++
+		check := func(field string, d, n float64) {
+			if math.IsNaN(d) || math.IsNaN(n) {
+				return
+			}
+			if math.Abs(d-n) > tolerance*math.Max(math.Abs(d), math.Abs(n)) {
+				res = append(res, Mismatch{derived.OpenTime[i], field, d, n})
+			}
+		}
+		check("open", derived.Open[i], native.Open[j])
+		check("high", derived.High[i], native.High[j])
+		check("low", derived.Low[i], native.Low[j])
+		check("close", derived.Close[i], native.Close[j])
+		check("volume", derived.Volume[i], native.Volume[j])
+		check("close_time", float64(derived.CloseTime[i]), float64(native.CloseTime[j]))
+		check("quote_volume", derived.QuoteAssetVolume[i], native.QuoteAssetVolume[j])
+		if derived.NumberOfTrades[i] != 0 && native.NumberOfTrades[j] != 0 {
+			check("trades", float64(derived.NumberOfTrades[i]), float64(native.NumberOfTrades[j]))
+		}
+		check("taker_buy_volume", derived.TakerBuyBaseAssetVolume[i], native.TakerBuyBaseAssetVolume[j])
+		check("taker_buy_quote_volume", derived.TakerBuyQuoteAssetVolume[i], native.TakerBuyQuoteAssetVolume[j])
+	}
+	if compared == 0 {
+		return 0, nil, errNoOverlap
+	}
+	return compared, res, nil
+}
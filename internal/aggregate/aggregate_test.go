@@ -0,0 +1,149 @@
+package aggregate
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+)
+
+func at(s string) int64 {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return millis(t)
+}
+
+func TestStart(t *testing.T) {
+	tests := []struct {
+		interval string
+		t        string
+		start    string
+		next     string
+	}{
+		{"15m", "2020-01-13T01:07:30Z", "2020-01-13T01:00:00Z", "2020-01-13T01:15:00Z"},
+		{"90m", "2020-01-13T02:59:00Z", "2020-01-13T01:30:00Z", "2020-01-13T03:00:00Z"},
+		{"4h", "2020-01-13T03:59:59Z", "2020-01-13T00:00:00Z", "2020-01-13T04:00:00Z"},
+		// days are counted from 1970-01-01, 2020-01-13 being day 18274
+		{"2d", "2020-01-14T12:00:00Z", "2020-01-13T00:00:00Z", "2020-01-15T00:00:00Z"},
+		// weeks start on Mondays, 2020-01-13 being one
+		{"1w", "2020-01-19T23:59:00Z", "2020-01-13T00:00:00Z", "2020-01-20T00:00:00Z"},
+		{"1w", "2020-01-13T00:00:00Z", "2020-01-13T00:00:00Z", "2020-01-20T00:00:00Z"},
+		{"1M", "2020-02-29T23:00:00Z", "2020-02-01T00:00:00Z", "2020-03-01T00:00:00Z"},
+		{"3M", "2020-05-10T00:00:00Z", "2020-04-01T00:00:00Z", "2020-07-01T00:00:00Z"},
+	}
+	for _, test := range tests {
+		iv, err := ParseInterval(test.interval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := iv.Start(at(test.t))
+		if start != at(test.start) {
+			t.Errorf("%s of %s: started %d, want %s", test.interval, test.t, start, test.start)
+		}
+		if next := iv.Next(start); next != at(test.next) {
+			t.Errorf("%s of %s: next %d, want %s", test.interval, test.t, next, test.next)
+		}
+	}
+	for _, s := range []string{"", "m", "0m", "-1h", "15x", "1.5h"} {
+		if _, err := ParseInterval(s); err == nil {
+			t.Errorf("parsed %q", s)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	iv, _ := ParseInterval("3m")
+	a := New(iv)
+	m := at("2020-01-13T00:00:00Z")
+	k := func(i int, closed bool) ingest.Kline {
+		open := float64(10 + i)
+		return ingest.Kline{
+			Candle: stream.Candle{
+				OpenTime: m + int64(i)*minute,
+				Open:     open,
+				High:     open + 2,
+				Low:      open - 1,
+				Close:    open + 1,
+				Volume:   1,
+				Trades:   2,
+			},
+			CloseTime: m + int64(i+1)*minute - 1,
+			Closed:    closed,
+		}
+	}
+	// an update of the second candle, then its close, then the last one
+	a.Update(k(0, true))
+	bar := a.Update(k(1, false))
+	if bar.Candle.Volume != 2 || bar.Closed {
+		t.Errorf("in progress: volume %v, closed %v", bar.Candle.Volume, bar.Closed)
+	}
+	a.Update(k(1, true))
+	bar = a.Update(k(2, true))
+	want := [...]float64{10, 14, 9, 13, 3}
+	got := [...]float64{bar.Candle.Open, bar.Candle.High, bar.Candle.Low, bar.Candle.Close, bar.Candle.Volume}
+	if got != want || !bar.Closed || bar.Candle.OpenTime != m || bar.CloseTime != m+3*minute-1 || bar.Candle.Trades != 6 {
+		t.Errorf("closed bar: got %v %+v", got, bar)
+	}
+	// the next bar starts afresh
+	if bar = a.Update(k(3, false)); bar.Candle.Volume != 1 || bar.Candle.OpenTime != m+3*minute {
+		t.Errorf("next bar: got %+v", bar)
+	}
+}
+
+// snapshot reads a gob+gzip snapshot of data/.
+func snapshot(t *testing.T, pattern string) *store.Batch {
+	names, err := filepath.Glob(filepath.Join("..", "..", "data", pattern))
+	if err != nil || len(names) == 0 {
+		t.Fatalf("no snapshot %s", pattern)
+	}
+	f, err := os.Open(names[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := new(store.Batch)
+	if err := gob.NewDecoder(r).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+	// snapshots recorded before the order flow columns were kept lack them
+	res.Fill()
+	return res
+}
+
+// TestNative builds the native intervals from the 1m candles of the bundled
+// snapshots and compares them with the ones Binance served.
+func TestNative(t *testing.T) {
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT", "XRPUSDT"} {
+		base := snapshot(t, symbol+"_1m_old_*.gz")
+		for _, interval := range []string{"3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "12h", "1d", "1w"} {
+			iv, _ := ParseInterval(interval)
+			// Only the bars the 1m candles cover from start to end compare.
+			from := iv.Next(iv.Start(base.OpenTime[0] - 1))
+			derived, partial := Aggregate(base.Slice(from, math.MaxInt64), iv)
+			if partial && derived.Len() > 0 {
+				derived = derived.Slice(0, derived.OpenTime[derived.Len()-1]-1)
+			}
+			native := snapshot(t, symbol+"_"+interval+"_old_*.gz").Slice(from, math.MaxInt64)
+			n, mismatches, err := Compare(derived, native, 1e-9)
+			if err != nil {
+				t.Fatalf("%s_%s: %v", symbol, interval, err)
+			}
+			if len(mismatches) > 0 {
+				t.Errorf("%s_%s: %d of %d candles differ, first %s", symbol, interval, len(mismatches), n, mismatches[0])
+			}
+		}
+	}
+}
@@ -3,26 +3,37 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/binance/mock"
 	"cryptoapi/internal/cache"
-	"cryptoapi/internal/helpers"
+	"cryptoapi/internal/execution"
+	"cryptoapi/internal/hub"
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/ingest"
 	"cryptoapi/internal/logging"
-	"cryptoapi/internal/talib"
+	"cryptoapi/internal/paper"
+	"cryptoapi/internal/risk"
+	"cryptoapi/internal/rules"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+	"cryptoapi/internal/universe"
 	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/spf13/viper"
-	"io"
 	"math"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 )
 
 var (
-	Tickers   = []string{"BTCUSDT", "ETHUSDT", "XRPUSDT"}
 	Intervals = []string{"1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "12h", "1d", "1w", "1M"}
 )
 
@@ -47,14 +58,34 @@ type BinanceData struct {
 	Data []BinanceKlineData
 }
 
+// klineData has the columns of store.Batch, so the two convert into each
+// other.
 type klineData struct {
-	OpenTime  []int64
-	Open      []float64
-	High      []float64
-	Low       []float64
-	Close     []float64
-	Volume    []float64
-	CloseTime []int64
+	OpenTime                 []int64
+	Open                     []float64
+	High                     []float64
+	Low                      []float64
+	Close                    []float64
+	Volume                   []float64
+	CloseTime                []int64
+	QuoteAssetVolume         []float64
+	NumberOfTrades           []int64
+	TakerBuyBaseAssetVolume  []float64
+	TakerBuyQuoteAssetVolume []float64
+}
+
+// Series returns the column an indicator input reads.
+func (d *klineData) Series(in indicator.Input) []float64 {
+	return backtest.Candles{Batch: d.batch()}.Series(in)
+}
+
+// Candles returns the klines as candles for seeding streaming indicators.
+func (d *klineData) Candles() []stream.Candle {
+	res := make([]stream.Candle, d.len())
+	for i := range res {
+		res[i] = aggregate.Row(d.batch(), i).Candle
+	}
+	return res
 }
 
 //func (binanceData *BinanceData) UnmarshalJSON(data [] byte) error {
@@ -77,7 +108,14 @@ func (d *klineData) UnmarshalJSON(data [] byte) error {
 	d.Close = make([]float64, len(v))
 	d.Volume = make([]float64, len(v))
 	d.CloseTime = make([]int64, len(v))
+	d.QuoteAssetVolume = make([]float64, len(v))
+	d.NumberOfTrades = make([]int64, len(v))
+	d.TakerBuyBaseAssetVolume = make([]float64, len(v))
+	d.TakerBuyQuoteAssetVolume = make([]float64, len(v))
 	for i := 0; i < len(v); i++ {
+		if len(v[i]) < 11 {
+			return errors.New("kline with missing fields")
+		}
 		openTime, err := v[i][0].(json.Number).Int64()
 		if err != nil {
 			return err
@@ -106,6 +144,22 @@ func (d *klineData) UnmarshalJSON(data [] byte) error {
 		if err != nil {
 			return err
 		}
+		quoteAssetVolume, err := strconv.ParseFloat(v[i][7].(string), 64)
+		if err != nil {
+			return err
+		}
+		numberOfTrades, err := v[i][8].(json.Number).Int64()
+		if err != nil {
+			return err
+		}
+		takerBuyBaseAssetVolume, err := strconv.ParseFloat(v[i][9].(string), 64)
+		if err != nil {
+			return err
+		}
+		takerBuyQuoteAssetVolume, err := strconv.ParseFloat(v[i][10].(string), 64)
+		if err != nil {
+			return err
+		}
 		d.OpenTime[i] = openTime
 		d.Open[i] = open
 		d.High[i] = high
@@ -113,26 +167,101 @@ func (d *klineData) UnmarshalJSON(data [] byte) error {
 		d.Close[i] = close
 		d.Volume[i] = volume
 		d.CloseTime[i] = closeTime
+		d.QuoteAssetVolume[i] = quoteAssetVolume
+		d.NumberOfTrades[i] = numberOfTrades
+		d.TakerBuyBaseAssetVolume[i] = takerBuyBaseAssetVolume
+		d.TakerBuyQuoteAssetVolume[i] = takerBuyQuoteAssetVolume
 	}
 	return nil
 }
 
 type CryptoAPI struct {
-	HttpClient *http.Client
+	Binance *binance.Client
 	*logging.Logger
-	Cache *cache.Cache
-	Delay time.Duration
+	Cache    *cache.Cache
+	Delay    time.Duration
+	Universe *universe.Universe
+	Store    *store.Store
+	Rules    *rules.Engine
+	// nil unless paper trading is enabled
+	Paper *paper.Trader
+	// nil unless execution is enabled
+	Executor *execution.Executor
+	// websocket clients the signals, candles and indicators are pushed to
+	Hub *hub.Hub
+
+	mu      sync.Mutex
+	tickers map[string]*tickerState
+	streams *ingest.Client
+	// bars of the derived intervals in progress, by ticker key
+	live     map[string]*liveBar
+	handlers []func(rules.Signal)
+	// the mock exchange execution trades on, fed with the streamed prices
+	exchange *mock.Exchange
+	// risk gates of the paper and live accounts
+	gates          []*risk.Gate
+	rejectHandlers []func(risk.Rejection)
+	// serializes the updates of the cached candles, by ticker key
+	updating map[string]*sync.Mutex
+	// hub.indicators, pushed on every closed candle
+	indicators []*rules.Expr
+	// serializes the saves of the signal, paper, execution and risk states
+	saving sync.Mutex
 }
 
 func New(logger *logging.Logger, cache *cache.Cache, delay time.Duration) *CryptoAPI {
+	cryptoapi := &CryptoAPI{
+		Binance: binance.New(viper.GetString("binance-rest"), &http.Client{Timeout: 10 * time.Second}),
+		Logger:  logger,
+		Cache:   cache,
+		Delay:   delay,
+		Store:   store.New(dataPath("klines")),
+		tickers: make(map[string]*tickerState),
+		live:    make(map[string]*liveBar),
+	}
+	cryptoapi.Universe = universe.New(cryptoapi.Binance, universe.FilterFromConfig(), logger, cryptoapi.universeChanged)
+	signals, err := rules.FromConfig()
+	if err != nil {
+		logger.WithError(err).Debug("failed reading the signal rules, none will fire")
+	}
+	cryptoapi.Rules = rules.New(signals, logger)
+	if snapshot, err := loadSignals(); err != nil {
+		logger.WithError(err).Debug("failed loading the signal state, rules start armed")
+	} else {
+		cryptoapi.Rules.Restore(snapshot)
+	}
+	cryptoapi.startHub()
+	if viper.GetBool("paper.enabled") {
+		cryptoapi.startPaper()
+	}
+	if viper.GetBool("execution.enabled") {
+		cryptoapi.startExecution()
+	}
+	return cryptoapi
+}
+
+// NewHistory returns a CryptoAPI with only what the history commands need:
+// the binance client, the store and the universe. Nothing is streamed,
+// restored or started.
+func NewHistory(logger *logging.Logger) *CryptoAPI {
+	client := binance.New(viper.GetString("binance-rest"), &http.Client{Timeout: 10 * time.Second})
 	return &CryptoAPI{
-		HttpClient: &http.Client{},
-		Logger:     logger,
-		Cache:      cache,
-		Delay:      delay,
+		Binance:  client,
+		Logger:   logger,
+		Universe: universe.New(client, universe.FilterFromConfig(), logger, nil),
+		Store:    store.New(dataPath("klines")),
 	}
 }
 
+// Symbols are the tickers followed: the universe once loaded, the ones the
+// config always includes before.
+func (cryptoapi *CryptoAPI) Symbols() []string {
+	if symbols := cryptoapi.Universe.Symbols(); len(symbols) > 0 {
+		return symbols
+	}
+	return universe.FilterFromConfig().Include
+}
+
 func (cryptoapi *CryptoAPI) FormatTickerKey(ticker, interval string) string {
 	return fmt.Sprintf("%s_%s", ticker, interval)
 }
@@ -141,24 +270,7 @@ func (cryptoapi *CryptoAPI) CollectDataFromBinance(ticker, interval string, endT
 	if ticker == "" || interval == "" {
 		return nil, errors.New("parameters not provided")
 	}
-	var url string
-	if endTime == 0 {
-		url = fmt.Sprintf(viper.GetString("binance-without-endtime"), ticker, interval)
-	} else {
-		url = fmt.Sprintf(viper.GetString("binance-with-endtime"), ticker, interval, endTime)
-	}
-	resp, err := cryptoapi.HttpClient.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	b := new(bytes.Buffer)
-	if _, err := io.Copy(b, resp.Body); err != nil {
-		return nil, err
-	}
-	if err := resp.Body.Close(); err != nil {
-		return nil, err
-	}
-	return b.Bytes(), nil
+	return cryptoapi.Binance.Klines(context.Background(), ticker, interval, 0, endTime, binance.MaxKlines)
 }
 
 func processData(raw []byte) (*klineData, error) {
@@ -169,150 +281,62 @@ func processData(raw []byte) (*klineData, error) {
 	return data, nil
 }
 
-//func RetryFunc(ticker, interval string, fn CryptoGetDataFromBinance) (*BinanceData, error) {
-func RetryFunc(ticker, interval string, endTime int64, fn CryptoGetDataFromBinance) (*klineData, error) {
-	count := 0
-	t := time.NewTimer(time.Second)
-	for {
-		d, err := fn(ticker, interval, endTime)
-		if err == nil {
-			d2, err := processData(d)
-			if err == nil {
-				return d2, nil
-			}
-		}
-		fmt.Printf("%s_%s failed: count: %d, error: %s", ticker, interval, count, err.Error())
-		count++
-		t.Reset(time.Second * time.Duration(count))
-		if count >= 5 {
-			return nil, errors.New("too many attempts")
-		}
-		<-t.C
-	}
-}
-
-func createFileAndWrite(name string, data *klineData) error {
-	cwd, err := os.Getwd()
+// FetchKlines fetches and decodes the klines of a ticker ending at endTime,
+// the latest ones when zero. Retries are left to the binance client.
+func FetchKlines(ticker, interval string, endTime int64, fn CryptoGetDataFromBinance) (*klineData, error) {
+	raw, err := fn(ticker, interval, endTime)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("fetching %s_%s: %w", ticker, interval, err)
 	}
-	if err := helpers.CreateDirIfNotExist(viper.GetString("base.data.folder")); err != nil {
-		return err
-	}
-	fullPath := filepath.Join(cwd, viper.GetString("base.data.folder"), fmt.Sprintf("%s.gz", name))
-	f, err := os.OpenFile(fullPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	data, err := processData(raw)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("decoding %s_%s: %w", ticker, interval, err)
 	}
-	b := new(bytes.Buffer)
-	if err := gob.NewEncoder(b).Encode(data); err != nil {
-		return err
-	}
-	gzipWriter, err := gzip.NewWriterLevel(f, gzip.BestCompression)
-	if err != nil {
-		return err
-	}
-	if _, err := gzipWriter.Write(b.Bytes()); err != nil {
-		return err
-	}
-	if err := gzipWriter.Close(); err != nil {
-		return err
-	}
-	fmt.Println("written", f.Name())
-	return nil
+	return data, nil
 }
+
 func ReadDataFromFile(name string) (*klineData, error) {
 	f, err := os.OpenFile(name, os.O_RDONLY, 0777)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 	gzipReader, err := gzip.NewReader(f)
 	if err != nil {
 		return nil, err
 	}
-	if err := gzipReader.Close(); err != nil {
-		return nil, err
-	}
 	data := new(klineData)
 	if err := gob.NewDecoder(gzipReader).Decode(data); err != nil {
 		return nil, err
 	}
-	return data, nil
-}
-
-func findFile(pattern string) (string, error) {
-	fullPath := fmt.Sprintf("%s/%s", viper.GetString("base.data.folder"), pattern)
-	m, err := filepath.Glob(fullPath)
-	if err != nil {
-		return "", err
-	}
-	if m[0] == "" {
-		return "", errors.New("could not find file")
+	if err := gzipReader.Close(); err != nil {
+		return nil, err
 	}
-	return m[0], nil
+	// files written before the order flow columns were kept lack them
+	data.batch().Fill()
+	return data, nil
 }
 
 func (cryptoapi *CryptoAPI) LoadIntoCache() {
-	for i := 0; i < len(Tickers); i++ {
+	tickers := cryptoapi.Symbols()
+	for i := 0; i < len(tickers); i++ {
 		for j := 0; j < len(Intervals); j++ {
-			f, err := findFile(fmt.Sprintf("%s_%s_*.gz", Tickers[i], Intervals[j]))
+			b, err := cryptoapi.Store.Read(tickers[i], Intervals[j], 0, math.MaxInt64)
 			if err != nil {
-				cryptoapi.WithError(err).Debug("findFile error, will continue")
+				cryptoapi.WithError(err).Debug("failed reading data from the store, will continue")
 				continue
 			}
-			t1, err := ReadDataFromFile(f)
-			if err != nil {
-				cryptoapi.WithError(err).Debug("failed reading data from file, will continue")
-				continue
-			}
-			fn := fmt.Sprintf("%s_%s_old", Tickers[i], Intervals[j])
+			t1 := (*klineData)(b)
+			fn := fmt.Sprintf("%s_%s_old", tickers[i], Intervals[j])
 			cryptoapi.Cache.Set(fn, t1)
 		}
 	}
 }
 
-// StartOldData collects old data from Binance
+// CollectOldData brings the stored history of every symbol up to date.
 func (cryptoapi *CryptoAPI) CollectOldData() {
-	if err := helpers.DeleteDir(); err != nil {
-		fmt.Println(err)
-		return
-	}
-	d := new(klineData)
-	var lastOpenTime int64 = 0
-	for i := 0; i < len(Tickers); i++ {
-		for j := 0; j < len(Intervals); j++ {
-			cryptoapi.Debugf("processing %s_%s", Tickers[i], Intervals[j])
-			lastOpenTime = 0
-			d = new(klineData)
-			for {
-				data, err := RetryFunc(Tickers[i], Intervals[j], lastOpenTime, cryptoapi.CollectDataFromBinance)
-				if err != nil {
-					fmt.Println("MUIE", err)
-					cryptoapi.WithError(err).Debugf("%s_%s failed too many times", Tickers[i], Intervals[i])
-					continue
-				}
-				if lastOpenTime == data.OpenTime[0] || len(d.OpenTime) >= 50000 {
-					fmt.Println("WRITING", Tickers[i], Intervals[j])
-					if err := createFileAndWrite(fmt.Sprintf("%s_%s_old_%d", Tickers[i], Intervals[j], time.Now().Unix()), d); err != nil {
-						fmt.Println("PIZDA", err)
-						d = new(klineData)
-						cryptoapi.WithError(err).Debugf("failed creating file: %s_%s_%d", Tickers[i], Intervals[j], time.Now().Unix())
-						//continue
-						break
-					}
-					d = new(klineData)
-					break
-				}
-				lastOpenTime = data.OpenTime[0]
-				d.OpenTime = append(data.OpenTime[:len(data.OpenTime)-1], d.OpenTime...)
-				d.Open = append(data.Open[:len(data.Open)-1], d.Open...)
-				d.High = append(data.High[:len(data.High)-1], d.High...)
-				d.Low = append(data.Low[:len(data.Low)-1], d.Low...)
-				d.Close = append(data.Close[:len(data.Close)-1], d.Close...)
-				d.Volume = append(data.Volume[:len(data.Volume)-1], d.Volume...)
-				d.CloseTime = append(data.CloseTime[:len(data.CloseTime)-1], d.CloseTime...)
-			}
-		}
+	if err := cryptoapi.BackfillHistory(context.Background(), cryptoapi.Symbols(), Intervals, time.Time{}, time.Time{}); err != nil {
+		cryptoapi.WithError(err).Debug("failed collecting old data")
 	}
 }
 func rev(s []float64) {
@@ -323,18 +347,18 @@ func rev(s []float64) {
 
 // Start collects recent data from binance
 func (cryptoapi *CryptoAPI) CollectData() {
+	tickers := cryptoapi.Symbols()
 	timer := time.NewTicker(cryptoapi.Delay)
-	for i := 0; i < len(Tickers); i++ {
+	for i := 0; i < len(tickers); i++ {
 		for j := 0; j < len(Intervals); j++ {
-			cryptoapi.Debugf("processing %s_%s", Tickers[i], Intervals[j])
-			data, err := RetryFunc(Tickers[i], Intervals[j], 0, cryptoapi.CollectDataFromBinance)
+			cryptoapi.Debugf("processing %s_%s", tickers[i], Intervals[j])
+			data, err := FetchKlines(tickers[i], Intervals[j], 0, cryptoapi.CollectDataFromBinance)
 			if err != nil {
-				cryptoapi.WithError(err).Debugf("%s_%s failed too many times, will skip", Tickers[i], Intervals[i])
+				cryptoapi.WithError(err).Debugf("%s_%s failed, will skip", tickers[i], Intervals[j])
 				continue
 			}
-			key := cryptoapi.FormatTickerKey(Tickers[i], Intervals[j])
-			cryptoapi.CalculateIndicators(data)
-			cryptoapi.Cache.Set(key, data)
+			cryptoapi.setSeries(tickers[i], Intervals[j], data)
+			cryptoapi.evaluate(tickers[i], Intervals[j], data.closedBefore(time.Now().UnixNano()/int64(time.Millisecond)))
 			<-timer.C
 		}
 	}
@@ -345,109 +369,3 @@ func (cryptoapi *CryptoAPI) StartWithLoop() {
 		cryptoapi.CollectData()
 	}
 }
-
-func (cryptoapi *CryptoAPI) CalculateIndicators(data *klineData) {
-	rsi14 := talib.Rsi(data.Close, 14)
-	if rsi14[len(rsi14)-1] < 30 {
-		// send to subscribers
-	}
-	if rsi14[len(rsi14)-1] > 70 {
-		// send to subs
-	}
-}
-
-func (cryptoapi *CryptoAPI) TestRSI(ticker string, balance float64, tradeSize int64, stopLossPercent float64, buySignal, sellSignal float64) {
-	t := cryptoapi.Cache.Get(ticker)
-	tickerData := t.(*klineData)
-
-	var currentBalance = balance
-	var boughtAt float64
-	var soldAt float64
-	var orderPlaced bool
-	profitPercent := 0.00
-	maxDif := 5.0
-
-	rsi14 := talib.Rsi(tickerData.Close, 14)
-	for i := 8; i < len(tickerData.Close); i++ {
-		if stopLossPercent != 0.00 && orderPlaced == true {
-			//onePercent := boughtAt * 0.01
-			onePercent := boughtAt * (stopLossPercent / 100)
-			stopLossPrice := boughtAt - onePercent
-			if tickerData.Close[i] <= stopLossPrice {
-				orderPlaced = false
-				soldAt = tickerData.Close[i]
-				currentBalance = currentBalance - (float64(tradeSize) * (stopLossPercent / 100))
-				cryptoapi.Debugf("date: %s, stoploss hit at: %.2f, trade size: $%.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0), stopLossPrice, float64(tradeSize), currentBalance)
-			}
-		}
-		if rsi14[i] <= buySignal+maxDif && rsi14[i] >= buySignal-maxDif {
-			if orderPlaced {
-				cryptoapi.Debug("an order is already open")
-				continue
-			}
-			orderPlaced = true
-			boughtAt = tickerData.Close[i]
-			cryptoapi.Debugf("date: %s, bought at: %.2f, rsi (14): %.2f, trade size: $%.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0), boughtAt, rsi14[i], float64(tradeSize), currentBalance)
-		} else if rsi14[i] <= sellSignal+maxDif && rsi14[i] >= sellSignal-maxDif {
-			if orderPlaced {
-				orderPlaced = false
-				soldAt = tickerData.Close[i]
-				profitPercent = ((soldAt - boughtAt) / boughtAt) * 100
-				if profitPercent > 0.00 {
-					currentBalance = currentBalance + ((float64(tradeSize) * profitPercent) / 100)
-				} else if profitPercent < 0.00 {
-					currentBalance = currentBalance - ((float64(tradeSize) * math.Abs(profitPercent)) / 100)
-				}
-				cryptoapi.Debugf("date: %s, sold at: %.2f, rsi (14): %.2f, trade size: $%.2f, profit: $%0.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0),
-					soldAt, rsi14[i], float64(tradeSize), ((profitPercent * float64(tradeSize)) / 100), currentBalance)
-			}
-		}
-	}
-}
-func (cryptoapi *CryptoAPI) TestEngulfing(ticker string, balance float64, tradeSize int64, stopLossPercent float64) {
-	t := cryptoapi.Cache.Get(ticker)
-	tickerData := t.(*klineData)
-
-	var currentBalance = balance
-	var boughtAt float64
-	var soldAt float64
-	var orderPlaced bool
-	profitPercent := 0.00
-
-	engulfing := talib.CdlEngulfing(tickerData.Open, tickerData.High, tickerData.Low, tickerData.Close)
-	for i := 8; i < len(tickerData.Close); i++ {
-		if stopLossPercent != 0.00 && orderPlaced == true {
-			//onePercent := boughtAt * 0.01
-			onePercent := boughtAt * (stopLossPercent / 100)
-			stopLossPrice := boughtAt - onePercent
-			if tickerData.Close[i] <= stopLossPrice {
-				orderPlaced = false
-				soldAt = tickerData.Close[i]
-				currentBalance = currentBalance - (float64(tradeSize) * (stopLossPercent / 100))
-				cryptoapi.Debugf("date: %s, stoploss hit at: %.2f, trade size: $%.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0), stopLossPrice, float64(tradeSize), currentBalance)
-			}
-		}
-		if engulfing[i] > 0 {
-			if orderPlaced {
-				cryptoapi.Debug("an order is already open")
-				continue
-			}
-			orderPlaced = true
-			boughtAt = tickerData.Close[i]
-			cryptoapi.Debugf("date: %s, bought at: %.2f, engulfing: %d, trade size: $%.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0), boughtAt, engulfing[i], float64(tradeSize), currentBalance)
-		} else if engulfing[i] < 0 {
-			if orderPlaced {
-				orderPlaced = false
-				soldAt = tickerData.Close[i]
-				profitPercent = ((soldAt - boughtAt) / boughtAt) * 100
-				if profitPercent > 0.00 {
-					currentBalance = currentBalance + ((float64(tradeSize) * profitPercent) / 100)
-				} else if profitPercent < 0.00 {
-					currentBalance = currentBalance - ((float64(tradeSize) * math.Abs(profitPercent)) / 100)
-				}
-				cryptoapi.Debugf("date: %s, sold at: %.2f, engulfing: %d, trade size: $%.2f, profit: $%0.2f, current balance: $%.2f", time.Unix(tickerData.CloseTime[i]/1000, 0),
-					soldAt, engulfing[i], float64(tradeSize), ((profitPercent * float64(tradeSize)) / 100), currentBalance)
-			}
-		}
-	}
-}
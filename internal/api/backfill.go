@@ -0,0 +1,366 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/store"
+
+	"github.com/spf13/viper"
+)
+
+// checkpointPages is how many pages are fetched between two writes of a
+// series to the store, bounding what a crash loses.
+const checkpointPages = 20
+
+// progress is what the backfill remembers about a series besides its
+// candles: where the exchange history starts, the ranges the exchange has
+// no candles for, so they are not fetched again, and how far the series is
+// known to be complete, so the stored candles before are not scanned again.
+type progress struct {
+	FirstOpenTime int64      `json:"first_open_time"`
+	Holes         [][2]int64 `json:"holes"`
+	// every candle opened before is stored or in a hole
+	Complete int64 `json:"complete,omitempty"`
+}
+
+// addHole adds h to the holes, merging it with those it overlaps or
+// touches, and returns them sorted.
+func addHole(holes [][2]int64, h [2]int64) [][2]int64 {
+	res := make([][2]int64, 0, len(holes)+1)
+	for _, o := range holes {
+		if o[1]+1 < h[0] || h[1]+1 < o[0] {
+			res = append(res, o)
+			continue
+		}
+		if o[0] < h[0] {
+			h[0] = o[0]
+		}
+		if o[1] > h[1] {
+			h[1] = o[1]
+		}
+	}
+	res = append(res, h)
+	sort.Slice(res, func(i, j int) bool { return res[i][0] < res[j][0] })
+	return res
+}
+
+func dataPath(name string) string {
+	return filepath.Join(viper.GetString("base.data.folder"), name)
+}
+
+// writeFileAtomic replaces name only once the new content is on disk, so a
+// crash leaves either the old or the new version.
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func loadProgress() (map[string]*progress, error) {
+	res := make(map[string]*progress)
+	b, err := ioutil.ReadFile(dataPath("backfill.json"))
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func saveProgress(p map[string]*progress) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(dataPath("backfill.json"), b)
+}
+
+// batch is the store view of a series, which has the same columns.
+func (d *klineData) batch() *store.Batch {
+	return (*store.Batch)(d)
+}
+
+func (d *klineData) len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.OpenTime)
+}
+
+// mergeKlines merges two series sorted by open time into a new one, b
+// winning over a for the candles both have.
+func mergeKlines(a, b *klineData) *klineData {
+	res := new(klineData)
+	i, j := 0, 0
+	for i < a.len() || j < b.len() {
+		switch {
+		case j == b.len() || (i < a.len() && a.OpenTime[i] < b.OpenTime[j]):
+			res.appendFrom(a, i)
+			i++
+		case i < a.len() && a.OpenTime[i] == b.OpenTime[j]:
+			i++
+		default:
+			res.appendFrom(b, j)
+			j++
+		}
+	}
+	return res
+}
+
+func (d *klineData) appendFrom(src *klineData, i int) {
+	d.OpenTime = append(d.OpenTime, src.OpenTime[i])
+	d.Open = append(d.Open, src.Open[i])
+	d.High = append(d.High, src.High[i])
+	d.Low = append(d.Low, src.Low[i])
+	d.Close = append(d.Close, src.Close[i])
+	d.Volume = append(d.Volume, src.Volume[i])
+	d.CloseTime = append(d.CloseTime, src.CloseTime[i])
+	d.QuoteAssetVolume = append(d.QuoteAssetVolume, src.QuoteAssetVolume[i])
+	d.NumberOfTrades = append(d.NumberOfTrades, src.NumberOfTrades[i])
+	d.TakerBuyBaseAssetVolume = append(d.TakerBuyBaseAssetVolume, src.TakerBuyBaseAssetVolume[i])
+	d.TakerBuyQuoteAssetVolume = append(d.TakerBuyQuoteAssetVolume, src.TakerBuyQuoteAssetVolume[i])
+}
+
+// closedBefore drops the candles still open at now.
+func (d *klineData) closedBefore(now int64) *klineData {
+	n := sort.Search(d.len(), func(i int) bool {
+		return d.CloseTime[i] >= now
+	})
+	if n == d.len() {
+		return d
+	}
+	res := new(klineData)
+	for i := 0; i < n; i++ {
+		res.appendFrom(d, i)
+	}
+	return res
+}
+
+// nextOpenTime is the open time of the candle after the one opened at t.
+func nextOpenTime(t int64, interval string) int64 {
+	if interval[len(interval)-1] == 'M' {
+		months, _ := strconv.Atoi(interval[:len(interval)-1])
+		return time.Unix(0, t*int64(time.Millisecond)).UTC().AddDate(0, months, 0).UnixNano() / int64(time.Millisecond)
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		// days and weeks, which time.ParseDuration does not know
+		n, _ := strconv.Atoi(interval[:len(interval)-1])
+		d = time.Duration(n) * 24 * time.Hour
+		if interval[len(interval)-1] == 'w' {
+			d *= 7
+		}
+	}
+	return t + int64(d/time.Millisecond)
+}
+
+// missingRanges lists the [from, to] open time ranges of the stored series
+// lacking candles within [start, end], leaving out the known holes. Only
+// the partitions of [start, end] are read, a month at a time.
+func (cryptoapi *CryptoAPI) missingRanges(symbol, interval string, start, end int64, holes [][2]int64) ([][2]int64, int, error) {
+	sorted := append([][2]int64(nil), holes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+	var res [][2]int64
+	add := func(from, to int64) {
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		for _, h := range sorted {
+			if h[1] < from || h[0] > to {
+				continue
+			}
+			if h[0] > from {
+				res = append(res, [2]int64{from, h[0] - 1})
+			}
+			from = h[1] + 1
+		}
+		if from <= to {
+			res = append(res, [2]int64{from, to})
+		}
+	}
+	stored := 0
+	var prev int64
+	err := cryptoapi.Store.Scan(symbol, interval, start, end, func(b *store.Batch) error {
+		for _, t := range b.OpenTime {
+			if stored == 0 {
+				add(start, t-1)
+			} else if expected := nextOpenTime(prev, interval); t > expected {
+				add(expected, t-1)
+			}
+			prev = t
+			stored++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if stored == 0 {
+		add(start, end)
+	} else {
+		add(nextOpenTime(prev, interval), end)
+	}
+	return res, stored, nil
+}
+
+// BackfillHistory brings the stored history of every symbol and interval up
+// to date between from and to, a zero from meaning since the listing and a
+// zero to meaning now. Only the missing ranges are downloaded and progress
+// is saved as it goes, so an interrupted run resumes where it stopped.
+func (cryptoapi *CryptoAPI) BackfillHistory(ctx context.Context, symbols, intervals []string, from, to time.Time) error {
+	if err := os.MkdirAll(dataPath(""), 0755); err != nil {
+		return err
+	}
+	state, err := loadProgress()
+	if err != nil {
+		return err
+	}
+	for _, symbol := range symbols {
+		for _, interval := range intervals {
+			if err := cryptoapi.backfillSeries(ctx, state, symbol, interval, from, to); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				cryptoapi.WithError(err).Debugf("%s_%s backfill failed, will continue", symbol, interval)
+			}
+		}
+	}
+	return nil
+}
+
+func (cryptoapi *CryptoAPI) backfillSeries(ctx context.Context, state map[string]*progress, symbol, interval string, from, to time.Time) error {
+	key := cryptoapi.FormatTickerKey(symbol, interval)
+	p, ok := state[key]
+	if !ok {
+		p = new(progress)
+		state[key] = p
+	}
+	// The oldest candle Binance has marks the listing.
+	if p.FirstOpenTime == 0 {
+		raw, err := cryptoapi.Binance.Klines(ctx, symbol, interval, 1, 0, 1)
+		if err != nil {
+			return err
+		}
+		first, err := processData(raw)
+		if err != nil {
+			return err
+		}
+		p.FirstOpenTime = first.OpenTime[0]
+		if err := saveProgress(state); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	start, end := p.FirstOpenTime, now
+	if ms := from.UnixNano() / int64(time.Millisecond); !from.IsZero() && ms > start {
+		start = ms
+	}
+	if ms := to.UnixNano() / int64(time.Millisecond); !to.IsZero() && ms < end {
+		end = ms
+	}
+
+	// What is before the complete mark needs no scanning, as long as the
+	// scan starts within the part it covers.
+	scanFrom := start
+	if p.Complete > start {
+		scanFrom = p.Complete
+	}
+	missing, stored, err := cryptoapi.missingRanges(symbol, interval, scanFrom, end, p.Holes)
+	if err != nil {
+		return err
+	}
+	cryptoapi.Debugf("%s: %d candles scanned, %d ranges missing", key, stored, len(missing))
+
+	// Pages are written to the store in checkpoints rather than one by one,
+	// each write rewriting a month partition.
+	pending := new(klineData)
+	flush := func() error {
+		if err := cryptoapi.Store.Write(symbol, interval, pending.batch()); err != nil {
+			return err
+		}
+		pending = new(klineData)
+		return nil
+	}
+	// fail keeps the pages fetched so far before giving up
+	fail := func(err error) error {
+		if err := flush(); err != nil {
+			cryptoapi.WithError(err).Debugf("failed saving %s", key)
+		}
+		return err
+	}
+	// Gaps the exchange has nothing for, e.g. a maintenance window, are
+	// remembered as holes rather than asked for again on every run, once
+	// they are old enough for Binance to have caught up. The series is
+	// complete up to the first gap that is not.
+	complete := end + 1
+	gap := func(from, to int64) {
+		if to < now-int64(time.Hour/time.Millisecond) {
+			p.Holes = addHole(p.Holes, [2]int64{from, to})
+		} else if from < complete {
+			complete = from
+		}
+	}
+	pages := 0
+	for _, r := range missing {
+		next := r[0]
+		for next <= r[1] {
+			raw, err := cryptoapi.Binance.Klines(ctx, symbol, interval, next, r[1], binance.MaxKlines)
+			if err != nil {
+				return fail(err)
+			}
+			// an empty page is how Binance says there is nothing left
+			if bytes.Equal(bytes.TrimSpace(raw), []byte("[]")) {
+				break
+			}
+			page, err := processData(raw)
+			if err != nil {
+				return fail(err)
+			}
+			page = page.closedBefore(now)
+			if page.len() == 0 {
+				break
+			}
+			for _, t := range page.OpenTime {
+				if t > next {
+					gap(next, t-1)
+				}
+				next = nextOpenTime(t, interval)
+			}
+			pending = mergeKlines(pending, page)
+			if pages++; pages%checkpointPages == 0 {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if next <= r[1] {
+			gap(next, r[1])
+		}
+	}
+	if scanFrom == p.Complete || scanFrom <= p.FirstOpenTime {
+		p.Complete = complete
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return saveProgress(state)
+}
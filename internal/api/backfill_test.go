@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/store"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const hour = int64(time.Hour / time.Millisecond)
+
+// candlesAt returns candles opened at the given times.
+func candlesAt(times ...int64) *klineData {
+	var res *klineData
+	for _, t := range times {
+		res = res.merge(kline(t, 1))
+	}
+	return res
+}
+
+func TestAddHole(t *testing.T) {
+	holes := [][2]int64{{10, 19}, {40, 49}}
+	for _, tt := range []struct {
+		hole [2]int64
+		want [][2]int64
+	}{
+		{[2]int64{0, 5}, [][2]int64{{0, 5}, {10, 19}, {40, 49}}},
+		{[2]int64{25, 29}, [][2]int64{{10, 19}, {25, 29}, {40, 49}}},
+		{[2]int64{20, 29}, [][2]int64{{10, 29}, {40, 49}}},
+		{[2]int64{15, 45}, [][2]int64{{10, 49}}},
+		{[2]int64{12, 14}, [][2]int64{{10, 19}, {40, 49}}},
+		{[2]int64{0, 100}, [][2]int64{{0, 100}}},
+	} {
+		if got := addHole(holes, tt.hole); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("adding %v: got %v, want %v", tt.hole, got, tt.want)
+		}
+	}
+	if !reflect.DeepEqual(holes, [][2]int64{{10, 19}, {40, 49}}) {
+		t.Errorf("the holes given changed to %v", holes)
+	}
+}
+
+func TestMissingRanges(t *testing.T) {
+	cryptoapi := &CryptoAPI{Store: store.New(t.TempDir())}
+	// hours 1 to 9 but 3, 4 and 7
+	if err := cryptoapi.Store.Write("BTCUSDT", "1h", candlesAt(1*hour, 2*hour, 5*hour, 6*hour, 8*hour, 9*hour).batch()); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name       string
+		start, end int64
+		holes      [][2]int64
+		want       [][2]int64
+		stored     int
+	}{
+		{"all", 0, 12 * hour, nil,
+			[][2]int64{{0, hour - 1}, {3 * hour, 5*hour - 1}, {7 * hour, 8*hour - 1}, {10 * hour, 12 * hour}}, 6},
+		{"holes", 0, 12 * hour, [][2]int64{{7 * hour, 8*hour - 1}, {3 * hour, 5*hour - 1}},
+			[][2]int64{{0, hour - 1}, {10 * hour, 12 * hour}}, 6},
+		{"part of a gap in a hole", 0, 9 * hour, [][2]int64{{3*hour + 1, 4 * hour}},
+			[][2]int64{{0, hour - 1}, {3 * hour, 3 * hour}, {4*hour + 1, 5*hour - 1}, {7 * hour, 8*hour - 1}}, 6},
+		{"window", 5 * hour, 8 * hour, nil, [][2]int64{{7 * hour, 8*hour - 1}}, 3},
+		{"window in a gap", 3 * hour, 4 * hour, nil, [][2]int64{{3 * hour, 4 * hour}}, 0},
+	} {
+		got, n, err := cryptoapi.missingRanges("BTCUSDT", "1h", tt.start, tt.end, tt.holes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || n != tt.stored {
+			t.Errorf("%s: got %v and %d stored, want %v and %d", tt.name, got, n, tt.want, tt.stored)
+		}
+	}
+}
+
+// exchange serves the hourly klines opened at listed+i hours, up to now,
+// but for the missing ones. It records the start times asked for, leaving
+// out the first request, which looks for the listing.
+func exchange(t *testing.T, listed int64, missing map[int]bool) (*binance.Client, *[]int64) {
+	var starts []int64
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if atomic.AddInt32(&requests, 1) > 1 {
+			starts = append(starts, start)
+		}
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		var rows []string
+		for i := 0; listed+int64(i)*hour <= now && len(rows) < limit; i++ {
+			open := listed + int64(i)*hour
+			if missing[i] || open < start || (end != 0 && open > end) {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf(`[%d,"1","1","1","1","1",%d,"1",1,"1","1","0"]`, open, open+hour-1))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(rows, ","))
+	}))
+	t.Cleanup(srv.Close)
+	return binance.New(srv.URL, nil), &starts
+}
+
+func TestBackfillSeries(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("base.data.folder", dir)
+	t.Cleanup(func() { viper.Set("base.data.folder", "") })
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	forming := now - now%hour
+	listed := forming - 100*hour
+	// two maintenance windows, one of them three hours long
+	client, starts := exchange(t, listed, map[int]bool{10: true, 11: true, 12: true, 50: true})
+	cryptoapi := &CryptoAPI{
+		Binance: client,
+		Logger:  &logging.Logger{Logger: logrus.New()},
+		Store:   store.New(dir),
+	}
+	ctx := context.Background()
+	state := map[string]*progress{}
+	if err := cryptoapi.backfillSeries(ctx, state, "BTCUSDT", "1h", time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	p := state["BTCUSDT_1h"]
+	if want := [][2]int64{{listed + 10*hour, listed + 13*hour - 1}, {listed + 50*hour, listed + 51*hour - 1}}; !reflect.DeepEqual(p.Holes, want) {
+		t.Errorf("holes %v, want %v", p.Holes, want)
+	}
+	// the candle still forming is the first not known
+	if p.FirstOpenTime != listed || p.Complete != forming {
+		t.Errorf("first %d, complete %d, want %d and %d", p.FirstOpenTime, p.Complete, listed, forming)
+	}
+	first, last, _, err := cryptoapi.Store.Bounds("BTCUSDT", "1h")
+	if err != nil || first != listed || last != forming-hour {
+		t.Errorf("stored from %d to %d, %v, want %d to %d", first, last, err, listed, forming-hour)
+	}
+
+	// the next run asks for what comes after the complete mark only
+	*starts = nil
+	if err := cryptoapi.backfillSeries(ctx, state, "BTCUSDT", "1h", time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*starts, []int64{forming}) {
+		t.Errorf("requested klines from %v, want only from %d", *starts, forming)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/store"
+)
+
+// history reads the stored candles of a ticker between from and to,
+// deriving them from 1m when their interval is not stored and building the
+// bars of virtual intervals.
+func (cryptoapi *CryptoAPI) history(symbol, interval string, from, to int64) (*store.Batch, error) {
+	if strings.Contains(interval, ":") {
+		data, err := cryptoapi.Bars(symbol, interval, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return data.batch(), nil
+	}
+	b, err := cryptoapi.Store.Read(symbol, interval, from, to)
+	if err != nil || b.Len() > 0 || interval == baseInterval {
+		return b, err
+	}
+	data, err := cryptoapi.Derive(symbol, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return data.batch(), nil
+}
+
+// Candles returns the stored candles of a ticker between from and to for
+// backtests. The other intervals rules read come from the store over the
+// same period, read once.
+func (cryptoapi *CryptoAPI) Candles(symbol, interval string, from, to int64) (backtest.Candles, error) {
+	b, err := cryptoapi.history(symbol, interval, from, to)
+	if err != nil {
+		return backtest.Candles{}, err
+	}
+	var mu sync.Mutex
+	frames := make(map[string]*store.Batch)
+	return backtest.Candles{Batch: b, Frames: func(interval string) (*store.Batch, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if b, ok := frames[interval]; ok {
+			return b, nil
+		}
+		b, err := cryptoapi.history(symbol, interval, from, to)
+		if err == nil {
+			frames[interval] = b
+		}
+		return b, err
+	}}, nil
+}
+
+// Backtest runs a strategy on the stored candles of a ticker between from
+// and to.
+func (cryptoapi *CryptoAPI) Backtest(symbol, interval string, from, to int64, s backtest.Strategy, cfg backtest.Config) (*backtest.Result, error) {
+	src, err := cryptoapi.Candles(symbol, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return backtest.Run(src, s, cfg)
+}
+
+// SaveStudy keeps an optimization in the studies folder of the data and
+// returns its file.
+func SaveStudy(s *backtest.Study) (string, error) {
+	if s.Time == 0 {
+		s.Time = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	if err := os.MkdirAll(dataPath("studies"), 0755); err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := dataPath(filepath.Join("studies", fmt.Sprintf("%d_%s_%s.json", s.Time, s.Symbol, strings.Replace(s.Interval, ":", "-", -1))))
+	return name, writeFileAtomic(name, b)
+}
+
+// Studies reads the saved optimizations, oldest first.
+func Studies() ([]*backtest.Study, error) {
+	files, err := ioutil.ReadDir(dataPath("studies"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res []*backtest.Study
+	for _, f := range files {
+		if filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		b, err := ioutil.ReadFile(dataPath(filepath.Join("studies", f.Name())))
+		if err != nil {
+			return nil, err
+		}
+		s := new(backtest.Study)
+		if err := json.Unmarshal(b, s); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		res = append(res, s)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time < res[j].Time })
+	return res, nil
+}
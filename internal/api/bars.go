@@ -0,0 +1,90 @@
+package api
+
+import (
+	"sort"
+	"sync"
+
+	"cryptoapi/internal/bars"
+
+	"github.com/spf13/viper"
+)
+
+// virtualIntervals lists the configured bars built on top of interval.
+func virtualIntervals(interval string) []bars.Spec {
+	var res []bars.Spec
+	for _, name := range viper.GetStringSlice("bars") {
+		spec, err := bars.Parse(name)
+		if err == nil && spec.Base == interval {
+			res = append(res, spec)
+		}
+	}
+	return res
+}
+
+// setSeries caches the candles of a ticker along with the configured bars
+// built from them, each under its virtual interval, e.g. BTCUSDT_ha:1h.
+func (cryptoapi *CryptoAPI) setSeries(symbol, interval string, data *klineData) {
+	cryptoapi.Cache.Set(cryptoapi.FormatTickerKey(symbol, interval), data)
+	for _, spec := range virtualIntervals(interval) {
+		cryptoapi.Cache.Set(cryptoapi.FormatTickerKey(symbol, spec.String()), (*klineData)(spec.Build(data.batch())))
+	}
+}
+
+// updateSeries caches update's result on the cached candles of a ticker,
+// or on nil, and returns it. The updates of a ticker run one at a time, so
+// a backfill landing while the stream merges a candle loses neither.
+func (cryptoapi *CryptoAPI) updateSeries(symbol, interval string, update func(cached *klineData) *klineData) *klineData {
+	key := cryptoapi.FormatTickerKey(symbol, interval)
+	cryptoapi.mu.Lock()
+	if cryptoapi.updating == nil {
+		cryptoapi.updating = make(map[string]*sync.Mutex)
+	}
+	lock := cryptoapi.updating[key]
+	if lock == nil {
+		lock = new(sync.Mutex)
+		cryptoapi.updating[key] = lock
+	}
+	cryptoapi.mu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+	cached, _ := cryptoapi.Cache.Get(key).(*klineData)
+	data := update(cached)
+	cryptoapi.setSeries(symbol, interval, data)
+	return data
+}
+
+// refreshed returns an update replacing the cached candles with fetched,
+// but for those the stream brought in since the request went out: the
+// cached candles from the last one fetched on are kept.
+func refreshed(fetched *klineData) func(cached *klineData) *klineData {
+	return func(cached *klineData) *klineData {
+		if fetched.len() == 0 {
+			return cached
+		}
+		last := fetched.OpenTime[fetched.len()-1]
+		i := sort.Search(cached.len(), func(i int) bool { return cached.OpenTime[i] >= last })
+		return mergeKlines(fetched, cached.slice(i, cached.len()))
+	}
+}
+
+// Bars builds the bars of a virtual interval from the stored candles
+// between from and to, derived from 1m when their interval is not stored.
+func (cryptoapi *CryptoAPI) Bars(symbol, interval string, from, to int64) (*klineData, error) {
+	spec, err := bars.Parse(interval)
+	if err != nil {
+		return nil, err
+	}
+	src, err := cryptoapi.Store.Read(symbol, spec.Base, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if src.Len() == 0 && spec.Base != baseInterval {
+		data, err := cryptoapi.Derive(symbol, spec.Base, from, to)
+		if err != nil {
+			return nil, err
+		}
+		src = data.batch()
+	}
+	return (*klineData)(spec.Build(src)), nil
+}
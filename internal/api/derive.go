@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"math"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/store"
+)
+
+// baseInterval is the stored interval other ones are derived from.
+const baseInterval = "1m"
+
+// Derive builds the candles of any interval, 90m or 2d as well as the
+// native ones, from the stored 1m candles between from and to. The last
+// candle may still be missing its end, as the last REST one does.
+func (cryptoapi *CryptoAPI) Derive(symbol, interval string, from, to int64) (*klineData, error) {
+	iv, err := aggregate.ParseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	src, err := cryptoapi.Store.Read(symbol, baseInterval, iv.Start(from), to)
+	if err != nil {
+		return nil, err
+	}
+	res, _ := aggregate.Aggregate(src, iv)
+	return (*klineData)(res), nil
+}
+
+// DeriveHistory writes to the store the candles of every interval derived
+// from the stored 1m ones, so only 1m has to be downloaded. Only the candles
+// after the last derived one are built again, and a last candle missing its
+// end is left for a later run.
+func (cryptoapi *CryptoAPI) DeriveHistory(symbols, intervals []string) {
+	for _, symbol := range symbols {
+		for _, interval := range intervals {
+			if interval == baseInterval {
+				continue
+			}
+			if err := cryptoapi.deriveSeries(symbol, interval); err != nil {
+				cryptoapi.WithError(err).Debugf("deriving %s_%s failed, will continue", symbol, interval)
+			}
+		}
+	}
+}
+
+func (cryptoapi *CryptoAPI) deriveSeries(symbol, interval string) error {
+	iv, err := aggregate.ParseInterval(interval)
+	if err != nil {
+		return err
+	}
+	_, last, ok, err := cryptoapi.Store.Bounds(symbol, interval)
+	if err != nil {
+		return err
+	}
+	var from int64
+	if ok {
+		from = iv.Next(iv.Start(last))
+	}
+	a := aggregate.New(iv)
+	derived := 0
+	// Bars are written a month of 1m candles at a time.
+	err = cryptoapi.Store.Scan(symbol, baseInterval, from, math.MaxInt64, func(b *store.Batch) error {
+		bars := &store.Batch{}
+		a.Add(bars, b)
+		derived += bars.Len()
+		return cryptoapi.Store.Write(symbol, interval, bars)
+	})
+	if err != nil {
+		return fmt.Errorf("deriving %s_%s: %w", symbol, interval, err)
+	}
+	cryptoapi.Debugf("%s_%s: %d candles derived from %s", symbol, interval, derived, baseInterval)
+	return nil
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"cryptoapi/internal/binance/mock"
+	"cryptoapi/internal/execution"
+
+	"github.com/spf13/viper"
+)
+
+// startExecution trades the signals on the Binance account of the config,
+// or on an in-process mock exchange following the streamed prices.
+func (cryptoapi *CryptoAPI) startExecution() {
+	x, err := execution.FromConfig(cryptoapi.Logger)
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed reading the execution config, execution is off")
+		return
+	}
+	if viper.GetBool("execution.mock") {
+		ex, err := cryptoapi.mockExchange()
+		if err != nil {
+			cryptoapi.WithError(err).Debug("failed starting the mock exchange, execution is off")
+			return
+		}
+		x.Client.BaseURL, x.Client.APIKey, x.Client.SecretKey = ex.URL(), ex.APIKey, ex.SecretKey
+		x.Stream.URL = ex.StreamURL()
+		cryptoapi.exchange = ex
+	}
+	if x.Gate, err = cryptoapi.newGate("live"); err != nil {
+		cryptoapi.WithError(err).Debug("failed reading the risk config, execution is off")
+		return
+	}
+	x.Prices = cryptoapi.LastPrice
+	// the mock exchange starts afresh, without the orders of a saved state
+	if cryptoapi.exchange == nil {
+		if state, err := loadExecution(); err != nil {
+			cryptoapi.WithError(err).Debug("failed loading the execution state, no position is open")
+		} else {
+			x.Restore(state)
+		}
+	}
+	x.OnChange = func() {
+		cryptoapi.saveExecution()
+		cryptoapi.saveRisk()
+	}
+	cryptoapi.Executor = x
+	cryptoapi.HandleSignals(x.Handle)
+}
+
+// mockExchange starts a mock exchange listing the symbols of Binance, with
+// execution.mock-cash of each quote asset of the universe.
+func (cryptoapi *CryptoAPI) mockExchange() (*mock.Exchange, error) {
+	symbols, err := cryptoapi.Binance.ExchangeInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	ex := mock.New("mock", "mock", symbols...)
+	for _, quote := range viper.GetStringSlice("universe.quote") {
+		ex.Deposit(quote, viper.GetFloat64("execution.mock-cash"))
+	}
+	return ex, nil
+}
+
+// runExecution executes the signals until ctx is done.
+func (cryptoapi *CryptoAPI) runExecution(ctx context.Context) {
+	if err := cryptoapi.Executor.Run(ctx); err != nil && ctx.Err() == nil {
+		cryptoapi.WithError(err).Debug("execution stopped")
+	}
+}
+
+// executionFile is where the state of the account is saved, the trades on
+// the mock exchange being kept apart from the real ones.
+func executionFile() string {
+	if viper.GetBool("execution.mock") {
+		return dataPath("execution-mock.json")
+	}
+	return dataPath("execution.json")
+}
+
+func loadExecution() (execution.State, error) {
+	var res execution.State
+	b, err := ioutil.ReadFile(executionFile())
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(b, &res)
+	return res, err
+}
+
+// saveExecution persists the positions and fills of the account when they
+// changed.
+func (cryptoapi *CryptoAPI) saveExecution() {
+	cryptoapi.saving.Lock()
+	defer cryptoapi.saving.Unlock()
+	state, changed := cryptoapi.Executor.Snapshot()
+	if !changed {
+		return
+	}
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(executionFile(), b)
+	}
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed saving the execution state")
+	}
+}
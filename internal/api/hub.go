@@ -0,0 +1,111 @@
+package api
+
+import (
+	"math"
+
+	"cryptoapi/internal/hub"
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+// candleUpdate is a kline pushed on the candles channels, Closed being set
+// on the final update of the candle.
+type candleUpdate struct {
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	Closed   bool   `json:"closed"`
+	candle
+}
+
+// indicatorUpdate is the value of an indicator on a closed candle.
+type indicatorUpdate struct {
+	Symbol    string `json:"symbol"`
+	Interval  string `json:"interval"`
+	Indicator string `json:"indicator"`
+	// close time of the candle in milliseconds
+	Time  int64    `json:"time"`
+	Value *float64 `json:"value"`
+}
+
+// startHub pushes the signals, the streamed candles and the values of
+// hub.indicators to the websocket clients.
+func (cryptoapi *CryptoAPI) startHub() {
+	cryptoapi.Hub = hub.FromConfig(cryptoapi.Logger)
+	for _, s := range viper.GetStringSlice("hub.indicators") {
+		x, err := rules.Compile(s)
+		if err != nil {
+			cryptoapi.WithError(err).Debugf("not pushing indicator %s", s)
+			continue
+		}
+		cryptoapi.indicators = append(cryptoapi.indicators, x)
+	}
+	cryptoapi.HandleSignals(cryptoapi.publishSignal)
+}
+
+func (cryptoapi *CryptoAPI) publishSignal(s rules.Signal) {
+	cryptoapi.Hub.Publish(hub.Message{
+		Type:    hub.TypeSignal,
+		Channel: hub.Channel(hub.Signals, s.Symbol, s.Interval),
+		Sender:  s.Rule,
+		Target:  s.Symbol,
+		Payload: s,
+	})
+}
+
+// publishCandle pushes a kline update, from the stream it came on. The
+// updates of a candle still forming are superseded by the next one, so slow
+// clients lose them first.
+func (cryptoapi *CryptoAPI) publishCandle(k ingest.Kline) {
+	c := k.Candle
+	update := candleUpdate{Symbol: k.Symbol, Interval: k.Interval, Closed: k.Closed, candle: candle{
+		OpenTime:                 c.OpenTime,
+		Open:                     c.Open,
+		High:                     c.High,
+		Low:                      c.Low,
+		Close:                    c.Close,
+		Volume:                   c.Volume,
+		CloseTime:                k.CloseTime,
+		QuoteAssetVolume:         &c.QuoteVolume,
+		NumberOfTrades:           c.Trades,
+		TakerBuyBaseAssetVolume:  &c.TakerBuyVolume,
+		TakerBuyQuoteAssetVolume: &c.TakerBuyQuoteVolume,
+	}}
+	cryptoapi.Hub.Publish(hub.Message{
+		Type:      hub.TypeCandle,
+		Channel:   hub.Channel(hub.Candles, k.Symbol, k.Interval),
+		Sender:    ingest.StreamName(k.Symbol, k.Interval),
+		Target:    k.Symbol,
+		Payload:   update,
+		Droppable: !k.Closed,
+	})
+}
+
+// publishIndicators pushes the values of hub.indicators on the last candle
+// of src, which has closed, when anyone listens.
+func (cryptoapi *CryptoAPI) publishIndicators(symbol, interval string, src frameSource) {
+	channel := hub.Channel(hub.Indicators, symbol, interval)
+	if len(cryptoapi.indicators) == 0 || !cryptoapi.Hub.Subscribed(channel) {
+		return
+	}
+	n := src.len()
+	for _, x := range cryptoapi.indicators {
+		v, err := x.Eval(src)
+		if err != nil {
+			cryptoapi.WithError(err).Debugf("failed computing %s on %s_%s", x, symbol, interval)
+			continue
+		}
+		update := indicatorUpdate{Symbol: symbol, Interval: interval, Indicator: x.String(), Time: src.CloseTime[n-1]}
+		if last := v[n-1]; !math.IsNaN(last) && !math.IsInf(last, 0) {
+			update.Value = &last
+		}
+		cryptoapi.Hub.Publish(hub.Message{
+			Type:    hub.TypeIndicator,
+			Channel: channel,
+			Sender:  update.Indicator,
+			Target:  symbol,
+			Payload: update,
+		})
+	}
+}
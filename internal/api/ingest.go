@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"cryptoapi/internal/ingest"
+
+	"github.com/spf13/viper"
+)
+
+// maxKlines is how many candles are kept per ticker, as many as one REST
+// call returns.
+const maxKlines = 1000
+
+// tickerState is the signal state of one ticker and interval.
+type tickerState struct {
+	// open time of the last candle the rules ran on
+	committed int64
+}
+
+// StartStreaming follows the symbol universe over the kline websocket
+// streams until ctx is done, backfilling every symbol over REST as it joins.
+func (cryptoapi *CryptoAPI) StartStreaming(ctx context.Context) error {
+	client := ingest.New(viper.GetString("binance-stream"), cryptoapi.Logger, cryptoapi.Ingest, cryptoapi.Backfill)
+	cryptoapi.mu.Lock()
+	cryptoapi.streams = client
+	cryptoapi.mu.Unlock()
+	if err := cryptoapi.Universe.Refresh(ctx); err != nil {
+		return err
+	}
+	go cryptoapi.Universe.Run(ctx, viper.GetDuration("universe.refresh"))
+	if cryptoapi.Executor != nil {
+		go cryptoapi.runExecution(ctx)
+	}
+	return client.Run(ctx)
+}
+
+// universeChanged starts following the symbols added to the universe and
+// drops the data of the removed ones.
+func (cryptoapi *CryptoAPI) universeChanged(added, removed []string) {
+	streamed := cryptoapi.streamedIntervals()
+	for _, symbol := range removed {
+		for _, interval := range append(streamed, cryptoapi.derivedIntervals()...) {
+			key := cryptoapi.FormatTickerKey(symbol, interval)
+			cryptoapi.Cache.Delete(key)
+			cryptoapi.mu.Lock()
+			delete(cryptoapi.tickers, key)
+			delete(cryptoapi.live, key)
+			cryptoapi.mu.Unlock()
+		}
+	}
+
+	cryptoapi.mu.Lock()
+	client := cryptoapi.streams
+	cryptoapi.mu.Unlock()
+	if client == nil {
+		// not streaming, nothing to start or stop
+		return
+	}
+	if err := client.Unsubscribe(removed, streamed); err != nil {
+		cryptoapi.WithError(err).Debug("failed unsubscribing removed symbols")
+	}
+	if err := client.Subscribe(added, streamed); err != nil {
+		cryptoapi.WithError(err).Debug("failed subscribing added symbols")
+	}
+	for _, symbol := range added {
+		for _, interval := range streamed {
+			cryptoapi.Backfill(symbol, interval, 0)
+		}
+	}
+}
+
+// Backfill reloads the recent klines of a ticker over REST. The last 1000
+// candles always cover the gaps the stream leaves after a reconnect, so
+// since is only logged. The rules are not run on the candles reloaded.
+// The derived intervals are reloaded along with 1m, which they are built
+// from.
+func (cryptoapi *CryptoAPI) Backfill(symbol, interval string, since int64) {
+	cryptoapi.Debugf("backfilling %s_%s since %d", symbol, interval, since)
+	data, err := FetchKlines(symbol, interval, 0, cryptoapi.CollectDataFromBinance)
+	if err != nil {
+		cryptoapi.WithError(err).Debugf("%s_%s backfill failed", symbol, interval)
+		return
+	}
+	cryptoapi.updateSeries(symbol, interval, refreshed(data))
+
+	// The last REST kline is usually still forming.
+	closed := data.closedBefore(time.Now().UnixNano() / int64(time.Millisecond))
+	if n := closed.len(); n > 0 {
+		cryptoapi.mu.Lock()
+		cryptoapi.tickers[cryptoapi.FormatTickerKey(symbol, interval)] = &tickerState{committed: closed.OpenTime[n-1]}
+		cryptoapi.mu.Unlock()
+	}
+	if interval == baseInterval {
+		for _, derived := range cryptoapi.derivedIntervals() {
+			cryptoapi.backfillDerived(symbol, derived)
+		}
+	}
+}
+
+// Ingest merges a streamed kline into the cached data of its ticker and
+// runs the rules once the candle closes. The 1m klines update the bars of
+// the derived intervals, which are ingested in turn.
+func (cryptoapi *CryptoAPI) Ingest(k ingest.Kline) {
+	data := cryptoapi.updateSeries(k.Symbol, k.Interval, func(cached *klineData) *klineData {
+		return cached.merge(k)
+	})
+	cryptoapi.publishCandle(k)
+	if cryptoapi.exchange != nil && k.Interval == baseInterval {
+		cryptoapi.exchange.SetPrice(k.Symbol, k.Candle.Close)
+	}
+	if k.Closed {
+		cryptoapi.evaluate(k.Symbol, k.Interval, data)
+	}
+	if k.Interval == baseInterval {
+		cryptoapi.deriveLive(k)
+	}
+}
+
+// merge returns a copy of d with k appended, or replacing the last candle
+// when it is an update of it. d itself is left alone since readers of the
+// cache may still hold it.
+func (d *klineData) merge(k ingest.Kline) *klineData {
+	res := new(klineData)
+	if d != nil {
+		*res = *d
+	}
+	n := len(res.OpenTime)
+	switch {
+	case n > 0 && k.Candle.OpenTime < res.OpenTime[n-1]:
+		return res
+	case n > 0 && k.Candle.OpenTime == res.OpenTime[n-1]:
+		n--
+	}
+	start := 0
+	if n+1 > maxKlines {
+		start = n + 1 - maxKlines
+	}
+	res.OpenTime = append(append([]int64(nil), res.OpenTime[start:n]...), k.Candle.OpenTime)
+	res.Open = append(append([]float64(nil), res.Open[start:n]...), k.Candle.Open)
+	res.High = append(append([]float64(nil), res.High[start:n]...), k.Candle.High)
+	res.Low = append(append([]float64(nil), res.Low[start:n]...), k.Candle.Low)
+	res.Close = append(append([]float64(nil), res.Close[start:n]...), k.Candle.Close)
+	res.Volume = append(append([]float64(nil), res.Volume[start:n]...), k.Candle.Volume)
+	res.CloseTime = append(append([]int64(nil), res.CloseTime[start:n]...), k.CloseTime)
+	res.QuoteAssetVolume = append(append([]float64(nil), res.QuoteAssetVolume[start:n]...), k.Candle.QuoteVolume)
+	res.NumberOfTrades = append(append([]int64(nil), res.NumberOfTrades[start:n]...), k.Candle.Trades)
+	res.TakerBuyBaseAssetVolume = append(append([]float64(nil), res.TakerBuyBaseAssetVolume[start:n]...), k.Candle.TakerBuyVolume)
+	res.TakerBuyQuoteAssetVolume = append(append([]float64(nil), res.TakerBuyQuoteAssetVolume[start:n]...), k.Candle.TakerBuyQuoteVolume)
+	return res
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"cryptoapi/internal/cache"
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/stream"
+)
+
+// kline is a closed BTCUSDT 1m candle.
+func kline(open int64, close float64) ingest.Kline {
+	return ingest.Kline{
+		Symbol:    "BTCUSDT",
+		Interval:  "1m",
+		Closed:    true,
+		CloseTime: open + 59999,
+		Candle:    stream.Candle{OpenTime: open, Open: close, High: close, Low: close, Close: close},
+	}
+}
+
+// klines returns n candles closing at close, one minute apart.
+func klines(n int, close float64) *klineData {
+	var res *klineData
+	for i := 0; i < n; i++ {
+		res = res.merge(kline(int64(i)*60000, close))
+	}
+	return res
+}
+
+func TestRefreshed(t *testing.T) {
+	// the stream closed candle 2 and started candle 3 while the request
+	// fetching candles 0 to 2, the last one forming, was out
+	cached := klines(4, 2)
+	got := refreshed(klines(3, 1))(cached)
+	if want := []float64{1, 1, 2, 2}; !reflect.DeepEqual(got.Close, want) {
+		t.Errorf("got %v, want %v", got.Close, want)
+	}
+	if got := refreshed(klines(0, 1))(cached); got != cached {
+		t.Error("an empty fetch dropped the cache")
+	}
+	if got := refreshed(klines(2, 1))(nil); got.len() != 2 {
+		t.Errorf("got %d candles on an empty cache, want 2", got.len())
+	}
+}
+
+func TestUpdateSeries(t *testing.T) {
+	cryptoapi := &CryptoAPI{Cache: cache.New()}
+	fetched := klines(100, 1)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 100; i < 300; i++ {
+			k := kline(int64(i)*60000, 2)
+			cryptoapi.updateSeries(k.Symbol, k.Interval, func(cached *klineData) *klineData {
+				return cached.merge(k)
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			cryptoapi.updateSeries("BTCUSDT", "1m", refreshed(fetched))
+		}
+	}()
+	wg.Wait()
+
+	data := cryptoapi.Cache.Get("BTCUSDT_1m").(*klineData)
+	// every streamed candle made it, behind the fetched ones or not
+	if n := data.len(); n < 200 || data.OpenTime[n-1] != 299*60000 {
+		t.Fatalf("%d candles up to %d", n, data.OpenTime[n-1])
+	}
+	for i := 1; i < data.len(); i++ {
+		if data.OpenTime[i] != data.OpenTime[i-1]+60000 {
+			t.Fatalf("candle %d opens at %d after %d", i, data.OpenTime[i], data.OpenTime[i-1])
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"time"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/bars"
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/store"
+
+	"github.com/spf13/viper"
+)
+
+// liveBar builds the candles of a derived interval from the streamed 1m ones.
+type liveBar struct {
+	agg *aggregate.Aggregator
+	// open time of the bar being built and of the last closed 1m candle
+	// folded into it
+	start, last int64
+	// whether the bar holds every minute since it opened, so it can be
+	// cached; the bar the stream joins in the middle of is not
+	complete bool
+}
+
+func isNative(interval string) bool {
+	for _, iv := range Intervals {
+		if iv == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// derivedIntervals are the intervals built from the 1m stream rather than
+// streamed: those listed under derived and those of the rules Binance does
+// not stream, such as 90m or 2d.
+func (cryptoapi *CryptoAPI) derivedIntervals() []string {
+	var res []string
+	seen := map[string]bool{baseInterval: true}
+	add := func(interval string) {
+		if seen[interval] {
+			return
+		}
+		seen[interval] = true
+		if _, err := aggregate.ParseInterval(interval); err == nil {
+			res = append(res, interval)
+		}
+	}
+	for _, interval := range viper.GetStringSlice("derived") {
+		add(interval)
+	}
+	for _, r := range cryptoapi.Rules.Rules() {
+		if _, err := bars.Parse(r.Interval); err != nil && !isNative(r.Interval) {
+			add(r.Interval)
+		}
+	}
+	return res
+}
+
+// streamedIntervals are the native intervals not derived, 1m always being
+// one.
+func (cryptoapi *CryptoAPI) streamedIntervals() []string {
+	derived := make(map[string]bool)
+	for _, interval := range cryptoapi.derivedIntervals() {
+		derived[interval] = true
+	}
+	var res []string
+	for _, interval := range Intervals {
+		if !derived[interval] {
+			res = append(res, interval)
+		}
+	}
+	return res
+}
+
+// backfillDerived reloads the candles of a derived interval, over REST when
+// Binance has it and from the stored 1m candles otherwise, then starts
+// building its bar in progress from the cached 1m candles.
+func (cryptoapi *CryptoAPI) backfillDerived(symbol, interval string) {
+	iv, err := aggregate.ParseInterval(interval)
+	if err != nil {
+		return
+	}
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	var data *klineData
+	if isNative(interval) {
+		data, err = FetchKlines(symbol, interval, 0, cryptoapi.CollectDataFromBinance)
+	} else {
+		data, err = cryptoapi.Derive(symbol, interval, now-maxKlines*iv.Next(0), now)
+	}
+	if err != nil {
+		cryptoapi.WithError(err).Debugf("%s_%s backfill failed", symbol, interval)
+		return
+	}
+	if data.len() == 0 {
+		// nothing stored, the cached 1m candles make a start
+		base, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, baseInterval)).(*klineData)
+		if base.len() > 0 {
+			res, _ := aggregate.Aggregate(base.batch(), iv)
+			data = (*klineData)(res)
+		}
+	}
+	if data.len() > 0 {
+		cryptoapi.updateSeries(symbol, interval, refreshed(data))
+		closed := data.closedBefore(now)
+		if n := closed.len(); n > 0 {
+			cryptoapi.mu.Lock()
+			cryptoapi.tickers[cryptoapi.FormatTickerKey(symbol, interval)] = &tickerState{committed: closed.OpenTime[n-1]}
+			cryptoapi.mu.Unlock()
+		}
+	}
+	cryptoapi.startLive(symbol, iv, now)
+}
+
+// startLive folds the closed 1m candles of the bar of iv in progress at now
+// into a new liveBar, reading the store for those older than the cache.
+func (cryptoapi *CryptoAPI) startLive(symbol string, iv aggregate.Interval, now int64) {
+	start := iv.Start(now)
+	key := cryptoapi.FormatTickerKey(symbol, iv.String())
+	older := &store.Batch{}
+	if b, err := cryptoapi.Store.Read(symbol, baseInterval, start, now); err == nil {
+		older = b
+	}
+	// The cache is read under the lock Ingest derives under, so no closed
+	// candle is missed or folded twice.
+	cryptoapi.mu.Lock()
+	defer cryptoapi.mu.Unlock()
+	cached := &store.Batch{}
+	if base, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, baseInterval)).(*klineData); base.len() > 0 {
+		cached = base.closedBefore(now).batch()
+	}
+	lb := &liveBar{agg: aggregate.New(iv), start: start, last: start - 1}
+	fold := func(b *store.Batch, before int64) {
+		for i := 0; i < b.Len() && b.OpenTime[i] < before; i++ {
+			if b.OpenTime[i] > lb.last {
+				lb.agg.Update(aggregate.Row(b, i))
+				lb.last = b.OpenTime[i]
+			}
+		}
+	}
+	first := now
+	if cached.Len() > 0 {
+		first = cached.OpenTime[0]
+	}
+	fold(older, first)
+	fold(cached, now)
+	lb.complete = first <= start || older.Len() > 0 && older.OpenTime[0] == start
+	cryptoapi.live[key] = lb
+}
+
+// deriveLive folds a streamed 1m kline into the bars of the derived
+// intervals and ingests the bars it changes.
+func (cryptoapi *CryptoAPI) deriveLive(k ingest.Kline) {
+	for _, interval := range cryptoapi.derivedIntervals() {
+		cryptoapi.mu.Lock()
+		lb := cryptoapi.live[cryptoapi.FormatTickerKey(k.Symbol, interval)]
+		if lb == nil || k.Candle.OpenTime <= lb.last {
+			cryptoapi.mu.Unlock()
+			continue
+		}
+		if start := lb.agg.Interval.Start(k.Candle.OpenTime); start != lb.start {
+			lb.start, lb.complete = start, k.Candle.OpenTime == start
+		}
+		if k.Closed {
+			lb.last = k.Candle.OpenTime
+		}
+		bar := lb.agg.Update(k)
+		complete := lb.complete
+		cryptoapi.mu.Unlock()
+		if complete {
+			cryptoapi.Ingest(bar)
+		}
+	}
+}
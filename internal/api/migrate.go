@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// legacySeries reads the symbol and interval of a gob+gzip snapshot, named
+// <SYMBOL>_<interval>.gz or <SYMBOL>_<interval>_old_<unix>.gz.
+func legacySeries(name string) (symbol, interval string, ok bool) {
+	parts := strings.Split(strings.TrimSuffix(filepath.Base(name), ".gz"), "_")
+	switch {
+	case len(parts) == 2:
+	case len(parts) == 4 && parts[2] == "old":
+	default:
+		return "", "", false
+	}
+	return parts[0], parts[1], parts[0] != "" && parts[1] != ""
+}
+
+// ImportLegacy copies the gob+gzip snapshots found in dir into the store,
+// removing each once imported when remove is set. Candles already stored
+// are kept over the snapshot ones, so importing twice is harmless.
+func (cryptoapi *CryptoAPI) ImportLegacy(dir string, remove bool) error {
+	names, err := filepath.Glob(filepath.Join(dir, "*.gz"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		symbol, interval, ok := legacySeries(name)
+		if !ok {
+			cryptoapi.Debugf("skipping %s, not a snapshot name", name)
+			continue
+		}
+		data, err := ReadDataFromFile(name)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		if data.len() > 0 {
+			stored, err := cryptoapi.Store.Read(symbol, interval, data.OpenTime[0], data.OpenTime[data.len()-1])
+			if err != nil {
+				return err
+			}
+			if err := cryptoapi.Store.Write(symbol, interval, mergeKlines(data, (*klineData)(stored)).batch()); err != nil {
+				return fmt.Errorf("importing %s: %w", name, err)
+			}
+		}
+		cryptoapi.Debugf("imported %d candles of %s_%s from %s", data.len(), symbol, interval, name)
+		if remove {
+			if err := os.Remove(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"cryptoapi/internal/paper"
+	"cryptoapi/internal/rules"
+)
+
+// startPaper trades the signals on the paper account, restored from its
+// last save.
+func (cryptoapi *CryptoAPI) startPaper() {
+	trader, err := paper.FromConfig(cryptoapi.LastPrice, cryptoapi.Logger)
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed reading the paper config, paper trading is off")
+		return
+	}
+	if trader.Gate, err = cryptoapi.newGate("paper"); err != nil {
+		cryptoapi.WithError(err).Debug("failed reading the risk config, paper trading is off")
+		return
+	}
+	if account, err := loadPaper(); err != nil {
+		cryptoapi.WithError(err).Debug("failed loading the paper account, it starts over")
+	} else {
+		trader.Restore(account)
+	}
+	cryptoapi.Paper = trader
+	cryptoapi.HandleSignals(cryptoapi.paperTrade)
+}
+
+// LastPrice is the close of the most recent candle cached for symbol, the
+// one still forming included.
+func (cryptoapi *CryptoAPI) LastPrice(symbol string) (float64, bool) {
+	var res float64
+	var latest int64
+	for _, interval := range Intervals {
+		data, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, interval)).(*klineData)
+		if n := data.len(); n > 0 && data.OpenTime[n-1] > latest {
+			res, latest = data.Close[n-1], data.OpenTime[n-1]
+		}
+	}
+	return res, latest > 0
+}
+
+// paperTrade executes s on the paper account at the last price of its
+// symbol rather than the close of the candle, which bars such as Heikin
+// Ashi make up.
+func (cryptoapi *CryptoAPI) paperTrade(s rules.Signal) {
+	price, ok := cryptoapi.LastPrice(s.Symbol)
+	if !ok {
+		price = s.Price
+	}
+	cryptoapi.Paper.Handle(s, price)
+	cryptoapi.savePaper()
+	cryptoapi.saveRisk()
+}
+
+// PaperAccount returns the paper account and its equity at the last prices.
+func (cryptoapi *CryptoAPI) PaperAccount() (paper.Account, float64, bool) {
+	if cryptoapi.Paper == nil {
+		return paper.Account{}, 0, false
+	}
+	return cryptoapi.Paper.Account(), cryptoapi.Paper.Equity(), true
+}
+
+func loadPaper() (paper.Account, error) {
+	var res paper.Account
+	b, err := ioutil.ReadFile(dataPath("paper.json"))
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(b, &res)
+	return res, err
+}
+
+// savePaper persists the paper account when it changed.
+func (cryptoapi *CryptoAPI) savePaper() {
+	cryptoapi.saving.Lock()
+	defer cryptoapi.saving.Unlock()
+	account, changed := cryptoapi.Paper.Snapshot()
+	if !changed {
+		return
+	}
+	b, err := json.MarshalIndent(account, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(dataPath("paper.json"), b)
+	}
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed saving the paper account")
+	}
+}
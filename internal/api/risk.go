@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"cryptoapi/internal/risk"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+// newGate makes the risk gate of an account, restored from its last save.
+func (cryptoapi *CryptoAPI) newGate(account string) (*risk.Gate, error) {
+	gate, err := risk.FromConfig(account, cryptoapi.riskCandles, cryptoapi.Logger)
+	if err != nil {
+		return nil, err
+	}
+	states, err := loadRisk()
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed loading the risk state, the day starts over")
+	}
+	if state, ok := states[account]; ok {
+		gate.Restore(state)
+	}
+	gate.HandleRejections(cryptoapi.rejected)
+	cryptoapi.mu.Lock()
+	cryptoapi.gates = append(cryptoapi.gates, gate)
+	cryptoapi.mu.Unlock()
+	return gate, nil
+}
+
+// riskCandles returns the cached candles of risk.interval the gates compute
+// the ATR and the correlations on.
+func (cryptoapi *CryptoAPI) riskCandles(symbol string) (rules.Source, error) {
+	interval := viper.GetString("risk.interval")
+	data, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, interval)).(*klineData)
+	if data.len() == 0 {
+		return nil, fmt.Errorf("no %s candles of %s", interval, symbol)
+	}
+	return frameSource{klineData: data, cryptoapi: cryptoapi, symbol: symbol}, nil
+}
+
+// HandleRejections registers fn to be told of every signal the risk gates
+// turn down or cut down.
+func (cryptoapi *CryptoAPI) HandleRejections(fn func(risk.Rejection)) {
+	cryptoapi.mu.Lock()
+	defer cryptoapi.mu.Unlock()
+	cryptoapi.rejectHandlers = append(cryptoapi.rejectHandlers, fn)
+}
+
+func (cryptoapi *CryptoAPI) rejected(r risk.Rejection) {
+	cryptoapi.mu.Lock()
+	handlers := cryptoapi.rejectHandlers
+	cryptoapi.mu.Unlock()
+	for _, fn := range handlers {
+		fn(r)
+	}
+}
+
+// Rejections returns the last rejections of every gate, oldest first.
+func (cryptoapi *CryptoAPI) Rejections() []risk.Rejection {
+	cryptoapi.mu.Lock()
+	gates := cryptoapi.gates
+	cryptoapi.mu.Unlock()
+	var res []risk.Rejection
+	for _, g := range gates {
+		res = append(res, g.Rejections()...)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time < res[j].Time })
+	return res
+}
+
+func loadRisk() (map[string]risk.State, error) {
+	res := make(map[string]risk.State)
+	b, err := ioutil.ReadFile(dataPath("risk.json"))
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(b, &res)
+	return res, err
+}
+
+// saveRisk persists the days, kill switches and rejections of the gates
+// when one changed.
+func (cryptoapi *CryptoAPI) saveRisk() {
+	cryptoapi.mu.Lock()
+	gates := cryptoapi.gates
+	cryptoapi.mu.Unlock()
+	cryptoapi.saving.Lock()
+	defer cryptoapi.saving.Unlock()
+	states := make(map[string]risk.State, len(gates))
+	changed := false
+	for _, g := range gates {
+		state, c := g.Snapshot()
+		states[g.Account] = state
+		changed = changed || c
+	}
+	if !changed {
+		return
+	}
+	b, err := json.MarshalIndent(states, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(dataPath("risk.json"), b)
+	}
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed saving the risk state")
+	}
+}
@@ -0,0 +1,701 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/bars"
+	"cryptoapi/internal/config"
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultLimit = 500
+	// as many candles as are cached per ticker
+	maxLimit = maxKlines
+	// most indicator values computed before the page so it starts warm
+	maxWarmup = 1000
+)
+
+// httpError is an error with the HTTP status it answers with.
+type httpError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e *httpError) Error() string {
+	return e.Message
+}
+
+func errorf(status int, format string, a ...interface{}) error {
+	return &httpError{Status: status, Message: fmt.Sprintf(format, a...)}
+}
+
+// page is the body of every successful response. Next and Prev are the
+// from, to or offset parameters of the following and preceding pages, left
+// out when there are none.
+type page struct {
+	Data  interface{} `json:"data"`
+	Next  *int64      `json:"next,omitempty"`
+	Prev  *int64      `json:"prev,omitempty"`
+	Total int         `json:"total,omitempty"`
+}
+
+// cursor returns a pointer to v for the Next and Prev of a page.
+func cursor(v int64) *int64 {
+	return &v
+}
+
+type handlerFunc func(r *http.Request, args []string) (*page, error)
+
+// route serves the GET requests of the paths starting with prefix, the rest
+// of the path being split into exactly n arguments.
+type route struct {
+	prefix string
+	n      int
+	fn     handlerFunc
+}
+
+// Handler serves the REST API:
+//
+//	GET /v1/health
+//	GET /v1/version
+//	GET /v1/symbols
+//	GET /v1/intervals
+//	GET /v1/candles/{symbol}/{interval}?from&to&limit
+//	GET /v1/indicators?category
+//	GET /v1/indicators/{name}
+//	GET /v1/indicators/{name}/{symbol}/{interval}?from&to&limit&inputs&{option}
+//	GET /v1/signals/active?symbol&interval&rule
+//	GET /v1/signals/history?symbol&interval&rule&limit&offset
+//	GET /v1/ws, the websocket of the hub, see internal/hub
+//
+// Times are in milliseconds or RFC 3339. Errors answer with
+// {"error": {"status", "message"}}.
+func (cryptoapi *CryptoAPI) Handler() http.Handler {
+	started := time.Now()
+	routes := []route{
+		{"/v1/health", 0, func(*http.Request, []string) (*page, error) {
+			return &page{Data: map[string]interface{}{
+				"status":  "ok",
+				"version": config.Version,
+				"uptime":  time.Since(started).Round(time.Second).String(),
+				"symbols": len(cryptoapi.Symbols()),
+			}}, nil
+		}},
+		{"/v1/version", 0, func(*http.Request, []string) (*page, error) {
+			return &page{Data: map[string]string{"version": config.Version}}, nil
+		}},
+		{"/v1/symbols", 0, cryptoapi.serveSymbols},
+		{"/v1/intervals", 0, cryptoapi.serveIntervals},
+		{"/v1/candles", 2, cryptoapi.serveCandles},
+		{"/v1/indicators", 0, serveIndicators},
+		{"/v1/indicators", 1, serveIndicator},
+		{"/v1/indicators", 3, cryptoapi.serveIndicatorValues},
+		{"/v1/signals/active", 0, cryptoapi.serveActiveSignals},
+		{"/v1/signals/history", 0, cryptoapi.serveSignalHistory},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		if path == "/v1/ws" {
+			cryptoapi.Hub.ServeHTTP(w, r)
+			return
+		}
+		for _, rt := range routes {
+			args, ok := match(path, rt.prefix, rt.n)
+			if !ok {
+				continue
+			}
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				w.Header().Set("Allow", "GET, HEAD")
+				cryptoapi.writeError(w, errorf(http.StatusMethodNotAllowed, "method %s not allowed", r.Method))
+				return
+			}
+			res, err := rt.fn(r, args)
+			if err != nil {
+				cryptoapi.writeError(w, err)
+				return
+			}
+			cryptoapi.writeJSON(w, http.StatusOK, res)
+			return
+		}
+		cryptoapi.writeError(w, errorf(http.StatusNotFound, "no such endpoint %s", r.URL.Path))
+	})
+}
+
+func match(path, prefix string, n int) ([]string, bool) {
+	if path == prefix {
+		return nil, n == 0
+	}
+	if !strings.HasPrefix(path, prefix+"/") || n == 0 {
+		return nil, false
+	}
+	args := strings.Split(path[len(prefix)+1:], "/")
+	if len(args) != n {
+		return nil, false
+	}
+	for _, a := range args {
+		if a == "" {
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+func (cryptoapi *CryptoAPI) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed encoding a response")
+		status = http.StatusInternalServerError
+		b, _ = json.Marshal(map[string]*httpError{"error": {Status: status, Message: "failed encoding the response"}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(b, '\n'))
+}
+
+// writeError answers with the status of err, 500 unless it is an httpError.
+// The message of other errors is only logged, it may tell about the store.
+func (cryptoapi *CryptoAPI) writeError(w http.ResponseWriter, err error) {
+	var e *httpError
+	if !errors.As(err, &e) {
+		cryptoapi.WithError(err).Debug("failed serving a request")
+		e = &httpError{Status: http.StatusInternalServerError, Message: "internal error"}
+	}
+	cryptoapi.writeJSON(w, e.Status, map[string]*httpError{"error": e})
+}
+
+// Serve listens on addr until ctx is done, then lets the requests in flight
+// finish.
+func (cryptoapi *CryptoAPI) Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           cryptoapi.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+	err := srv.ListenAndServe()
+	if err == http.ErrServerClosed {
+		<-done
+		return ctx.Err()
+	}
+	return err
+}
+
+// ServeFromConfig serves the REST API on base.port.
+func (cryptoapi *CryptoAPI) ServeFromConfig(ctx context.Context) error {
+	return cryptoapi.Serve(ctx, ":"+strconv.Itoa(viper.GetInt("base.port")))
+}
+
+func (cryptoapi *CryptoAPI) serveSymbols(*http.Request, []string) (*page, error) {
+	symbols := cryptoapi.Symbols()
+	return &page{Data: symbols, Total: len(symbols)}, nil
+}
+
+// serveIntervals lists the native intervals followed by the other derived
+// ones and the configured bars. Any other interval Derive builds can be
+// asked for too.
+func (cryptoapi *CryptoAPI) serveIntervals(*http.Request, []string) (*page, error) {
+	res := append([]string(nil), Intervals...)
+	for _, interval := range cryptoapi.derivedIntervals() {
+		if !isNative(interval) {
+			res = append(res, interval)
+		}
+	}
+	for _, name := range viper.GetStringSlice("bars") {
+		if _, err := bars.Parse(name); err == nil {
+			res = append(res, name)
+		}
+	}
+	return &page{Data: res, Total: len(res)}, nil
+}
+
+// candle is a row of klineData. The volumes of candles recorded before
+// they were kept are null.
+type candle struct {
+	OpenTime                 int64    `json:"open_time"`
+	Open                     float64  `json:"open"`
+	High                     float64  `json:"high"`
+	Low                      float64  `json:"low"`
+	Close                    float64  `json:"close"`
+	Volume                   float64  `json:"volume"`
+	CloseTime                int64    `json:"close_time"`
+	QuoteAssetVolume         *float64 `json:"quote_asset_volume"`
+	NumberOfTrades           int64    `json:"number_of_trades"`
+	TakerBuyBaseAssetVolume  *float64 `json:"taker_buy_base_asset_volume"`
+	TakerBuyQuoteAssetVolume *float64 `json:"taker_buy_quote_asset_volume"`
+}
+
+func (cryptoapi *CryptoAPI) serveCandles(r *http.Request, args []string) (*page, error) {
+	q, err := cryptoapi.query(r, args)
+	if err != nil {
+		return nil, err
+	}
+	data, _, res, err := cryptoapi.window(q, 0)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]candle, data.len())
+	for i := range rows {
+		rows[i] = candle{
+			OpenTime:                 data.OpenTime[i],
+			Open:                     data.Open[i],
+			High:                     data.High[i],
+			Low:                      data.Low[i],
+			Close:                    data.Close[i],
+			Volume:                   data.Volume[i],
+			CloseTime:                data.CloseTime[i],
+			QuoteAssetVolume:         nullable(data.QuoteAssetVolume[i : i+1])[0],
+			NumberOfTrades:           data.NumberOfTrades[i],
+			TakerBuyBaseAssetVolume:  nullable(data.TakerBuyBaseAssetVolume[i : i+1])[0],
+			TakerBuyQuoteAssetVolume: nullable(data.TakerBuyQuoteAssetVolume[i : i+1])[0],
+		}
+	}
+	res.Data = rows
+	return res, nil
+}
+
+func serveIndicators(r *http.Request, _ []string) (*page, error) {
+	defs := indicator.List()
+	if c := r.URL.Query().Get("category"); c != "" {
+		var res []*indicator.Definition
+		for _, d := range defs {
+			if d.Category.String() == c {
+				res = append(res, d)
+			}
+		}
+		defs = res
+	}
+	if defs == nil {
+		defs = []*indicator.Definition{}
+	}
+	return &page{Data: defs, Total: len(defs)}, nil
+}
+
+func serveIndicator(_ *http.Request, args []string) (*page, error) {
+	d, err := indicator.Find(args[0])
+	if err != nil {
+		return nil, errorf(http.StatusNotFound, "%v", err)
+	}
+	return &page{Data: d}, nil
+}
+
+// indicatorValues are the outputs of an indicator on a page of candles,
+// null before the indicator has enough candles.
+type indicatorValues struct {
+	Indicator string                `json:"indicator"`
+	Options   map[string]float64    `json:"options"`
+	OpenTime  []int64               `json:"open_time"`
+	Outputs   map[string][]*float64 `json:"outputs"`
+}
+
+// serveIndicatorValues computes an indicator on the candles the same query
+// to /v1/candles returns. The parameters other than from, to, limit and
+// inputs are options of the indicator; inputs binds its real inputs to other
+// columns, e.g. inputs=high,low.
+func (cryptoapi *CryptoAPI) serveIndicatorValues(r *http.Request, args []string) (*page, error) {
+	d, err := indicator.Find(args[0])
+	if err != nil {
+		return nil, errorf(http.StatusNotFound, "%v", err)
+	}
+	options := make(map[string]float64)
+	for name, v := range r.URL.Query() {
+		switch name {
+		case "from", "to", "limit", "inputs":
+			continue
+		}
+		f, err := strconv.ParseFloat(v[0], 64)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "bad value of option %s: %q", name, v[0])
+		}
+		options[name] = f
+	}
+	opts, err := d.Resolve(options)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "%v", err)
+	}
+	for i, o := range d.Options {
+		options[o.Name] = opts[i]
+	}
+	var reals []indicator.Input
+	if v := r.URL.Query().Get("inputs"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			in := indicator.Input(strings.TrimSpace(name))
+			if !column(in) {
+				return nil, errorf(http.StatusBadRequest, "unknown input %q", name)
+			}
+			reals = append(reals, in)
+		}
+	}
+	lookback, _ := d.Lookback(options)
+	warmup := lookback
+	if warmup > maxWarmup {
+		warmup = maxWarmup
+	}
+
+	q, err := cryptoapi.query(r, args[1:])
+	if err != nil {
+		return nil, err
+	}
+	data, warm, res, err := cryptoapi.window(q, warmup)
+	if err != nil {
+		return nil, err
+	}
+	values := indicatorValues{
+		Indicator: d.ID(),
+		Options:   options,
+		OpenTime:  append([]int64{}, data.OpenTime[warm:]...),
+		Outputs:   make(map[string][]*float64, len(d.Outputs)),
+	}
+	if data.len() > 0 {
+		out, err := d.Invoke(data, options, reals...)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "%v", err)
+		}
+		for i, name := range d.Outputs {
+			// the values before the lookback are not meaningful
+			for j := 0; j < lookback && j < len(out[i]); j++ {
+				out[i][j] = math.NaN()
+			}
+			values.Outputs[name] = nullable(out[i][warm:])
+		}
+	}
+	res.Data = values
+	return res, nil
+}
+
+// column tells whether in names a candle column.
+func column(in indicator.Input) bool {
+	switch in {
+	case indicator.Open, indicator.High, indicator.Low, indicator.Close, indicator.Volume,
+		indicator.QuoteVolume, indicator.Trades, indicator.TakerBuyVolume, indicator.TakerBuyQuoteVolume:
+		return true
+	}
+	return false
+}
+
+func nullable(v []float64) []*float64 {
+	res := make([]*float64, len(v))
+	for i := range v {
+		if !math.IsNaN(v[i]) && !math.IsInf(v[i], 0) {
+			res[i] = &v[i]
+		}
+	}
+	return res
+}
+
+// activeSignal is a rule that fired on a ticker and has not re-armed yet,
+// with the signal it last fired.
+type activeSignal struct {
+	Rule     string        `json:"rule"`
+	Symbol   string        `json:"symbol"`
+	Interval string        `json:"interval"`
+	State    rules.State   `json:"state"`
+	Signal   *rules.Signal `json:"signal,omitempty"`
+}
+
+func (cryptoapi *CryptoAPI) serveActiveSignals(r *http.Request, _ []string) (*page, error) {
+	f := signalFilter(r)
+	last := make(map[string]rules.Signal)
+	for _, s := range cryptoapi.Rules.History() {
+		last[rules.StateKey(s.Rule, s.Symbol, s.Interval)] = s
+	}
+	res := []activeSignal{}
+	for key, state := range cryptoapi.Rules.States() {
+		if state.Phase != rules.Triggered {
+			continue
+		}
+		parts := strings.SplitN(key, "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		a := activeSignal{Rule: parts[0], Symbol: parts[1], Interval: parts[2], State: state}
+		if !f(rules.Signal{Rule: a.Rule, Symbol: a.Symbol, Interval: a.Interval}) {
+			continue
+		}
+		if s, ok := last[key]; ok {
+			a.Signal = &s
+		}
+		res = append(res, a)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].State.Fired > res[j].State.Fired
+	})
+	return &page{Data: res, Total: len(res)}, nil
+}
+
+// serveSignalHistory pages through the signals fired, newest first.
+func (cryptoapi *CryptoAPI) serveSignalHistory(r *http.Request, _ []string) (*page, error) {
+	f := signalFilter(r)
+	limit, err := intParam(r, "limit", defaultLimit, 1, maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := intParam(r, "offset", 0, 0, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	history := cryptoapi.Rules.History()
+	matched := []rules.Signal{}
+	for i := len(history) - 1; i >= 0; i-- {
+		if f(history[i]) {
+			matched = append(matched, history[i])
+		}
+	}
+	res := &page{Total: len(matched)}
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	end := offset + limit
+	if end < len(matched) {
+		res.Next = cursor(int64(end))
+	} else {
+		end = len(matched)
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		res.Prev = cursor(int64(prev))
+	}
+	res.Data = matched[offset:end]
+	return res, nil
+}
+
+// signalFilter matches the signals of the symbol, interval and rule asked
+// for, all of them by default.
+func signalFilter(r *http.Request) func(rules.Signal) bool {
+	q := r.URL.Query()
+	symbol, interval, rule := strings.ToUpper(q.Get("symbol")), q.Get("interval"), q.Get("rule")
+	return func(s rules.Signal) bool {
+		return (symbol == "" || s.Symbol == symbol) &&
+			(interval == "" || s.Interval == interval) &&
+			(rule == "" || s.Rule == rule)
+	}
+}
+
+// candleQuery is a page of candles asked for: the first limit ones opened
+// from from when it is set, else the last limit ones opened until to.
+type candleQuery struct {
+	symbol, interval string
+	from, to         int64
+	limit            int
+	// length of a candle, about that of the candles bars are built from
+	span int64
+	// whether candles are of a time interval rather than bars, so span
+	// bounds how long limit of them last
+	timed bool
+}
+
+func (cryptoapi *CryptoAPI) query(r *http.Request, args []string) (candleQuery, error) {
+	q := candleQuery{symbol: strings.ToUpper(args[0]), interval: args[1], to: math.MaxInt64}
+	base := q.interval
+	if q.timed = !strings.Contains(q.interval, ":"); !q.timed {
+		spec, err := bars.Parse(q.interval)
+		if err != nil {
+			return q, errorf(http.StatusBadRequest, "%v", err)
+		}
+		base = spec.Base
+	}
+	iv, err := aggregate.ParseInterval(base)
+	if err != nil {
+		return q, errorf(http.StatusBadRequest, "%v", err)
+	}
+	if q.span = iv.Millis(); q.span == 0 {
+		q.span = iv.Next(0)
+	}
+	if q.from, err = timeParam(r, "from", -1); err != nil {
+		return q, err
+	}
+	if q.to, err = timeParam(r, "to", math.MaxInt64); err != nil {
+		return q, err
+	}
+	if q.from > q.to {
+		return q, errorf(http.StatusBadRequest, "from is after to")
+	}
+	if q.limit, err = intParam(r, "limit", defaultLimit, 1, maxLimit); err != nil {
+		return q, err
+	}
+	if !cryptoapi.follows(q.symbol) {
+		return q, errorf(http.StatusNotFound, "unknown symbol %s", q.symbol)
+	}
+	return q, nil
+}
+
+// follows tells whether candles of symbol are streamed or stored.
+func (cryptoapi *CryptoAPI) follows(symbol string) bool {
+	for _, s := range cryptoapi.Symbols() {
+		if s == symbol {
+			return true
+		}
+	}
+	series, err := cryptoapi.Store.Series()
+	return err == nil && len(series[symbol]) > 0
+}
+
+// window returns the candles of the page q asks for preceded by up to warmup
+// more, how many precede it and the page with its cursors.
+func (cryptoapi *CryptoAPI) window(q candleQuery, warmup int) (*klineData, int, *page, error) {
+	res := new(page)
+	if q.from >= 0 {
+		// limit candles of a time interval last no more than limit spans,
+		// the one after them giving the next cursor
+		to := q.to
+		if end := q.from + int64(q.limit+1)*q.span; q.timed && end < to {
+			to = end
+		}
+		data, err := cryptoapi.candles(q.symbol, q.interval, q.from-int64(warmup)*q.span, to)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		i := sort.Search(data.len(), func(i int) bool { return data.OpenTime[i] >= q.from })
+		start, end := i-warmup, i+q.limit
+		if start < 0 {
+			start = 0
+		}
+		if end < data.len() {
+			res.Next = cursor(data.OpenTime[end])
+		} else {
+			end = data.len()
+			// a gap cut the page short of limit, later candles may follow it
+			if to < q.to {
+				last, err := cryptoapi.lastOpenTime(q.symbol, q.interval)
+				if err != nil {
+					return nil, 0, nil, err
+				}
+				if last > to {
+					res.Next = cursor(to + 1)
+				}
+			}
+		}
+		return data.slice(start, end), i - start, res, nil
+	}
+
+	last, err := cryptoapi.lastOpenTime(q.symbol, q.interval)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if last > q.to {
+		last = q.to
+	}
+	n := q.limit + warmup
+	data, err := cryptoapi.candles(q.symbol, q.interval, last-int64(n)*q.span, q.to)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	// a full page may have older candles before it
+	i := data.len() - q.limit
+	if i < 0 {
+		i = 0
+	} else if data.len() > 0 {
+		res.Prev = cursor(data.OpenTime[i] - 1)
+	}
+	start := i - warmup
+	if start < 0 {
+		start = 0
+	}
+	return data.slice(start, data.len()), i - start, res, nil
+}
+
+// candles reads the candles of a ticker opened within [from, to], the cached
+// ones completed by the stored ones before them.
+func (cryptoapi *CryptoAPI) candles(symbol, interval string, from, to int64) (*klineData, error) {
+	if from < 0 {
+		from = 0
+	}
+	cached, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, interval)).(*klineData)
+	var res *klineData
+	if cached.len() > 0 {
+		res = (*klineData)(cached.batch().Slice(from, to))
+		if cached.OpenTime[0] <= from {
+			return res, nil
+		}
+		if to >= cached.OpenTime[0] {
+			to = cached.OpenTime[0] - 1
+		}
+	}
+	b, err := cryptoapi.history(symbol, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return mergeKlines((*klineData)(b.Slice(from, to)), res), nil
+}
+
+// lastOpenTime is the open time of the last cached or stored candle of a
+// ticker, that of the candles it is built from when it has none itself.
+func (cryptoapi *CryptoAPI) lastOpenTime(symbol, interval string) (int64, error) {
+	cached, _ := cryptoapi.Cache.Get(cryptoapi.FormatTickerKey(symbol, interval)).(*klineData)
+	if n := cached.len(); n > 0 {
+		return cached.OpenTime[n-1], nil
+	}
+	if spec, err := bars.Parse(interval); err == nil {
+		interval = spec.Base
+	}
+	_, last, ok, err := cryptoapi.Store.Bounds(symbol, interval)
+	if err != nil || ok || interval == baseInterval {
+		return last, err
+	}
+	_, last, _, err = cryptoapi.Store.Bounds(symbol, baseInterval)
+	return last, err
+}
+
+// slice copies the candles from i to j.
+func (d *klineData) slice(i, j int) *klineData {
+	res := new(klineData)
+	for ; i < j; i++ {
+		res.appendFrom(d, i)
+	}
+	return res
+}
+
+// timeParam reads a time in milliseconds or RFC 3339.
+func timeParam(r *http.Request, name string, def int64) (int64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+		return n, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, errorf(http.StatusBadRequest, "bad %s: %q is neither milliseconds nor RFC 3339", name, v)
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+func intParam(r *http.Request, name string, def, min, max int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || n > max {
+		return 0, errorf(http.StatusBadRequest, "bad %s: %q is not a number within [%d, %d]", name, v, min, max)
+	}
+	return n, nil
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cryptoapi/internal/cache"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/rules"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/universe"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const minute = int64(60000)
+
+// response is the body of any answer of the API.
+type response struct {
+	Data  json.RawMessage `json:"data"`
+	Next  *int64          `json:"next"`
+	Prev  *int64          `json:"prev"`
+	Total int             `json:"total"`
+	Error *httpError      `json:"error"`
+}
+
+// server follows BTCUSDT, whose ten cached 1m candles close at 1 to 10,
+// and has fired the signals.
+func server(t *testing.T, signals ...rules.Signal) (*CryptoAPI, http.Handler) {
+	viper.Set("universe.include", []string{"BTCUSDT"})
+	t.Cleanup(func() { viper.Set("universe.include", nil) })
+	logger := &logging.Logger{Logger: logrus.New()}
+	cryptoapi := &CryptoAPI{
+		Logger:   logger,
+		Cache:    cache.New(),
+		Universe: universe.New(nil, universe.Filter{}, logger, nil),
+		Store:    store.New(t.TempDir()),
+		Rules:    rules.New(nil, logger),
+	}
+	var data *klineData
+	for i := 0; i < 10; i++ {
+		data = data.merge(kline(int64(i)*minute, float64(i+1)))
+	}
+	cryptoapi.Cache.Set(cryptoapi.FormatTickerKey("BTCUSDT", "1m"), data)
+	cryptoapi.Rules.Restore(rules.Snapshot{History: signals})
+	return cryptoapi, cryptoapi.Handler()
+}
+
+func get(t *testing.T, h http.Handler, method, url string) (*httptest.ResponseRecorder, response) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+	var res response
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("%s %s: %v in %s", method, url, err, w.Body)
+	}
+	return w, res
+}
+
+func TestErrors(t *testing.T) {
+	_, h := server(t)
+	for _, tt := range []struct {
+		method, url string
+		status      int
+		message     string
+	}{
+		{"GET", "/v1/nothing", 404, "no such endpoint /v1/nothing"},
+		{"GET", "/v1/candles/BTCUSDT", 404, "no such endpoint"},
+		{"GET", "/v1/candles/DOGEUSDT/1m", 404, "unknown symbol DOGEUSDT"},
+		{"GET", "/v1/indicators/nothing", 404, "nothing"},
+		{"POST", "/v1/symbols", 405, "method POST not allowed"},
+		{"DELETE", "/v1/candles/BTCUSDT/1m", 405, "method DELETE not allowed"},
+		{"GET", "/v1/candles/BTCUSDT/7x", 400, ""},
+		{"GET", "/v1/candles/BTCUSDT/1m?limit=0", 400, "bad limit"},
+		{"GET", "/v1/candles/BTCUSDT/1m?limit=x", 400, "bad limit"},
+		{"GET", "/v1/candles/BTCUSDT/1m?from=yesterday", 400, "bad from"},
+		{"GET", "/v1/candles/BTCUSDT/1m?from=2&to=1", 400, "from is after to"},
+		{"GET", "/v1/indicators/sma/BTCUSDT/1m?timeperiod=x", 400, "bad value of option timeperiod"},
+		{"GET", "/v1/indicators/sma/BTCUSDT/1m?inputs=price", 400, "unknown input"},
+		{"GET", "/v1/signals/history?offset=-1", 400, "bad offset"},
+	} {
+		w, res := get(t, h, tt.method, tt.url)
+		if w.Code != tt.status || res.Error == nil || res.Error.Status != tt.status || !strings.Contains(res.Error.Message, tt.message) {
+			t.Errorf("%s %s: %d %s, want %d %q", tt.method, tt.url, w.Code, w.Body, tt.status, tt.message)
+		}
+		if tt.status == 405 && w.Header().Get("Allow") != "GET, HEAD" {
+			t.Errorf("%s %s: allows %q", tt.method, tt.url, w.Header().Get("Allow"))
+		}
+	}
+
+	// other errors answer with a 500 that tells nothing of them
+	cryptoapi, _ := server(t)
+	w := httptest.NewRecorder()
+	cryptoapi.writeError(w, errors.New("open /var/lib/klines: permission denied"))
+	if w.Code != 500 || strings.Contains(w.Body.String(), "klines") || !strings.Contains(w.Body.String(), `"status":500`) {
+		t.Errorf("%d %s", w.Code, w.Body)
+	}
+}
+
+// openTimes reads the minutes the candles of a page open at.
+func openTimes(t *testing.T, data json.RawMessage) []int64 {
+	var rows []candle
+	if err := json.Unmarshal(data, &rows); err != nil {
+		t.Fatal(err)
+	}
+	res := []int64{}
+	for _, c := range rows {
+		res = append(res, c.OpenTime/minute)
+	}
+	return res
+}
+
+func cursorOf(p *int64) string {
+	if p == nil {
+		return "none"
+	}
+	return strconv.FormatInt(*p, 10)
+}
+
+func TestCandlePages(t *testing.T) {
+	_, h := server(t)
+	for _, tt := range []struct {
+		query      string
+		want       []int64
+		next, prev string
+	}{
+		// the last candles, the older ones before them
+		{"limit=3", []int64{7, 8, 9}, "none", "419999"},
+		{"to=419999&limit=3", []int64{4, 5, 6}, "none", "239999"},
+		{"to=119999&limit=3", []int64{0, 1}, "none", "none"},
+		// the first candles from a time, the newer ones after them
+		{"from=0&limit=4", []int64{0, 1, 2, 3}, "240000", "none"},
+		{"from=240000&limit=4", []int64{4, 5, 6, 7}, "480000", "none"},
+		{"from=480000&limit=4", []int64{8, 9}, "none", "none"},
+		{"from=90000&to=240000", []int64{2, 3, 4}, "none", "none"},
+		{"from=1970-01-01T00:05:00Z&limit=1", []int64{5}, "360000", "none"},
+		{"from=600000", []int64{}, "none", "none"},
+	} {
+		w, res := get(t, h, "GET", "/v1/candles/BTCUSDT/1m?"+tt.query)
+		if w.Code != 200 {
+			t.Errorf("%s: %d %s", tt.query, w.Code, w.Body)
+			continue
+		}
+		if got := openTimes(t, res.Data); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: minutes %v, want %v", tt.query, got, tt.want)
+		}
+		if next, prev := cursorOf(res.Next), cursorOf(res.Prev); next != tt.next || prev != tt.prev {
+			t.Errorf("%s: next %s, prev %s, want %s and %s", tt.query, next, prev, tt.next, tt.prev)
+		}
+	}
+}
+
+func TestIndicatorWarmup(t *testing.T) {
+	_, h := server(t)
+	for _, tt := range []struct {
+		query string
+		want  []float64
+	}{
+		// the candles before the page warm the average up
+		{"from=300000&limit=3", []float64{5, 6, 7}},
+		{"limit=2", []float64{8, 9}},
+		// nothing precedes the first candles
+		{"from=0&limit=4", []float64{-1, -1, 2, 3}},
+	} {
+		w, res := get(t, h, "GET", "/v1/indicators/sma/BTCUSDT/1m?timeperiod=3&"+tt.query)
+		if w.Code != 200 {
+			t.Errorf("%s: %d %s", tt.query, w.Code, w.Body)
+			continue
+		}
+		var values indicatorValues
+		if err := json.Unmarshal(res.Data, &values); err != nil {
+			t.Fatal(err)
+		}
+		if len(values.Outputs) != 1 || len(values.OpenTime) != len(tt.want) || values.Options["timeperiod"] != 3 {
+			t.Fatalf("%s: got %+v", tt.query, values)
+		}
+		for _, out := range values.Outputs {
+			got := make([]float64, len(out))
+			for i, v := range out {
+				got[i] = -1
+				if v != nil {
+					got[i] = *v
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: got %v, want %v (-1 for null)", tt.query, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSignalPages(t *testing.T) {
+	var signals []rules.Signal
+	for i := 1; i <= 5; i++ {
+		signals = append(signals, rules.Signal{Rule: "rule", Symbol: "BTCUSDT", Interval: "1m", Time: int64(i)})
+	}
+	signals = append(signals, rules.Signal{Rule: "rule", Symbol: "ETHUSDT", Interval: "1m", Time: 6})
+	_, h := server(t, signals...)
+	for _, tt := range []struct {
+		query      string
+		want       []int64
+		next, prev string
+	}{
+		{"symbol=btcusdt&limit=2", []int64{5, 4}, "2", "none"},
+		// the first page is at offset 0, not left out
+		{"symbol=btcusdt&limit=2&offset=2", []int64{3, 2}, "4", "0"},
+		{"symbol=btcusdt&limit=2&offset=3", []int64{2, 1}, "none", "1"},
+		{"symbol=btcusdt&limit=2&offset=4", []int64{1}, "none", "2"},
+		{"symbol=btcusdt&offset=9", []int64{}, "none", "0"},
+		{"limit=1", []int64{6}, "1", "none"},
+	} {
+		w, res := get(t, h, "GET", "/v1/signals/history?"+tt.query)
+		if w.Code != 200 {
+			t.Errorf("%s: %d %s", tt.query, w.Code, w.Body)
+			continue
+		}
+		var page []rules.Signal
+		if err := json.Unmarshal(res.Data, &page); err != nil {
+			t.Fatal(err)
+		}
+		got := []int64{}
+		for _, s := range page {
+			got = append(got, s.Time)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: times %v, want %v", tt.query, got, tt.want)
+		}
+		if next, prev := cursorOf(res.Next), cursorOf(res.Prev); next != tt.next || prev != tt.prev {
+			t.Errorf("%s: next %s, prev %s, want %s and %s", tt.query, next, prev, tt.next, tt.prev)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"cryptoapi/internal/bars"
+	"cryptoapi/internal/rules"
+)
+
+// HandleSignals registers fn to be told of every signal fired.
+func (cryptoapi *CryptoAPI) HandleSignals(fn func(rules.Signal)) {
+	cryptoapi.mu.Lock()
+	defer cryptoapi.mu.Unlock()
+	cryptoapi.handlers = append(cryptoapi.handlers, fn)
+}
+
+func loadSignals() (rules.Snapshot, error) {
+	var res rules.Snapshot
+	b, err := ioutil.ReadFile(dataPath("signals.json"))
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(b, &res)
+	return res, err
+}
+
+// saveSignals persists the rule states and the signal history when they
+// changed, so a restart neither fires again nor forgets what fired.
+func (cryptoapi *CryptoAPI) saveSignals() {
+	cryptoapi.saving.Lock()
+	defer cryptoapi.saving.Unlock()
+	snapshot, changed := cryptoapi.Rules.Snapshot()
+	if !changed {
+		return
+	}
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(dataPath("signals.json"), b)
+	}
+	if err != nil {
+		cryptoapi.WithError(err).Debug("failed saving the signal state")
+	}
+}
+
+// evaluate runs the rules on the last candle of data, which has closed, and
+// on the configured bars built from data. Each candle is evaluated once
+// however often it is polled.
+func (cryptoapi *CryptoAPI) evaluate(symbol, interval string, data *klineData) {
+	cryptoapi.evaluateOnce(symbol, interval, data)
+	for _, spec := range virtualIntervals(interval) {
+		cryptoapi.evaluateOnce(symbol, spec.String(), (*klineData)(spec.Build(data.batch())))
+	}
+}
+
+func (cryptoapi *CryptoAPI) evaluateOnce(symbol, interval string, data *klineData) {
+	n := data.len()
+	if n == 0 {
+		return
+	}
+	key := cryptoapi.FormatTickerKey(symbol, interval)
+	cryptoapi.mu.Lock()
+	state, ok := cryptoapi.tickers[key]
+	if !ok {
+		state = new(tickerState)
+		cryptoapi.tickers[key] = state
+	}
+	if data.OpenTime[n-1] <= state.committed {
+		cryptoapi.mu.Unlock()
+		return
+	}
+	state.committed = data.OpenTime[n-1]
+	handlers := cryptoapi.handlers
+	cryptoapi.mu.Unlock()
+
+	src := frameSource{klineData: data, cryptoapi: cryptoapi, symbol: symbol}
+	for _, s := range cryptoapi.Rules.Evaluate(symbol, interval, src) {
+		cryptoapi.Debugf("signal %s: %s %s_%s at %v", s.Rule, s.Direction, s.Symbol, s.Interval, s.Price)
+		for _, fn := range handlers {
+			fn(s)
+		}
+	}
+	cryptoapi.saveSignals()
+	cryptoapi.publishIndicators(symbol, interval, src)
+}
+
+// frameSource is the candles of a symbol the rules run on, reaching the other
+// intervals of the symbol through the cache.
+type frameSource struct {
+	*klineData
+	cryptoapi *CryptoAPI
+	symbol    string
+}
+
+func (s frameSource) CloseTimes() []int64 {
+	return s.CloseTime
+}
+
+// Frame returns the cached candles of another interval. The one still
+// forming closes after the candle evaluated, so the rules leave it out.
+// Bars not configured are built from the cached candles of their base.
+func (s frameSource) Frame(interval string) (rules.Source, error) {
+	data, _ := s.cryptoapi.Cache.Get(s.cryptoapi.FormatTickerKey(s.symbol, interval)).(*klineData)
+	if data.len() == 0 {
+		if spec, err := bars.Parse(interval); err == nil {
+			base, _ := s.cryptoapi.Cache.Get(s.cryptoapi.FormatTickerKey(s.symbol, spec.Base)).(*klineData)
+			if base.len() > 0 {
+				data = (*klineData)(spec.Build(base.batch()))
+			}
+		}
+	}
+	if data.len() == 0 {
+		return nil, fmt.Errorf("no %s candles of %s", interval, s.symbol)
+	}
+	return frameSource{klineData: data, cryptoapi: s.cryptoapi, symbol: s.symbol}, nil
+}
@@ -0,0 +1,240 @@
+// Package backtest replays candles through a strategy and simulates its
+// orders with fees, slippage, spread and partial fills, long and short, any
+// number of positions at once.
+//
+// The strategy decides as a candle closes and its orders fill from the next
+// candle on, so it never trades at a price it could not have seen. There is
+// no margin model: orders fill whatever the cash left, sizing is up to the
+// strategy and its Sizer.
+package backtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cryptoapi/internal/stream"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	// starting cash, in the quote asset
+	Cash float64
+	// fee rates of the value filled, for limit orders resting in the book
+	// and for the others
+	MakerFee float64
+	TakerFee float64
+	// fractions of the price market and stop orders lose, half the spread
+	// and the slippage
+	Spread   float64
+	Slippage float64
+	// part of the volume of a candle the orders can fill on it, all of it
+	// when zero
+	Participation float64
+	// size of the positions the strategy asks for
+	Sizer Sizer
+	// first candles only warming up the indicators, trading starting after
+	Warmup int
+	// way the price moves within a candle, OHLC when empty
+	Path Path
+}
+
+// MarketPrice is the price a market order fills at with the market at
+// price, the spread and the slippage paid.
+func (cfg Config) MarketPrice(side Side, price float64) float64 {
+	return price * (1 + side.sign()*(cfg.Spread/2+cfg.Slippage))
+}
+
+// FromConfig reads the backtest section of the config.
+func FromConfig() (Config, error) {
+	sizer, err := ParseSizer(viper.GetString("backtest.size"))
+	if err != nil {
+		return Config{}, err
+	}
+	path := Path(viper.GetString("backtest.path"))
+	if err := path.Check(); err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Cash:          viper.GetFloat64("backtest.cash"),
+		MakerFee:      viper.GetFloat64("backtest.maker-fee"),
+		TakerFee:      viper.GetFloat64("backtest.taker-fee"),
+		Spread:        viper.GetFloat64("backtest.spread"),
+		Slippage:      viper.GetFloat64("backtest.slippage"),
+		Participation: viper.GetFloat64("backtest.participation"),
+		Sizer:         sizer,
+		Path:          path,
+	}, nil
+}
+
+// Sizer tells the quantity of a new position.
+type Sizer interface {
+	Size(equity, price float64) float64
+}
+
+// Quantity sizes positions to a fixed quantity of the base asset.
+type Quantity float64
+
+func (q Quantity) Size(equity, price float64) float64 {
+	return float64(q)
+}
+
+// Notional sizes positions to a fixed value in the quote asset.
+type Notional float64
+
+func (n Notional) Size(equity, price float64) float64 {
+	return float64(n) / price
+}
+
+// Percent sizes positions to a percentage of the equity.
+type Percent float64
+
+func (p Percent) Size(equity, price float64) float64 {
+	return equity * float64(p) / 100 / price
+}
+
+// ParseSizer reads a size such as 1000, a value in the quote asset, or 10%,
+// a part of the equity.
+func ParseSizer(s string) (Sizer, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil || !(v > 0) {
+			return nil, fmt.Errorf("bad size %q", s)
+		}
+		return Percent(v), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || !(v > 0) {
+		return nil, fmt.Errorf("bad size %q", s)
+	}
+	return Notional(v), nil
+}
+
+// Point is the account at the close of a candle.
+type Point struct {
+	Time   int64   `json:"time"`
+	Equity float64 `json:"equity"`
+	// positions open
+	Positions int `json:"positions"`
+}
+
+type Result struct {
+	Cash   float64 `json:"cash"`
+	Equity float64 `json:"equity"`
+	Fees   float64 `json:"fees"`
+	Trades []Trade `json:"trades"`
+	Fills  []Fill  `json:"fills"`
+	Curve  []Point `json:"curve"`
+	// positions still open at the end, valued in Equity at the last close
+	Open []Position `json:"open"`
+}
+
+// Context is what the strategy sees of the run as a candle closes.
+type Context struct {
+	// index of the candle in the source
+	Index  int
+	Candle stream.Candle
+	// close time of the candle
+	Time int64
+
+	broker *broker
+}
+
+func (c *Context) Cash() float64 {
+	return c.broker.cash
+}
+
+// Equity is the cash and the open positions valued at the close.
+func (c *Context) Equity() float64 {
+	return c.broker.equity(c.Candle.Close)
+}
+
+// Positions returns the open positions, oldest first.
+func (c *Context) Positions() []*Position {
+	return append([]*Position(nil), c.broker.positions...)
+}
+
+// Orders returns the orders not filled yet, oldest first.
+func (c *Context) Orders() []*Order {
+	var res []*Order
+	for _, o := range c.broker.orders {
+		if o.Status == Open {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// Size is the quantity the sizer gives a new position at the close.
+func (c *Context) Size() float64 {
+	if c.broker.cfg.Sizer == nil {
+		return 0
+	}
+	return c.broker.cfg.Sizer.Size(c.Equity(), c.Candle.Close)
+}
+
+// Submit places an order, filling from the next candle on. Orders closing a
+// position take the side opposite to it.
+func (c *Context) Submit(o Order) *Order {
+	return c.broker.submit(o, c.Index)
+}
+
+// Buy opens a long position of qty at the next open.
+func (c *Context) Buy(qty float64, tag string) *Order {
+	return c.Submit(Order{Side: Buy, Type: Market, Qty: qty, Tag: tag})
+}
+
+// Sell opens a short position of qty at the next open.
+func (c *Context) Sell(qty float64, tag string) *Order {
+	return c.Submit(Order{Side: Sell, Type: Market, Qty: qty, Tag: tag})
+}
+
+// Close closes p at the next open.
+func (c *Context) Close(p *Position) *Order {
+	return c.Submit(Order{Type: Market, Position: p, Tag: p.Tag})
+}
+
+// OCO makes orders cancel each other, the first filling canceling the
+// others.
+func (c *Context) OCO(orders ...*Order) {
+	group := c.broker.id()
+	for _, o := range orders {
+		o.OCO = group
+	}
+}
+
+func (c *Context) Cancel(o *Order) {
+	if o.Status == Open {
+		o.Status = Canceled
+	}
+}
+
+// Run replays the candles of src through s.
+func Run(src Source, s Strategy, cfg Config) (*Result, error) {
+	if err := cfg.Path.Check(); err != nil {
+		return nil, err
+	}
+	if err := s.Init(src); err != nil {
+		return nil, err
+	}
+	b := newBroker(cfg, src)
+	times := src.CloseTimes()
+	res := &Result{Cash: cfg.Cash, Curve: make([]Point, 0, len(times))}
+	for i := cfg.Warmup; i < len(times); i++ {
+		c := src.Candle(i)
+		b.fill(i, c, times[i])
+		res.Curve = append(res.Curve, Point{Time: times[i], Equity: b.equity(c.Close), Positions: len(b.positions)})
+		s.OnCandle(&Context{Index: i, Candle: c, Time: times[i], broker: b})
+	}
+	res.Equity = cfg.Cash
+	if n := len(res.Curve); n > 0 {
+		res.Equity = res.Curve[n-1].Equity
+	}
+	res.Fees, res.Trades, res.Fills = b.fees, b.trades, b.fills
+	for _, p := range b.positions {
+		res.Open = append(res.Open, *p)
+	}
+	return res, nil
+}
@@ -0,0 +1,206 @@
+package backtest
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"cryptoapi/internal/rules"
+)
+
+// steps runs each function as the candle it is keyed by closes.
+type steps map[int]func(c *Context)
+
+func (s steps) Init(rules.Source) error { return nil }
+
+func (s steps) OnCandle(c *Context) {
+	if f := s[c.Index]; f != nil {
+		f(c)
+	}
+}
+
+// closeFirst closes the oldest position open.
+func closeFirst(c *Context) {
+	c.Close(c.Positions()[0])
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNextOpen(t *testing.T) {
+	src := candles(flat, [4]float64{101, 103, 99, 102}, [4]float64{102, 105, 101, 104}, [4]float64{105, 106, 104, 105})
+	res := run(t, src, steps{
+		0: func(c *Context) { c.Buy(1, "long") },
+		2: closeFirst,
+	}, Config{})
+	if len(res.Trades) != 1 {
+		t.Fatalf("%d trades", len(res.Trades))
+	}
+	tr := res.Trades[0]
+	if tr.Entry != 101 || tr.Exit != 105 || tr.PnL != 4 || tr.Opened != 2*minute-1 || tr.Closed != 4*minute-1 || tr.Tag != "long" {
+		t.Errorf("got %+v, want in at 101 on candle 1 and out at 105 on candle 3", tr)
+	}
+	// the equity of a candle is taken at its close, after its fills
+	var equity []float64
+	for _, p := range res.Curve {
+		equity = append(equity, p.Equity)
+	}
+	if want := []float64{1000, 1001, 1003, 1004}; !reflect.DeepEqual(equity, want) {
+		t.Errorf("equity %v, want %v", equity, want)
+	}
+	if res.Equity != 1004 || res.Cash != 1000 {
+		t.Errorf("ended with %v from %v", res.Equity, res.Cash)
+	}
+}
+
+func TestFees(t *testing.T) {
+	src := candles(flat, [4]float64{100, 101, 98, 100})
+	var market, limit *Order
+	res := run(t, src, steps{0: func(c *Context) {
+		market = c.Buy(1, "")
+		limit = c.Submit(Order{Side: Buy, Type: Limit, Qty: 1, Price: 99})
+	}}, Config{MakerFee: 0.0005, TakerFee: 0.001, Spread: 0.002, Slippage: 0.001})
+	if len(res.Fills) != 2 {
+		t.Fatalf("got fills %+v", res.Fills)
+	}
+	// the market order pays the spread, the slippage and the taker fee, the
+	// limit order resting in the book only the maker fee
+	for _, tt := range []struct {
+		order      *Order
+		price, fee float64
+	}{
+		{market, 100.2, 100.2 * 0.001},
+		{limit, 99, 99 * 0.0005},
+	} {
+		var f *Fill
+		for i := range res.Fills {
+			if res.Fills[i].Order == tt.order.ID {
+				f = &res.Fills[i]
+			}
+		}
+		if f == nil || !near(f.Price, tt.price) || !near(f.Fee, tt.fee) {
+			t.Errorf("%s order filled %+v, want at %v paying %v", tt.order.Type, f, tt.price, tt.fee)
+		}
+	}
+	fees := 100.2*0.001 + 99*0.0005
+	if !near(res.Fees, fees) || !near(res.Curve[1].Equity, 1000-100.2-99-fees+2*100) {
+		t.Errorf("fees %v, equity %v", res.Fees, res.Curve[1].Equity)
+	}
+}
+
+func TestMarketPrice(t *testing.T) {
+	cfg := Config{Spread: 0.002, Slippage: 0.001}
+	if got := cfg.MarketPrice(Buy, 100); !near(got, 100.2) {
+		t.Errorf("bought at %v, want 100.2", got)
+	}
+	if got := cfg.MarketPrice(Sell, 100); !near(got, 99.8) {
+		t.Errorf("sold at %v, want 99.8", got)
+	}
+
+	// a stop turns into a market order and pays them too, 5% under the
+	// price the entry paid
+	src := candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{100, 101, 94, 100})
+	res := run(t, src, buyOn(&Bracket{StopLoss: 0.05}), Config{Spread: 0.002})
+	exit(t, res, 100.1*0.95*0.999, Stop, 2)
+	if tr := res.Trades[0]; !near(tr.Entry, 100.1) {
+		t.Errorf("entered at %v, want 100.1", tr.Entry)
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	// a thousandth of the volume of 1000 is one unit per candle
+	src := candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{102, 103, 101, 102}, [4]float64{104, 105, 103, 104}, flat)
+	var first, second *Order
+	res := run(t, src, steps{0: func(c *Context) {
+		first = c.Buy(2.5, "")
+		second = c.Buy(0.5, "")
+	}}, Config{Participation: 0.001})
+	var qty []float64
+	for _, f := range res.Fills {
+		qty = append(qty, f.Qty)
+	}
+	// the first order takes what a candle offers before the second gets any
+	if want := []float64{1, 1, 0.5, 0.5}; !reflect.DeepEqual(qty, want) {
+		t.Errorf("filled %v, want %v", qty, want)
+	}
+	if first.Status != Filled || second.Status != Filled || len(res.Open) != 2 {
+		t.Fatalf("orders %s and %s, %d positions", first.Status, second.Status, len(res.Open))
+	}
+	if p := res.Open[0]; p.Qty != 2.5 || !near(p.Entry, (100+102+0.5*104)/2.5) {
+		t.Errorf("position of %v at %v", p.Qty, p.Entry)
+	}
+	if p := res.Open[1]; p.Entry != 104 {
+		t.Errorf("second position at %v, want 104", p.Entry)
+	}
+}
+
+func TestShort(t *testing.T) {
+	src := candles(flat, [4]float64{100, 101, 94, 95}, [4]float64{90, 91, 89, 90})
+	res := run(t, src, steps{
+		0: func(c *Context) { c.Sell(2, "") },
+		1: closeFirst,
+	}, Config{})
+	if len(res.Trades) != 1 {
+		t.Fatalf("%d trades", len(res.Trades))
+	}
+	tr := res.Trades[0]
+	if tr.Side != Sell || tr.Entry != 100 || tr.Exit != 90 || tr.PnL != 20 || tr.Return != 0.1 {
+		t.Errorf("got %+v, want a short from 100 to 90 making 20", tr)
+	}
+	// selling adds to the cash, the position weighing on the equity
+	if res.Curve[1].Equity != 1010 || res.Equity != 1020 {
+		t.Errorf("equity %v then %v, want 1010 and 1020", res.Curve[1].Equity, res.Equity)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	src := candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{102, 103, 101, 102}, [4]float64{104, 105, 103, 104}, [4]float64{106, 107, 105, 106})
+	res := run(t, src, steps{
+		0: func(c *Context) { c.Buy(1, "long") },
+		1: func(c *Context) { c.Sell(1, "short") },
+		2: closeFirst,
+		3: closeFirst,
+	}, Config{})
+	var open []int
+	for _, p := range res.Curve {
+		open = append(open, p.Positions)
+	}
+	if want := []int{0, 1, 2, 1, 0}; !reflect.DeepEqual(open, want) {
+		t.Errorf("positions %v, want %v", open, want)
+	}
+	if len(res.Trades) != 2 {
+		t.Fatalf("%d trades", len(res.Trades))
+	}
+	long, short := res.Trades[0], res.Trades[1]
+	if long.Tag != "long" || long.PnL != 4 || short.Tag != "short" || short.PnL != -4 || long.Position == short.Position {
+		t.Errorf("got %+v and %+v", long, short)
+	}
+	if res.Equity != 1000 {
+		t.Errorf("ended with %v, want 1000", res.Equity)
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	src := candles(flat, flat, flat, [4]float64{101, 101, 101, 101}, [4]float64{102, 102, 102, 102})
+	var seen []int
+	s := steps{}
+	for i := 0; i < 5; i++ {
+		s[i] = func(c *Context) {
+			seen = append(seen, c.Index)
+			if c.Index == 2 {
+				c.Buy(1, "")
+			}
+		}
+	}
+	res := run(t, src, s, Config{Warmup: 2})
+	if !reflect.DeepEqual(seen, []int{2, 3, 4}) {
+		t.Errorf("saw candles %v, want 2 to 4", seen)
+	}
+	if len(res.Curve) != 3 || res.Curve[0].Time != 3*minute-1 {
+		t.Errorf("curve %+v, want one point from candle 2 on", res.Curve)
+	}
+	if len(res.Open) != 1 || res.Open[0].Entry != 101 || res.Equity != 1001 {
+		t.Errorf("open %+v, equity %v", res.Open, res.Equity)
+	}
+}
@@ -0,0 +1,71 @@
+package backtest
+
+import (
+	"errors"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/rules"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+)
+
+// Source is the candles a backtest runs on.
+type Source interface {
+	rules.Source
+	Candle(i int) stream.Candle
+}
+
+// Candles is a source of stored candles, other intervals of the symbol coming
+// from Frames.
+type Candles struct {
+	*store.Batch
+	Frames func(interval string) (*store.Batch, error)
+}
+
+func (c Candles) Series(in indicator.Input) []float64 {
+	switch in {
+	case indicator.Open:
+		return c.Open
+	case indicator.High:
+		return c.High
+	case indicator.Low:
+		return c.Low
+	case indicator.Close, indicator.Real:
+		return c.Close
+	case indicator.Volume:
+		return c.Volume
+	case indicator.QuoteVolume:
+		return c.QuoteAssetVolume
+	case indicator.Trades:
+		res := make([]float64, len(c.NumberOfTrades))
+		for i, n := range c.NumberOfTrades {
+			res[i] = float64(n)
+		}
+		return res
+	case indicator.TakerBuyVolume:
+		return c.TakerBuyBaseAssetVolume
+	case indicator.TakerBuyQuoteVolume:
+		return c.TakerBuyQuoteAssetVolume
+	}
+	return nil
+}
+
+func (c Candles) CloseTimes() []int64 {
+	return c.CloseTime
+}
+
+func (c Candles) Frame(interval string) (rules.Source, error) {
+	if c.Frames == nil {
+		return nil, errors.New("no other intervals")
+	}
+	b, err := c.Frames(interval)
+	if err != nil {
+		return nil, err
+	}
+	return Candles{Batch: b, Frames: c.Frames}, nil
+}
+
+func (c Candles) Candle(i int) stream.Candle {
+	return aggregate.Row(c.Batch, i).Candle
+}
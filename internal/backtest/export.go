@@ -0,0 +1,155 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func formatTime(ms int64) string {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// WriteCSV writes the trade ledger, one trade a row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"position", "side", "qty", "entry", "exit", "opened", "closed", "fees", "pnl", "return", "exit_order", "tag"})
+	for _, t := range r.Ledger {
+		out.Write([]string{
+			strconv.Itoa(t.Position), string(t.Side), formatFloat(t.Qty), formatFloat(t.Entry), formatFloat(t.Exit),
+			formatTime(t.Opened), formatTime(t.Closed), formatFloat(t.Fees), formatFloat(t.PnL), formatFloat(t.Return), string(t.ExitOrder), t.Tag,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteCurveCSV writes the equity curve, one candle a row.
+func (r *Report) WriteCurveCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"time", "equity", "positions"})
+	for _, p := range r.Curve {
+		out.Write([]string{formatTime(p.Time), formatFloat(p.Equity), strconv.Itoa(p.Positions)})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// chart is a line drawn in an SVG view box of chartWidth by chartHeight.
+type chart struct {
+	Points   string
+	Min, Max float64
+}
+
+const (
+	chartWidth  = 1000
+	chartHeight = 300
+	// most points drawn, the curve being sampled down to it
+	chartPoints = 2000
+)
+
+func newChart(values []float64) chart {
+	c := chart{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, v := range values {
+		c.Min, c.Max = math.Min(c.Min, v), math.Max(c.Max, v)
+	}
+	if len(values) == 0 {
+		return chart{}
+	}
+	step := 1
+	if len(values) > chartPoints {
+		step = (len(values) + chartPoints - 1) / chartPoints
+	}
+	var b strings.Builder
+	for i := 0; i < len(values); i += step {
+		x := float64(chartWidth)
+		if len(values) > 1 {
+			x = float64(i) / float64(len(values)-1) * chartWidth
+		}
+		y := chartHeight / 2.0
+		if c.Max > c.Min {
+			y = (c.Max - values[i]) / (c.Max - c.Min) * chartHeight
+		}
+		fmt.Fprintf(&b, "%.1f,%.1f ", x, y)
+	}
+	c.Points = b.String()
+	return c
+}
+
+// WriteHTML writes a self-contained page of the report, charts included.
+func (r *Report) WriteHTML(w io.Writer) error {
+	equity := make([]float64, len(r.Curve))
+	drawdown := make([]float64, len(r.Curve))
+	peak := r.Cash
+	for i, p := range r.Curve {
+		equity[i] = p.Equity
+		peak = math.Max(peak, p.Equity)
+		drawdown[i] = -ratio(peak-p.Equity, peak) * 100
+	}
+	return page.Execute(w, struct {
+		*Report
+		EquityChart, DrawdownChart chart
+		Width, Height              int
+	}{r, newChart(equity), newChart(drawdown), chartWidth, chartHeight})
+}
+
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"time":    formatTime,
+	"percent": func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+	"money":   func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"ratio":   func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"duration": func(ms int64) string {
+		return (time.Duration(ms) * time.Millisecond).Round(time.Minute).String()
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backtest {{time .From}} to {{time .To}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td, th { padding: 2px 10px; text-align: right; border-bottom: 1px solid #eee; }
+th { background: #f4f4f4; }
+svg { width: 100%; height: 300px; background: #fafafa; margin-bottom: 2em; }
+.win { color: #1a7f37; } .loss { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Backtest {{time .From}} to {{time .To}}</h1>
+<table>
+<tr><th>Cash</th><td>{{money .Cash}}</td><th>Equity</th><td>{{money .Equity}}</td><th>Fees</th><td>{{money .Fees}}</td></tr>
+<tr><th>Total return</th><td>{{percent .TotalReturn}}</td><th>Annual return</th><td>{{percent .AnnualReturn}}</td><th>Exposure</th><td>{{percent .Exposure}}</td></tr>
+<tr><th>Max drawdown</th><td>{{percent .MaxDrawdown}}</td><th>Max drawdown duration</th><td>{{duration .MaxDrawdownDuration}}</td><th>Calmar</th><td>{{ratio .Calmar}}</td></tr>
+<tr><th>Sharpe</th><td>{{ratio .Sharpe}}</td><th>Sortino</th><td>{{ratio .Sortino}}</td><th>Trades</th><td>{{.Trades}}</td></tr>
+<tr><th>Win rate</th><td>{{percent .WinRate}}</td><th>Profit factor</th><td>{{ratio .ProfitFactor}}</td><th>Expectancy</th><td>{{money .Expectancy}}</td></tr>
+<tr><th>Average trade</th><td>{{duration .AvgTradeDuration}}</td><th>Open positions</th><td>{{len .Open}}</td><th></th><td></td></tr>
+</table>
+<h2>Equity, {{money .EquityChart.Min}} to {{money .EquityChart.Max}}</h2>
+<svg viewBox="0 0 {{.Width}} {{.Height}}" preserveAspectRatio="none"><polyline fill="none" stroke="#1565c0" stroke-width="1.5" vector-effect="non-scaling-stroke" points="{{.EquityChart.Points}}"/></svg>
+<h2>Drawdown, down to {{money .DrawdownChart.Min}}%</h2>
+<svg viewBox="0 0 {{.Width}} {{.Height}}" preserveAspectRatio="none"><polyline fill="none" stroke="#c62828" stroke-width="1.5" vector-effect="non-scaling-stroke" points="{{.DrawdownChart.Points}}"/></svg>
+<h2>Trades</h2>
+<table>
+<tr><th>#</th><th>Side</th><th>Qty</th><th>Entry</th><th>Exit</th><th>Opened</th><th>Closed</th><th>Fees</th><th>PnL</th><th>Return</th><th>Exit</th><th>Tag</th></tr>
+{{range .Ledger}}<tr class="{{if gt .PnL 0.0}}win{{else}}loss{{end}}"><td>{{.Position}}</td><td>{{.Side}}</td><td>{{.Qty}}</td><td>{{.Entry}}</td><td>{{.Exit}}</td><td>{{time .Opened}}</td><td>{{time .Closed}}</td><td>{{money .Fees}}</td><td>{{money .PnL}}</td><td>{{percent .Return}}</td><td>{{.ExitOrder}}</td><td>{{.Tag}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
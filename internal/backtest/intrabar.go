@@ -0,0 +1,220 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"cryptoapi/internal/rules"
+	"cryptoapi/internal/stream"
+)
+
+// Path is the way the price is taken to move within a candle, which tells
+// the orders it reaches first, a stop or a target for instance.
+type Path string
+
+const (
+	// OHLC goes to the low first on rising candles and to the high first on
+	// falling ones, the wick against the move being taken to come first.
+	OHLC Path = "ohlc"
+	// HighFirst and LowFirst go to the high or the low first whatever the
+	// candle.
+	HighFirst Path = "high-first"
+	LowFirst  Path = "low-first"
+	// Worst fills the stops reached on a candle before the limit orders,
+	// and Best the other way round.
+	Worst Path = "worst"
+	Best  Path = "best"
+)
+
+func (p Path) Check() error {
+	switch p {
+	case "", OHLC, HighFirst, LowFirst, Worst, Best:
+		return nil
+	}
+	return fmt.Errorf("unknown intrabar path %q", p)
+}
+
+// points are the prices the path goes through, from the open to the close.
+func (p Path) points(c stream.Candle) [4]float64 {
+	highFirst := c.Close < c.Open
+	switch p {
+	case HighFirst:
+		highFirst = true
+	case LowFirst:
+		highFirst = false
+	}
+	if highFirst {
+		return [4]float64{c.Open, c.High, c.Low, c.Close}
+	}
+	return [4]float64{c.Open, c.Low, c.High, c.Close}
+}
+
+// reach returns how far along the path the price first gets to price from
+// below when up is set, from above otherwise, looking past position from,
+// from 0 at the open to 3 at the close.
+func reach(path [4]float64, from, price float64, up bool) (float64, bool) {
+	beyond := func(v float64) bool {
+		if up {
+			return v >= price
+		}
+		return v <= price
+	}
+	k := int(from)
+	at, v := from, path[len(path)-1]
+	if k < len(path)-1 {
+		v = path[k] + (from-float64(k))*(path[k+1]-path[k])
+	}
+	if beyond(v) {
+		return at, true
+	}
+	for k++; k < len(path); k++ {
+		if beyond(path[k]) {
+			return at + (price-v)/(path[k]-v)*(float64(k)-at), true
+		}
+		at, v = float64(k), path[k]
+	}
+	return 0, false
+}
+
+// touch tells how far along the path o triggers past position from, if it
+// does.
+func touch(o *Order, path [4]float64, from float64) (float64, bool) {
+	switch o.Type {
+	case Market:
+		return from, true
+	case Limit:
+		return reach(path, from, o.Price, o.Side == Sell)
+	case Stop, TrailingStop:
+		return reach(path, from, o.Price, o.Side == Buy)
+	}
+	return 0, false
+}
+
+// price tells whether o triggers on c, at which price before costs and
+// whether it takes liquidity. Orders reached at the open, by a gap, fill at
+// the open.
+func price(o *Order, c stream.Candle) (float64, bool, bool) {
+	switch o.Type {
+	case Market:
+		return c.Open, true, true
+	case Limit:
+		// a limit order reached at the open crosses the book
+		if o.Side == Buy && c.Low <= o.Price {
+			return math.Min(c.Open, o.Price), true, c.Open <= o.Price
+		}
+		if o.Side == Sell && c.High >= o.Price {
+			return math.Max(c.Open, o.Price), true, c.Open >= o.Price
+		}
+	case Stop, TrailingStop:
+		if o.Side == Buy && c.High >= o.Price {
+			return math.Max(c.Open, o.Price), true, true
+		}
+		if o.Side == Sell && c.Low <= o.Price {
+			return math.Min(c.Open, o.Price), true, true
+		}
+	}
+	return 0, false, false
+}
+
+// triggered returns the orders placed before candle i that c reaches, in the
+// order it does, along with the brackets placed by the fills of c reached
+// after their entry.
+func (b *broker) triggered(i int, c stream.Candle) []*Order {
+	type hit struct {
+		o    *Order
+		rank int
+		at   float64
+	}
+	path := b.cfg.Path.points(c)
+	var hits []hit
+	for _, o := range b.orders {
+		if o.Status != Open || o.Placed > i || o.Placed == i && !o.bracket {
+			continue
+		}
+		from := 0.
+		if o.Placed == i {
+			from = o.after
+		}
+		at, ok := touch(o, path, from)
+		if !ok {
+			continue
+		}
+		o.at = at
+		h := hit{o: o, at: at}
+		// market orders fill at the open whatever the path
+		stop := o.Type == Stop || o.Type == TrailingStop
+		switch {
+		case o.Type == Market:
+		case b.cfg.Path == Worst && !stop, b.cfg.Path == Best && stop:
+			h.rank = 2
+		default:
+			h.rank = 1
+		}
+		hits = append(hits, h)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].rank != hits[j].rank {
+			return hits[i].rank < hits[j].rank
+		}
+		return hits[i].at < hits[j].at
+	})
+	res := make([]*Order, len(hits))
+	for k, h := range hits {
+		res[k] = h.o
+	}
+	return res
+}
+
+// atr returns the ATR of period on each candle.
+func (b *broker) atr(period int) []float64 {
+	if period < 1 {
+		period = 14
+	}
+	if v, ok := b.atrs[period]; ok {
+		return v
+	}
+	var v []float64
+	if expr, err := rules.Compile(fmt.Sprintf("atr(%d)", period)); err == nil {
+		v, _ = expr.Eval(b.src)
+	}
+	b.atrs[period] = v
+	return v
+}
+
+// distance is how far a trailing stop trails the price on candle i.
+func (b *broker) distance(o *Order, price float64, i int) float64 {
+	if o.TrailATR > 0 {
+		if atr := b.atr(o.ATRPeriod); i < len(atr) && !math.IsNaN(atr[i]) {
+			return o.TrailATR * atr[i]
+		}
+		// no ATR yet, so no stop
+		return math.Inf(1)
+	}
+	return o.Trail * price
+}
+
+// startTrail sets a trailing stop trailing price on candle i.
+func (b *broker) startTrail(o *Order, price float64, i int) {
+	o.extreme = price
+	o.Price = price - o.Side.opposite().sign()*b.distance(o, price, i)
+}
+
+// trail moves the trailing stops after candle i, which they only see once
+// closed: the extreme of a candle cannot tell whether it came before or
+// after the stop was reached.
+func (b *broker) trail(i int, c stream.Candle) {
+	for _, o := range b.orders {
+		if o.Type != TrailingStop || o.Status != Open || o.Placed >= i {
+			continue
+		}
+		// sell stops protect longs and follow the highs
+		if o.Side == Sell {
+			o.extreme = math.Max(o.extreme, c.High)
+			o.Price = math.Max(o.Price, o.extreme-b.distance(o, o.extreme, i))
+		} else {
+			o.extreme = math.Min(o.extreme, c.Low)
+			o.Price = math.Min(o.Price, o.extreme+b.distance(o, o.extreme, i))
+		}
+	}
+}
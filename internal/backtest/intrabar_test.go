@@ -0,0 +1,191 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+
+	"cryptoapi/internal/rules"
+	"cryptoapi/internal/store"
+)
+
+const minute = int64(60000)
+
+// candles makes a source of one minute candles from open, high, low, close
+// rows, each with a volume of 1000.
+func candles(rows ...[4]float64) Candles {
+	b := &store.Batch{}
+	for i, r := range rows {
+		b.OpenTime = append(b.OpenTime, int64(i)*minute)
+		b.CloseTime = append(b.CloseTime, int64(i+1)*minute-1)
+		b.Open = append(b.Open, r[0])
+		b.High = append(b.High, r[1])
+		b.Low = append(b.Low, r[2])
+		b.Close = append(b.Close, r[3])
+		b.Volume = append(b.Volume, 1000)
+	}
+	b.Fill()
+	return Candles{Batch: b}
+}
+
+// script submits the orders of a candle as it closes.
+type script map[int][]Order
+
+func (s script) Init(rules.Source) error { return nil }
+
+func (s script) OnCandle(c *Context) {
+	for _, o := range s[c.Index] {
+		c.Submit(o)
+	}
+}
+
+// buyOn buys one unit at the open of candle 1 with a bracket.
+func buyOn(b *Bracket) script {
+	return script{0: {{Side: Buy, Type: Market, Qty: 1, Bracket: b}}}
+}
+
+// flat is the candle the strategy decides on, the entry filling at 100 on the
+// next one.
+var flat = [4]float64{100, 100, 100, 100}
+
+func run(t *testing.T, src Candles, s Strategy, cfg Config) *Result {
+	t.Helper()
+	if cfg.Cash == 0 {
+		cfg.Cash = 1000
+	}
+	res, err := Run(src, s, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// exit checks the single trade of a run.
+func exit(t *testing.T, res *Result, price float64, order OrderType, candle int) {
+	t.Helper()
+	if len(res.Trades) != 1 {
+		t.Fatalf("%d trades, %d positions open", len(res.Trades), len(res.Open))
+	}
+	tr := res.Trades[0]
+	if math.Abs(tr.Exit-price) > 1e-9 || tr.ExitOrder != order || tr.Closed != int64(candle+1)*minute-1 {
+		t.Errorf("exit at %v via %s on candle %d, want %v via %s on candle %d",
+			tr.Exit, tr.ExitOrder, (tr.Closed+1)/minute-1, price, order, candle)
+	}
+}
+
+func TestStopOnWick(t *testing.T) {
+	res := run(t, candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{100, 101, 94, 100}), buyOn(&Bracket{StopLoss: 0.05}), Config{})
+	exit(t, res, 95, Stop, 2)
+}
+
+func TestBracketOnEntryCandle(t *testing.T) {
+	// the entry candle goes down to 95 after opening at 100: the 1% stop
+	// fills on it
+	res := run(t, candles(flat, [4]float64{100, 101, 95, 100}, flat), buyOn(&Bracket{StopLoss: 0.01}), Config{})
+	exit(t, res, 99, Stop, 1)
+	// a falling candle goes to its high first, the target before the stop
+	res = run(t, candles(flat, [4]float64{100, 103, 96, 99}, flat), buyOn(&Bracket{StopLoss: 0.03, TakeProfit: 0.02}), Config{})
+	exit(t, res, 102, Limit, 1)
+}
+
+func TestGaps(t *testing.T) {
+	tests := []struct {
+		name  string
+		next  [4]float64
+		price float64
+		order OrderType
+	}{
+		// orders gapped through fill at the open, worse for a stop and
+		// better for a target
+		{"down through the stop", [4]float64{90, 92, 88, 91}, 90, Stop},
+		{"up through the target", [4]float64{115, 116, 112, 113}, 115, Limit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := candles(flat, [4]float64{100, 101, 99, 100}, test.next)
+			res := run(t, src, buyOn(&Bracket{StopLoss: 0.05, TakeProfit: 0.1}), Config{})
+			exit(t, res, test.price, test.order, 2)
+		})
+	}
+}
+
+func TestPaths(t *testing.T) {
+	// a rising candle reaching both the 95 stop and the 110 target
+	src := candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{100, 111, 94, 108})
+	tests := []struct {
+		path  Path
+		price float64
+		order OrderType
+	}{
+		{"", 95, Stop},
+		{OHLC, 95, Stop},
+		{HighFirst, 110, Limit},
+		{LowFirst, 95, Stop},
+		{Worst, 95, Stop},
+		{Best, 110, Limit},
+	}
+	for _, test := range tests {
+		t.Run(string(test.path), func(t *testing.T) {
+			res := run(t, src, buyOn(&Bracket{StopLoss: 0.05, TakeProfit: 0.1}), Config{Path: test.path})
+			exit(t, res, test.price, test.order, 2)
+		})
+	}
+	// a falling one goes to its high first along OHLC
+	res := run(t, candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{100, 111, 94, 96}),
+		buyOn(&Bracket{StopLoss: 0.05, TakeProfit: 0.1}), Config{})
+	exit(t, res, 110, Limit, 2)
+	if err := Path("middle").Check(); err == nil {
+		t.Error("unknown path accepted")
+	}
+}
+
+func TestOCO(t *testing.T) {
+	// the stop filling cancels the target of the bracket
+	res := run(t, candles(flat, [4]float64{100, 101, 99, 100}, [4]float64{100, 101, 94, 100}, [4]float64{100, 120, 100, 120}),
+		buyOn(&Bracket{StopLoss: 0.05, TakeProfit: 0.1}), Config{})
+	exit(t, res, 95, Stop, 2)
+	if len(res.Fills) != 2 {
+		t.Errorf("%d fills, want the entry and the stop", len(res.Fills))
+	}
+
+	// orders grouped by the strategy
+	s := &ocoScript{}
+	res = run(t, candles(flat, [4]float64{100, 101, 96, 100}, [4]float64{100, 106, 90, 100}), s, Config{})
+	if s.low.Status != Filled || s.high.Status != Canceled {
+		t.Errorf("low %s, high %s", s.low.Status, s.high.Status)
+	}
+	if len(res.Fills) != 1 || res.Fills[0].Price != 97 {
+		t.Errorf("got fills %+v", res.Fills)
+	}
+}
+
+// ocoScript places a buy limit below the price and one above, each
+// canceling the other.
+type ocoScript struct {
+	low, high *Order
+}
+
+func (s *ocoScript) Init(rules.Source) error { return nil }
+
+func (s *ocoScript) OnCandle(c *Context) {
+	if c.Index == 0 {
+		s.low = c.Submit(Order{Side: Buy, Type: Limit, Qty: 1, Price: 97})
+		s.high = c.Submit(Order{Side: Sell, Type: Limit, Qty: 1, Price: 105})
+		c.OCO(s.low, s.high)
+	}
+}
+
+func TestTrailingStops(t *testing.T) {
+	rise := [][4]float64{flat, {100, 101, 99, 100}, {100, 110, 100, 110}, {110, 120, 110, 119}}
+	// 5% under the highest high of the candles closed, 120
+	res := run(t, candles(append(rise, [4]float64{119, 119, 113, 113})...), buyOn(&Bracket{Trail: 0.05}), Config{})
+	exit(t, res, 114, TrailingStop, 4)
+	// the stop only moves once a candle closed, so the high of the candle
+	// it fills on does not count
+	res = run(t, candles(append(rise, [4]float64{119, 130, 113, 113})...), buyOn(&Bracket{Trail: 0.05}), Config{})
+	exit(t, res, 114, TrailingStop, 4)
+
+	// one ATR of 2 candles under the highest high: the ATR is 8 once the
+	// candle reaching 120 closed, so the stop sits at 112
+	res = run(t, candles(append(rise, [4]float64{119, 119, 100, 100})...), buyOn(&Bracket{TrailATR: 1, ATRPeriod: 2}), Config{})
+	exit(t, res, 112, TrailingStop, 4)
+}
@@ -0,0 +1,229 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Param is a parameter searched from Min to Max by Step, or over the
+// whole range by random searches when Step is zero.
+type Param struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step,omitempty"`
+}
+
+func (p Param) values() []float64 {
+	if p.Step <= 0 || p.Max <= p.Min {
+		return []float64{p.Min}
+	}
+	var res []float64
+	n := int(math.Floor((p.Max-p.Min)/p.Step + 1e-9))
+	for i := 0; i <= n; i++ {
+		res = append(res, p.Min+float64(i)*p.Step)
+	}
+	return res
+}
+
+// ParseSpace reads parameters written name=min:max:step, separated by
+// commas, e.g. buy=20:40:5,stop=0.01:0.05:0.01. A single value fixes the
+// parameter and the step can be left out for random searches.
+func ParseSpace(s string) ([]Param, error) {
+	var res []Param
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("bad parameter %q", part)
+		}
+		var v []float64
+		for _, f := range strings.Split(kv[1], ":") {
+			x, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad parameter %q", part)
+			}
+			v = append(v, x)
+		}
+		p := Param{Name: kv[0], Min: v[0], Max: v[0]}
+		switch len(v) {
+		case 1:
+		case 3:
+			p.Step = v[2]
+			fallthrough
+		case 2:
+			p.Max = v[1]
+		default:
+			return nil, fmt.Errorf("bad parameter %q", part)
+		}
+		if p.Max < p.Min {
+			return nil, fmt.Errorf("parameter %s: max below min", p.Name)
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// Params are the values of the parameters of one backtest.
+type Params map[string]float64
+
+// Factory makes the strategy of a set of parameters.
+type Factory func(Params) Strategy
+
+// Rules makes rule strategies whose conditions hold {name} placeholders,
+// e.g. rsi(14) crosses_below {buy}. The stop, target, trail and trail_atr
+// parameters set the bracket of the positions.
+func Rules(entry, exit string, side Side, max int) Factory {
+	return func(p Params) Strategy {
+		s := &RuleStrategy{
+			Entry:        entry,
+			Exit:         exit,
+			Side:         side,
+			MaxPositions: max,
+			StopLoss:     p["stop"],
+			TakeProfit:   p["target"],
+			Trail:        p["trail"],
+			TrailATR:     p["trail_atr"],
+		}
+		for name, v := range p {
+			s.Entry = strings.Replace(s.Entry, "{"+name+"}", formatFloat(v), -1)
+			s.Exit = strings.Replace(s.Exit, "{"+name+"}", formatFloat(v), -1)
+		}
+		return s
+	}
+}
+
+// Objective is the metric the optimizer maximizes.
+type Objective string
+
+var objectives = map[Objective]func(Metrics) float64{
+	"return":        func(m Metrics) float64 { return m.TotalReturn },
+	"annual_return": func(m Metrics) float64 { return m.AnnualReturn },
+	"sharpe":        func(m Metrics) float64 { return m.Sharpe },
+	"sortino":       func(m Metrics) float64 { return m.Sortino },
+	"calmar":        func(m Metrics) float64 { return m.Calmar },
+	"profit_factor": func(m Metrics) float64 { return m.ProfitFactor },
+	"expectancy":    func(m Metrics) float64 { return m.Expectancy },
+	"win_rate":      func(m Metrics) float64 { return m.WinRate },
+	// the smaller the better
+	"drawdown": func(m Metrics) float64 { return -m.MaxDrawdown },
+}
+
+func (o Objective) Check() error {
+	if _, ok := objectives[o]; !ok {
+		return fmt.Errorf("unknown objective %q", o)
+	}
+	return nil
+}
+
+func (o Objective) score(m Metrics) float64 {
+	return objectives[o](m)
+}
+
+// Trial is a backtest of the optimizer.
+type Trial struct {
+	Params  Params  `json:"params"`
+	Score   float64 `json:"score"`
+	Metrics Metrics `json:"metrics"`
+	Err     string  `json:"error,omitempty"`
+}
+
+type Optimizer struct {
+	Space     []Param
+	New       Factory
+	Config    Config
+	Objective Objective
+	// random draws from the space, the whole grid when zero
+	Samples int
+	Seed    int64
+	// backtests run at once, one per CPU when zero
+	Workers int
+}
+
+// points lists the parameters to try.
+func (o *Optimizer) points() []Params {
+	if o.Samples > 0 {
+		r := rand.New(rand.NewSource(o.Seed))
+		res := make([]Params, o.Samples)
+		for i := range res {
+			res[i] = make(Params, len(o.Space))
+			for _, p := range o.Space {
+				if p.Step > 0 {
+					v := p.values()
+					res[i][p.Name] = v[r.Intn(len(v))]
+				} else {
+					res[i][p.Name] = p.Min + r.Float64()*(p.Max-p.Min)
+				}
+			}
+		}
+		return res
+	}
+	res := []Params{{}}
+	for _, p := range o.Space {
+		var next []Params
+		for _, point := range res {
+			for _, v := range p.values() {
+				q := make(Params, len(point)+1)
+				for k, x := range point {
+					q[k] = x
+				}
+				q[p.Name] = v
+				next = append(next, q)
+			}
+		}
+		res = next
+	}
+	return res
+}
+
+// Optimize backtests the points of the space on src in parallel and returns
+// the trials best first, the failed ones last.
+func (o *Optimizer) Optimize(src Source) ([]Trial, error) {
+	if err := o.Objective.Check(); err != nil {
+		return nil, err
+	}
+	points := o.points()
+	res := make([]Trial, len(points))
+	workers := o.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res[i] = o.trial(src, points[i])
+			}
+		}()
+	}
+	for i := range points {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if (res[i].Err == "") != (res[j].Err == "") {
+			return res[i].Err == ""
+		}
+		return res[i].Score > res[j].Score
+	})
+	return res, nil
+}
+
+func (o *Optimizer) trial(src Source, p Params) Trial {
+	res, err := Run(src, o.New(p), o.Config)
+	if err != nil {
+		return Trial{Params: p, Err: err.Error()}
+	}
+	m := NewReport(res).Metrics
+	return Trial{Params: p, Score: o.Objective.score(m), Metrics: m}
+}
@@ -0,0 +1,165 @@
+package backtest
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"cryptoapi/internal/rules"
+)
+
+func TestParseSpace(t *testing.T) {
+	for _, tt := range []struct {
+		space string
+		want  []Param
+	}{
+		{"buy=20:40:5,stop=0.01:0.05:0.01", []Param{{"buy", 20, 40, 5}, {"stop", 0.01, 0.05, 0.01}}},
+		{"period=14", []Param{{"period", 14, 14, 0}}},
+		{"trail=0.01:0.02", []Param{{"trail", 0.01, 0.02, 0}}},
+		{"x", nil},
+		{"=1", nil},
+		{"x=a", nil},
+		{"x=1:", nil},
+		{"x=1:2:3:4", nil},
+		{"x=2:1", nil},
+		{"x=1,", nil},
+		{"x = 1", nil},
+	} {
+		got, err := ParseSpace(tt.space)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("%q: parsed to %+v", tt.space, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.space, err)
+		} else if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.space, got, tt.want)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	for _, tt := range []struct {
+		param Param
+		want  int
+	}{
+		{Param{Min: 20, Max: 40, Step: 5}, 5},
+		// the steps add up to a little less than the max
+		{Param{Min: 0.01, Max: 0.05, Step: 0.01}, 5},
+		{Param{Min: 1, Max: 2, Step: 5}, 1},
+		{Param{Min: 1, Max: 1, Step: 1}, 1},
+		{Param{Min: 1, Max: 2}, 1},
+	} {
+		got := tt.param.values()
+		if len(got) != tt.want || got[0] != tt.param.Min || got[len(got)-1] > tt.param.Max+1e-9 {
+			t.Errorf("%+v: got %v, want %d values", tt.param, got, tt.want)
+		}
+	}
+}
+
+func TestPoints(t *testing.T) {
+	o := &Optimizer{Space: []Param{{"a", 1, 3, 1}, {"b", 0, 1, 0.5}, {"c", 7, 7, 0}}}
+	var got []string
+	for _, p := range o.points() {
+		got = append(got, formatFloat(p["a"])+"/"+formatFloat(p["b"])+"/"+formatFloat(p["c"]))
+	}
+	sort.Strings(got)
+	want := []string{"1/0.5/7", "1/0/7", "1/1/7", "2/0.5/7", "2/0/7", "2/1/7", "3/0.5/7", "3/0/7", "3/1/7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("grid %v, want %v", got, want)
+	}
+
+	// random draws take the steps of the stepped parameters and anything in
+	// the range of the others, the same for the same seed
+	o = &Optimizer{Space: []Param{{"a", 1, 3, 1}, {"b", 0, 1, 0}}, Samples: 50, Seed: 1}
+	points := o.points()
+	if len(points) != 50 {
+		t.Fatalf("%d points, want 50", len(points))
+	}
+	seen := map[float64]bool{}
+	for _, p := range points {
+		if a := p["a"]; a != 1 && a != 2 && a != 3 {
+			t.Errorf("drew a=%v off the grid", a)
+		}
+		seen[p["a"]] = true
+		if b := p["b"]; b < 0 || b > 1 {
+			t.Errorf("drew b=%v out of range", b)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("drew a in %v only", seen)
+	}
+	if !reflect.DeepEqual(o.points(), points) {
+		t.Error("drew other points with the same seed")
+	}
+}
+
+// holder buys qty on the first candle it sees and holds it.
+type holder struct {
+	qty    float64
+	bought bool
+}
+
+func (h *holder) Init(rules.Source) error { return nil }
+
+func (h *holder) OnCandle(c *Context) {
+	if !h.bought {
+		c.Buy(h.qty, "")
+		h.bought = true
+	}
+}
+
+func TestWalkForward(t *testing.T) {
+	// a steady rise, each candle closing half a unit above its open
+	var rows [][4]float64
+	for i := 0; i < 11; i++ {
+		p := 100 + float64(i)
+		rows = append(rows, [4]float64{p, p + 0.5, p, p + 0.5})
+	}
+	w := &WalkForward{
+		Optimizer: Optimizer{
+			Space:     []Param{{"qty", 1, 2, 1}},
+			New:       func(p Params) Strategy { return &holder{qty: p["qty"]} },
+			Config:    Config{Cash: 1000},
+			Objective: "return",
+			Workers:   2,
+		},
+		InSample:  4,
+		OutSample: 2,
+	}
+	res, err := w.Run(candles(rows...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// windows of 4 candles from candles 0, 2 and 4, each tested on the 2
+	// candles after it
+	if len(res.Windows) != 3 {
+		t.Fatalf("%d windows, want 3", len(res.Windows))
+	}
+	for i, win := range res.Windows {
+		from := int64(2*i+4+1)*minute - 1
+		if win.From != from || win.To != from+minute {
+			t.Errorf("window %d tested from %d to %d, want %d to %d", i, win.From, win.To, from, from+minute)
+		}
+		// the larger position wins in sample, making 2.5 a unit over three
+		// candles, and holds half a unit out of sample, bought a candle late
+		if win.Best.Params["qty"] != 2 || !near(win.Best.Score, 0.005) || !near(win.OutScore, 0.001) {
+			t.Errorf("window %d: best %+v, out of sample %v", i, win.Best, win.OutScore)
+		}
+	}
+	if !near(res.Return, math.Pow(1.001, 3)-1) || !near(res.Efficiency, 0.2) {
+		t.Errorf("return %v, efficiency %v", res.Return, res.Efficiency)
+	}
+
+	w.InSample = 10
+	if _, err := w.Run(candles(rows...)); err == nil {
+		t.Error("ran without enough candles")
+	}
+	w.InSample, w.OutSample = 4, 0
+	if _, err := w.Run(candles(rows...)); err == nil {
+		t.Error("ran windows of no candles")
+	}
+}
@@ -0,0 +1,387 @@
+package backtest
+
+import (
+	"math"
+
+	"cryptoapi/internal/stream"
+)
+
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+func (s Side) sign() float64 {
+	if s == Buy {
+		return 1
+	}
+	return -1
+}
+
+func (s Side) opposite() Side {
+	if s == Buy {
+		return Sell
+	}
+	return Buy
+}
+
+type OrderType string
+
+const (
+	// Market orders fill at the open of the next candle.
+	Market OrderType = "market"
+	// Limit orders fill at their price or better once the price reaches it.
+	Limit OrderType = "limit"
+	// Stop orders turn into market orders once the price reaches theirs.
+	Stop OrderType = "stop"
+	// TrailingStop orders are stop orders following the best price since
+	// placed, at a distance set by Trail or TrailATR.
+	TrailingStop OrderType = "trailing_stop"
+)
+
+type Status string
+
+const (
+	Open     Status = "open"
+	Filled   Status = "filled"
+	Canceled Status = "canceled"
+)
+
+// Order is an order of the strategy. Orders without a position open a new
+// one, long when buying and short when selling; the others close the
+// position they name, all of it when their quantity is zero.
+type Order struct {
+	ID   int       `json:"id"`
+	Side Side      `json:"side"`
+	Type OrderType `json:"type"`
+	Qty  float64   `json:"qty"`
+	// limit price of limit orders, trigger price of stop orders, moved by
+	// trailing stops
+	Price    float64   `json:"price,omitempty"`
+	Position *Position `json:"-"`
+	Tag      string    `json:"tag,omitempty"`
+	// distance of trailing stops to the best price, a fraction of it or a
+	// multiple of the ATR of ATRPeriod candles, 14 when zero
+	Trail     float64 `json:"trail,omitempty"`
+	TrailATR  float64 `json:"trail_atr,omitempty"`
+	ATRPeriod int     `json:"atr_period,omitempty"`
+	// orders of the same one-cancels-the-other group, see Context.OCO, are
+	// canceled as soon as one fills
+	OCO int `json:"oco,omitempty"`
+	// protection placed once an opening order fills
+	Bracket *Bracket `json:"bracket,omitempty"`
+
+	Status Status  `json:"status"`
+	Filled float64 `json:"filled"`
+	// index of the candle the order was placed on
+	Placed int `json:"placed"`
+
+	opens bool
+	// best price seen by a trailing stop
+	extreme float64
+	// how far along the path of its candle the order was last reached
+	at float64
+	// set on the orders of a bracket, which may fill on the candle of their
+	// entry once the path is past after
+	bracket bool
+	after   float64
+}
+
+// Remaining is the quantity left to fill.
+func (o *Order) Remaining() float64 {
+	if !o.opens && o.Qty == 0 {
+		return o.Position.Qty
+	}
+	return o.Qty - o.Filled
+}
+
+// Bracket are the orders closing a position once opened, at distances from
+// its entry price given as fractions of it: a stop loss, a take profit and a
+// trailing stop. They cancel each other.
+type Bracket struct {
+	StopLoss   float64 `json:"stop_loss,omitempty"`
+	TakeProfit float64 `json:"take_profit,omitempty"`
+	Trail      float64 `json:"trail,omitempty"`
+	// trailing stop as a multiple of the ATR instead
+	TrailATR  float64 `json:"trail_atr,omitempty"`
+	ATRPeriod int     `json:"atr_period,omitempty"`
+}
+
+// Position is a long or short position, opened by one order and closed by
+// one or more.
+type Position struct {
+	ID int `json:"id"`
+	// Buy for long positions, Sell for short ones
+	Side Side `json:"side"`
+	// quantity still open
+	Qty float64 `json:"qty"`
+	// average entry price
+	Entry float64 `json:"entry"`
+	// close time of the candle of the first fill
+	Opened int64   `json:"opened"`
+	Fees   float64 `json:"fees"`
+	Tag    string  `json:"tag,omitempty"`
+
+	// quantity entered and exited, and the value exited
+	entered, exited, exitValue float64
+	// profit of the quantity exited, before fees
+	realized float64
+}
+
+// Value is the profit of the open quantity at price, before fees.
+func (p *Position) Value(price float64) float64 {
+	return p.Side.sign() * (price - p.Entry) * p.Qty
+}
+
+// Fill is an order filling, fully or in part, on a candle.
+type Fill struct {
+	Order    int     `json:"order"`
+	Position int     `json:"position"`
+	Side     Side    `json:"side"`
+	Qty      float64 `json:"qty"`
+	Price    float64 `json:"price"`
+	Fee      float64 `json:"fee"`
+	// close time of the candle
+	Time int64     `json:"time"`
+	Type OrderType `json:"type"`
+}
+
+// Trade is a position once closed.
+type Trade struct {
+	Position int     `json:"position"`
+	Side     Side    `json:"side"`
+	Qty      float64 `json:"qty"`
+	Entry    float64 `json:"entry"`
+	Exit     float64 `json:"exit"`
+	Opened   int64   `json:"opened"`
+	Closed   int64   `json:"closed"`
+	Fees     float64 `json:"fees"`
+	// profit after fees
+	PnL float64 `json:"pnl"`
+	// profit after fees over the value entered
+	Return float64 `json:"return"`
+	// type of the order closing the position, telling stops from targets
+	ExitOrder OrderType `json:"exit_order"`
+	Tag       string    `json:"tag,omitempty"`
+}
+
+// broker keeps the account of a run and fills its orders.
+type broker struct {
+	cfg       Config
+	src       Source
+	cash      float64
+	fees      float64
+	orders    []*Order
+	positions []*Position
+	fills     []Fill
+	trades    []Trade
+	nextID    int
+	// ATR values by period, computed when first needed
+	atrs map[int][]float64
+}
+
+func newBroker(cfg Config, src Source) *broker {
+	return &broker{cfg: cfg, src: src, cash: cfg.Cash, atrs: make(map[int][]float64)}
+}
+
+func (b *broker) id() int {
+	b.nextID++
+	return b.nextID
+}
+
+func (b *broker) submit(o Order, placed int) *Order {
+	res := &o
+	res.ID, res.Placed, res.Status, res.Filled = b.id(), placed, Open, 0
+	res.opens = o.Position == nil
+	if !res.opens {
+		res.Side = o.Position.Side.opposite()
+	}
+	if res.opens && !(res.Qty > 0) || res.Qty < 0 || res.Type == "" {
+		res.Status = Canceled
+		return res
+	}
+	if res.Type == TrailingStop {
+		// trailing from the close the order is placed on
+		b.startTrail(res, b.src.Candle(placed).Close, placed)
+	}
+	b.orders = append(b.orders, res)
+	return res
+}
+
+// equity is the cash and the open positions valued at price.
+func (b *broker) equity(price float64) float64 {
+	res := b.cash
+	for _, p := range b.positions {
+		res += p.Side.sign() * p.Qty * price
+	}
+	return res
+}
+
+// fill runs the open orders placed before candle i against it, closed at
+// time, in the order the path of the price reaches them. The brackets of the
+// positions opened on the way join in. With a participation set, the orders
+// of a candle share that part of its volume and what is left carries over to
+// the next one.
+func (b *broker) fill(i int, c stream.Candle, time int64) {
+	liquidity := math.Inf(1)
+	if b.cfg.Participation > 0 {
+		liquidity = b.cfg.Participation * c.Volume
+	}
+	// the fills place brackets, so the orders reached are looked up again
+	// after each of them
+	seen := make(map[*Order]bool)
+	for {
+		var next *Order
+		for _, o := range b.triggered(i, c) {
+			if !seen[o] {
+				next = o
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		seen[next] = true
+		if next.Status == Open {
+			liquidity -= b.fillOrder(next, c, i, time, liquidity)
+		}
+	}
+	b.trail(i, c)
+	var open []*Order
+	for _, o := range b.orders {
+		if o.Status == Open {
+			open = append(open, o)
+		}
+	}
+	b.orders = open
+}
+
+// fillOrder fills what it can of o on c, at most liquidity, and returns the
+// quantity filled.
+func (b *broker) fillOrder(o *Order, c stream.Candle, i int, time int64, liquidity float64) float64 {
+	qty := o.Remaining()
+	if !o.opens {
+		if o.Position.Qty <= 0 {
+			o.Status = Canceled
+			return 0
+		}
+		qty = math.Min(qty, o.Position.Qty)
+	}
+	qty = math.Min(qty, liquidity)
+	if !(qty > 0) {
+		return 0
+	}
+	p, ok, taker := price(o, c)
+	if o.Placed == i {
+		// a bracket reached after its entry, past any gap
+		p, ok, taker = o.Price, true, o.Type != Limit
+	}
+	if !ok {
+		return 0
+	}
+	rate := b.cfg.MakerFee
+	if taker {
+		rate = b.cfg.TakerFee
+		p = b.cfg.MarketPrice(o.Side, p)
+	}
+	fee := qty * p * rate
+
+	if o.opens && o.Position == nil {
+		o.Position = &Position{ID: b.id(), Side: o.Side, Opened: time, Tag: o.Tag}
+		b.positions = append(b.positions, o.Position)
+		defer b.protect(o.Position, o.Bracket, p, i, o.at)
+	}
+	pos := o.Position
+	if o.opens {
+		pos.Entry = (pos.Entry*pos.entered + p*qty) / (pos.entered + qty)
+		pos.entered += qty
+		pos.Qty += qty
+	} else {
+		pos.realized += pos.Side.sign() * (p - pos.Entry) * qty
+		pos.exitValue += p * qty
+		pos.exited += qty
+		pos.Qty -= qty
+	}
+	pos.Fees += fee
+	b.cash -= o.Side.sign()*qty*p + fee
+	b.fees += fee
+	b.fills = append(b.fills, Fill{Order: o.ID, Position: pos.ID, Side: o.Side, Qty: qty, Price: p, Fee: fee, Time: time, Type: o.Type})
+
+	o.Filled += qty
+	if o.Remaining() <= 0 || !o.opens && pos.Qty <= 0 {
+		o.Status = Filled
+	}
+	if o.OCO != 0 {
+		for _, other := range b.orders {
+			if other != o && other.OCO == o.OCO && other.Status == Open {
+				other.Status = Canceled
+			}
+		}
+	}
+	if !o.opens && pos.Qty <= 0 {
+		b.close(pos, time, o.Type)
+	}
+	return qty
+}
+
+// protect places the orders of a bracket on a position opened at price on
+// candle i, at position at along its path: they may fill on the rest of the
+// path.
+func (b *broker) protect(pos *Position, bracket *Bracket, price float64, i int, at float64) {
+	if bracket == nil {
+		return
+	}
+	group := b.id()
+	sign := pos.Side.sign()
+	place := func(o Order) {
+		o.Position, o.OCO, o.Tag = pos, group, pos.Tag
+		o.bracket, o.after = true, at
+		if res := b.submit(o, i); res.Type == TrailingStop {
+			b.startTrail(res, price, i)
+		}
+	}
+	if bracket.StopLoss > 0 {
+		place(Order{Type: Stop, Price: price * (1 - sign*bracket.StopLoss)})
+	}
+	if bracket.TakeProfit > 0 {
+		place(Order{Type: Limit, Price: price * (1 + sign*bracket.TakeProfit)})
+	}
+	if bracket.Trail > 0 || bracket.TrailATR > 0 {
+		place(Order{Type: TrailingStop, Trail: bracket.Trail, TrailATR: bracket.TrailATR, ATRPeriod: bracket.ATRPeriod})
+	}
+}
+
+// close records pos as a trade and drops it from the open positions along
+// with the orders left on it.
+func (b *broker) close(pos *Position, time int64, exit OrderType) {
+	for _, o := range b.orders {
+		if o.Position == pos && o.Status == Open {
+			o.Status = Canceled
+		}
+	}
+	pnl := pos.realized - pos.Fees
+	b.trades = append(b.trades, Trade{
+		Position: pos.ID,
+		Side:     pos.Side,
+		Qty:      pos.entered,
+		Entry:    pos.Entry,
+		Exit:     pos.exitValue / pos.exited,
+		Opened:   pos.Opened,
+		Closed:   time,
+		Fees:     pos.Fees,
+		PnL:      pnl,
+		Return:   pnl / (pos.Entry * pos.entered),
+
+		ExitOrder: exit,
+		Tag:       pos.Tag,
+	})
+	open := b.positions[:0]
+	for _, p := range b.positions {
+		if p != pos {
+			open = append(open, p)
+		}
+	}
+	b.positions = open
+}
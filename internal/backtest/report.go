@@ -0,0 +1,153 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+const year = int64(365 * 24 * time.Hour / time.Millisecond)
+
+// minAnnualSpan is the shortest run whose return is annualized, that of a
+// few hours compounding to absurd or infinite figures.
+const minAnnualSpan = int64(30 * 24 * time.Hour / time.Millisecond)
+
+// Metrics sum up a backtest. Ratios whose denominator is zero, such as the
+// profit factor of a run without losing trades, are zero, as is the annual
+// return of a run shorter than 30 days. Returns and drawdowns are
+// fractions, durations milliseconds.
+type Metrics struct {
+	// close times of the first and last candles
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+
+	Cash         float64 `json:"cash"`
+	Equity       float64 `json:"equity"`
+	Fees         float64 `json:"fees"`
+	TotalReturn  float64 `json:"total_return"`
+	AnnualReturn float64 `json:"annual_return"`
+	// deepest fall of the equity from a peak, and the longest time spent
+	// below a peak
+	MaxDrawdown         float64 `json:"max_drawdown"`
+	MaxDrawdownDuration int64   `json:"max_drawdown_duration"`
+	// annualized from the returns of each candle, without a risk free rate
+	Sharpe  float64 `json:"sharpe"`
+	Sortino float64 `json:"sortino"`
+	Calmar  float64 `json:"calmar"`
+
+	Trades       int     `json:"trades"`
+	WinRate      float64 `json:"win_rate"`
+	ProfitFactor float64 `json:"profit_factor"`
+	// average profit of a trade, after fees
+	Expectancy       float64 `json:"expectancy"`
+	AvgTradeDuration int64   `json:"avg_trade_duration"`
+	// part of the candles closing with a position open
+	Exposure float64 `json:"exposure"`
+}
+
+// Report is the metrics of a backtest with its trades and equity curve.
+type Report struct {
+	Metrics
+	Ledger []Trade    `json:"ledger"`
+	Curve  []Point    `json:"curve"`
+	Open   []Position `json:"open"`
+}
+
+func ratio(a, b float64) float64 {
+	if b == 0 || math.IsNaN(a) || math.IsNaN(b) {
+		return 0
+	}
+	return a / b
+}
+
+// NewReport computes the metrics of a result.
+func NewReport(res *Result) *Report {
+	r := &Report{Ledger: res.Trades, Curve: res.Curve, Open: res.Open}
+	m := &r.Metrics
+	m.Cash, m.Equity, m.Fees = res.Cash, res.Equity, res.Fees
+	m.TotalReturn = ratio(res.Equity-res.Cash, res.Cash)
+	curve := res.Curve
+	if n := len(curve); n > 0 {
+		m.From, m.To = curve[0].Time, curve[n-1].Time
+	}
+	span := m.To - m.From
+	if span >= minAnnualSpan && res.Cash > 0 {
+		if res.Equity <= 0 {
+			m.AnnualReturn = -1
+		} else {
+			m.AnnualReturn = math.Pow(res.Equity/res.Cash, float64(year)/float64(span)) - 1
+		}
+	}
+
+	peak, peakTime := res.Cash, m.From
+	exposed := 0
+	for _, p := range curve {
+		if p.Positions > 0 {
+			exposed++
+		}
+		if p.Equity >= peak {
+			peak, peakTime = p.Equity, p.Time
+			continue
+		}
+		if peak > 0 {
+			m.MaxDrawdown = math.Max(m.MaxDrawdown, (peak-p.Equity)/peak)
+		}
+		if d := p.Time - peakTime; d > m.MaxDrawdownDuration {
+			m.MaxDrawdownDuration = d
+		}
+	}
+	m.Exposure = ratio(float64(exposed), float64(len(curve)))
+	m.Calmar = ratio(m.AnnualReturn, m.MaxDrawdown)
+
+	// returns of each candle, the first one from the cash
+	if len(curve) > 1 {
+		perYear := float64(year) / (float64(span) / float64(len(curve)-1))
+		var sum, sumSq, downSq float64
+		prev := res.Cash
+		for _, p := range curve {
+			ret := ratio(p.Equity-prev, prev)
+			sum += ret
+			sumSq += ret * ret
+			if ret < 0 {
+				downSq += ret * ret
+			}
+			prev = p.Equity
+		}
+		n := float64(len(curve))
+		mean := sum / n
+		std := math.Sqrt(math.Max(sumSq/n-mean*mean, 0))
+		m.Sharpe = ratio(mean, std) * math.Sqrt(perYear)
+		m.Sortino = ratio(mean, math.Sqrt(downSq/n)) * math.Sqrt(perYear)
+	}
+
+	var won, lost, pnl float64
+	var wins int
+	var duration int64
+	for _, t := range res.Trades {
+		pnl += t.PnL
+		duration += t.Closed - t.Opened
+		if t.PnL > 0 {
+			wins++
+			won += t.PnL
+		} else {
+			lost -= t.PnL
+		}
+	}
+	m.Trades = len(res.Trades)
+	if m.Trades > 0 {
+		m.WinRate = float64(wins) / float64(m.Trades)
+		m.Expectancy = pnl / float64(m.Trades)
+		m.AvgTradeDuration = duration / int64(m.Trades)
+	}
+	m.ProfitFactor = ratio(won, lost)
+
+	// JSON has no NaN nor infinities
+	for _, v := range []*float64{
+		&m.Equity, &m.Fees, &m.TotalReturn, &m.AnnualReturn, &m.MaxDrawdown, &m.Sharpe, &m.Sortino,
+		&m.Calmar, &m.WinRate, &m.ProfitFactor, &m.Expectancy, &m.Exposure,
+	} {
+		if math.IsNaN(*v) || math.IsInf(*v, 0) {
+			*v = 0
+		}
+	}
+	return r
+}
@@ -0,0 +1,114 @@
+package backtest
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+const day = 24 * 60 * minute
+
+// curve returns daily points of the equities, a position open where open
+// is true.
+func curve(equity []float64, open ...bool) []Point {
+	var res []Point
+	for i, e := range equity {
+		p := Point{Time: int64(i) * day, Equity: e}
+		if i < len(open) && open[i] {
+			p.Positions = 1
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+func TestDrawdown(t *testing.T) {
+	r := NewReport(&Result{
+		Cash:   1000,
+		Equity: 1100,
+		Curve:  curve([]float64{1100, 880, 990, 1210, 1100}, true, true, false, true, false),
+	})
+	m := r.Metrics
+	// 20% down from 1100, which takes two days to recover from
+	if !near(m.MaxDrawdown, 0.2) || m.MaxDrawdownDuration != 2*day {
+		t.Errorf("drawdown %v for %d, want 0.2 for %d", m.MaxDrawdown, m.MaxDrawdownDuration, 2*day)
+	}
+	if !near(m.TotalReturn, 0.1) || m.Exposure != 0.6 {
+		t.Errorf("return %v, exposure %v", m.TotalReturn, m.Exposure)
+	}
+	// four days are too short to annualize
+	if m.AnnualReturn != 0 || m.Calmar != 0 {
+		t.Errorf("annual return %v, calmar %v", m.AnnualReturn, m.Calmar)
+	}
+	// daily returns of 10%, -20%, 12.5%, 22.2% and -9.1%, annualized over
+	// 365 days
+	if math.Abs(m.Sharpe-3.8815732281457027) > 1e-9 || math.Abs(m.Sortino-6.079154132701016) > 1e-9 {
+		t.Errorf("sharpe %v, sortino %v", m.Sharpe, m.Sortino)
+	}
+	if m.From != 0 || m.To != 4*day {
+		t.Errorf("from %d to %d", m.From, m.To)
+	}
+}
+
+func TestAnnualized(t *testing.T) {
+	// a fifth of a year from 1000 to 1100
+	r := NewReport(&Result{
+		Cash:   1000,
+		Equity: 1100,
+		Curve:  []Point{{Time: 0, Equity: 1000}, {Time: year / 5, Equity: 1100}},
+	})
+	m := r.Metrics
+	if !near(m.AnnualReturn, math.Pow(1.1, 5)-1) {
+		t.Errorf("annual return %v, want %v", m.AnnualReturn, math.Pow(1.1, 5)-1)
+	}
+	// returns of 0 and 10%, five a year; nothing falls
+	if !near(m.Sharpe, math.Sqrt(5)) || m.Sortino != 0 || m.MaxDrawdown != 0 || m.Calmar != 0 {
+		t.Errorf("sharpe %v, sortino %v, drawdown %v, calmar %v", m.Sharpe, m.Sortino, m.MaxDrawdown, m.Calmar)
+	}
+
+	if m := NewReport(&Result{Cash: 1000, Equity: -5, Curve: []Point{{Time: 0}, {Time: year}}}).Metrics; m.AnnualReturn != -1 {
+		t.Errorf("annual return %v of a blown account, want -1", m.AnnualReturn)
+	}
+}
+
+func TestTradeMetrics(t *testing.T) {
+	trades := []Trade{
+		{PnL: 30, Opened: 0, Closed: 4 * minute},
+		{PnL: -10, Opened: 0, Closed: 2 * minute},
+		{PnL: 20, Opened: minute, Closed: 3 * minute},
+		{PnL: -5, Opened: minute, Closed: 5 * minute},
+	}
+	m := NewReport(&Result{Cash: 1000, Equity: 1035, Trades: trades}).Metrics
+	if m.Trades != 4 || m.WinRate != 0.5 || m.Expectancy != 8.75 || m.AvgTradeDuration != 3*minute {
+		t.Errorf("got %+v", m)
+	}
+	if !near(m.ProfitFactor, 50.0/15) {
+		t.Errorf("profit factor %v, want %v", m.ProfitFactor, 50.0/15)
+	}
+	// without a losing trade the profit factor is left at zero
+	if m := NewReport(&Result{Cash: 1000, Equity: 1030, Trades: trades[:1]}).Metrics; m.ProfitFactor != 0 || m.WinRate != 1 {
+		t.Errorf("profit factor %v, win rate %v", m.ProfitFactor, m.WinRate)
+	}
+}
+
+func TestScrub(t *testing.T) {
+	r := NewReport(&Result{
+		Cash:   1000,
+		Equity: math.NaN(),
+		Fees:   math.Inf(1),
+		Trades: []Trade{{PnL: math.Inf(1)}},
+		Curve:  []Point{{Time: 0, Equity: 1000}, {Time: day, Equity: math.Inf(1)}},
+	})
+	m := r.Metrics
+	for name, v := range map[string]float64{
+		"equity": m.Equity, "fees": m.Fees, "total return": m.TotalReturn, "sharpe": m.Sharpe,
+		"sortino": m.Sortino, "profit factor": m.ProfitFactor, "expectancy": m.Expectancy,
+	} {
+		if v != 0 {
+			t.Errorf("%s %v, want 0", name, v)
+		}
+	}
+	if _, err := json.Marshal(r.Metrics); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,100 @@
+package backtest
+
+import (
+	"cryptoapi/internal/rules"
+)
+
+// Strategy decides the orders of a backtest.
+type Strategy interface {
+	// Init computes what the strategy reads, typically indicators, on all the
+	// candles of the run. The value of a candle must only depend on the
+	// candles up to it.
+	Init(src rules.Source) error
+	// OnCandle is called as each candle closes.
+	OnCandle(c *Context)
+}
+
+// RuleStrategy trades on rule conditions: it opens a position on the
+// candles Entry holds and closes it on the ones Exit holds, or once the
+// price reaches its stop loss, take profit or trailing stop.
+type RuleStrategy struct {
+	Entry string
+	// never closed on a condition when empty
+	Exit string
+	// Buy goes long, Sell short
+	Side Side
+	// positions open at once, one when zero
+	MaxPositions int
+	// distances from the entry as fractions of it, e.g. 0.01 for 1%, none
+	// when zero; see Bracket
+	StopLoss   float64
+	TakeProfit float64
+	Trail      float64
+	// trailing stop as a multiple of the 14 candle ATR instead
+	TrailATR float64
+
+	entry, exit []float64
+}
+
+func (s *RuleStrategy) Init(src rules.Source) error {
+	expr, err := rules.Compile(s.Entry)
+	if err != nil {
+		return err
+	}
+	if s.entry, err = expr.Eval(src); err != nil {
+		return err
+	}
+	s.exit = nil
+	if s.Exit != "" {
+		if expr, err = rules.Compile(s.Exit); err != nil {
+			return err
+		}
+		if s.exit, err = expr.Eval(src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RuleStrategy) OnCandle(c *Context) {
+	open := 0
+	for _, p := range c.Positions() {
+		switch {
+		case closing(c, p):
+		case s.exit != nil && s.exit[c.Index] == 1:
+			c.Close(p)
+		default:
+			open++
+		}
+	}
+	for _, o := range c.Orders() {
+		if o.Position == nil {
+			open++
+		}
+	}
+	max := s.MaxPositions
+	if max < 1 {
+		max = 1
+	}
+	if s.entry[c.Index] == 1 && open < max {
+		side := s.Side
+		if side == "" {
+			side = Buy
+		}
+		o := Order{Side: side, Type: Market, Qty: c.Size(), Tag: s.Entry}
+		if s.StopLoss > 0 || s.TakeProfit > 0 || s.Trail > 0 || s.TrailATR > 0 {
+			o.Bracket = &Bracket{StopLoss: s.StopLoss, TakeProfit: s.TakeProfit, Trail: s.Trail, TrailATR: s.TrailATR}
+		}
+		c.Submit(o)
+	}
+}
+
+// closing tells whether a market order closing p is already placed.
+func closing(c *Context, p *Position) bool {
+	for _, o := range c.Orders() {
+		if o.Position == p && !o.opens && o.Type == Market {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package backtest
+
+// Study is an optimization kept for comparison with later ones.
+type Study struct {
+	// unix milliseconds of the run
+	Time     int64  `json:"time"`
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	// what the strategy trades on, e.g. its conditions
+	Strategy  string    `json:"strategy"`
+	Objective Objective `json:"objective"`
+	Space     []Param   `json:"space"`
+	Trials    []Trial   `json:"trials,omitempty"`
+	// set for walk-forward analyses
+	WalkForward *WalkForwardResult `json:"walk_forward,omitempty"`
+}
@@ -0,0 +1,80 @@
+package backtest
+
+import (
+	"errors"
+)
+
+// WalkForward optimizes on a rolling window of candles and tests the best
+// parameters on the candles following it, to tell how much of the in-sample
+// performance holds out of sample.
+type WalkForward struct {
+	Optimizer
+	// candles of the windows optimized on and tested, the windows rolling
+	// by OutSample candles
+	InSample  int
+	OutSample int
+}
+
+// Window is a step of a walk-forward analysis.
+type Window struct {
+	// close times of the first and last candles tested
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// best trial in sample
+	Best Trial `json:"best"`
+	// the best parameters out of sample
+	Out      Metrics `json:"out"`
+	OutScore float64 `json:"out_score"`
+}
+
+type WalkForwardResult struct {
+	Windows []Window `json:"windows"`
+	// compounded return of the windows out of sample
+	Return float64 `json:"return"`
+	// average score out of sample over the average score in sample, near
+	// one when the optimization holds and low or negative when it overfits
+	Efficiency float64 `json:"efficiency"`
+}
+
+// rows returns the candles of src from i to j excluded.
+func rows(src Candles, i, j int) Candles {
+	return Candles{Batch: src.Slice(src.OpenTime[i], src.OpenTime[j-1]), Frames: src.Frames}
+}
+
+func (w *WalkForward) Run(src Candles) (*WalkForwardResult, error) {
+	if w.InSample < 1 || w.OutSample < 1 {
+		return nil, errors.New("walk-forward windows need candles")
+	}
+	if src.Len() < w.InSample+w.OutSample {
+		return nil, errors.New("not enough candles for a walk-forward window")
+	}
+	res := &WalkForwardResult{Return: 1}
+	var in, out float64
+	for start := 0; start+w.InSample+w.OutSample <= src.Len(); start += w.OutSample {
+		trials, err := w.Optimize(rows(src, start, start+w.InSample))
+		if err != nil {
+			return nil, err
+		}
+		best := trials[0]
+		if best.Err != "" {
+			return nil, errors.New(best.Err)
+		}
+		// The test runs on the in-sample candles too so the indicators are
+		// warm, trading only on the ones after.
+		cfg := w.Config
+		cfg.Warmup = w.InSample
+		r, err := Run(rows(src, start, start+w.InSample+w.OutSample), w.New(best.Params), cfg)
+		if err != nil {
+			return nil, err
+		}
+		m := NewReport(r).Metrics
+		window := Window{From: m.From, To: m.To, Best: best, Out: m, OutScore: w.Objective.score(m)}
+		res.Windows = append(res.Windows, window)
+		res.Return *= 1 + m.TotalReturn
+		in += best.Score
+		out += window.OutScore
+	}
+	res.Return--
+	res.Efficiency = ratio(out, in)
+	return res, nil
+}
@@ -0,0 +1,233 @@
+// Package bars turns time based candles into other kinds of bars:
+// Heikin-Ashi candles, Renko bricks, range bars and volume, dollar and tick
+// bars. They come back as candles again, so indicators run on them as they
+// are.
+//
+// A kind of bar is named like a virtual interval, the kind, the interval it
+// is built from and its size:
+//
+//	ha:1h             Heikin-Ashi candles of 1h candles
+//	renko:1m:50       Renko bricks of 50 price units
+//	renko:1m:atr14    Renko bricks as large as the 14 period ATR
+//	range:1m:100      bars spanning 100 price units from high to low
+//	volume:1m:5000    bars of 5000 base asset traded
+//	dollar:1m:1e8     bars of 1e8 quote asset traded
+//	tick:1m:20000     bars of 20000 trades
+//
+// Only candles are stored, not trades, so the bars are approximated from
+// them: range, volume, dollar and tick bars close on the first candle
+// reaching their size and are as fine as it, and Renko bricks only see the
+// closes.
+package bars
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+)
+
+type Kind string
+
+const (
+	HeikinAshi Kind = "ha"
+	Renko      Kind = "renko"
+	Range      Kind = "range"
+	Volume     Kind = "volume"
+	Dollar     Kind = "dollar"
+	Tick       Kind = "tick"
+)
+
+// Spec is a parsed virtual interval.
+type Spec struct {
+	Kind Kind
+	// interval of the candles the bars are built from
+	Base string
+	// box, range, volume or trade count of a bar
+	Size float64
+	// ATR period sizing Renko boxes instead of Size when set
+	ATR int
+
+	name string
+}
+
+func Parse(interval string) (Spec, error) {
+	parts := strings.Split(interval, ":")
+	if len(parts) < 2 {
+		return Spec{}, fmt.Errorf("bad virtual interval %q", interval)
+	}
+	s := Spec{Kind: Kind(parts[0]), Base: parts[1], name: interval}
+	if _, err := aggregate.ParseInterval(s.Base); err != nil {
+		return Spec{}, err
+	}
+	switch s.Kind {
+	case HeikinAshi:
+		if len(parts) != 2 {
+			return Spec{}, fmt.Errorf("bad virtual interval %q", interval)
+		}
+		return s, nil
+	case Renko, Range, Volume, Dollar, Tick:
+	default:
+		return Spec{}, fmt.Errorf("unknown bar kind %q", s.Kind)
+	}
+	if len(parts) != 3 {
+		return Spec{}, fmt.Errorf("%s bars need a size: %q", s.Kind, interval)
+	}
+	if s.Kind == Renko && strings.HasPrefix(parts[2], "atr") {
+		n, err := strconv.Atoi(strings.TrimPrefix(parts[2], "atr"))
+		if err != nil || n < 1 {
+			return Spec{}, fmt.Errorf("bad ATR period in %q", interval)
+		}
+		s.ATR = n
+		return s, nil
+	}
+	size, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil || !(size > 0) || math.IsInf(size, 0) {
+		return Spec{}, fmt.Errorf("bad size in %q", interval)
+	}
+	s.Size = size
+	return s, nil
+}
+
+// String is the virtual interval the spec was parsed from.
+func (s Spec) String() string {
+	return s.name
+}
+
+// Build turns the candles of src, sorted by open time and filled, into bars.
+// The last candle of src is taken as final, so bars built while it forms
+// may change once it closes.
+func (s Spec) Build(src *store.Batch) *store.Batch {
+	switch s.Kind {
+	case HeikinAshi:
+		return heikinAshi(src)
+	case Renko:
+		return renko(src, s.Size, s.ATR)
+	case Range:
+		return threshold(src, func(bar ingest.Kline) bool {
+			return bar.Candle.High-bar.Candle.Low >= s.Size
+		})
+	case Volume:
+		return threshold(src, func(bar ingest.Kline) bool {
+			return bar.Candle.Volume >= s.Size
+		})
+	case Dollar:
+		return threshold(src, func(bar ingest.Kline) bool {
+			return bar.Candle.QuoteVolume >= s.Size
+		})
+	case Tick:
+		return threshold(src, func(bar ingest.Kline) bool {
+			return float64(bar.Candle.Trades) >= s.Size
+		})
+	}
+	return &store.Batch{}
+}
+
+func heikinAshi(src *store.Batch) *store.Batch {
+	res := &store.Batch{}
+	var open, close float64
+	for i := 0; i < src.Len(); i++ {
+		k := aggregate.Row(src, i)
+		c := &k.Candle
+		if i == 0 {
+			open = (c.Open + c.Close) / 2
+		} else {
+			open = (open + close) / 2
+		}
+		close = (c.Open + c.High + c.Low + c.Close) / 4
+		c.Open, c.Close = open, close
+		c.High = math.Max(c.High, math.Max(open, close))
+		c.Low = math.Min(c.Low, math.Min(open, close))
+		aggregate.AppendBar(res, k)
+	}
+	return res
+}
+
+// threshold closes a bar on the first candle making done true. A last bar
+// not done yet is left out.
+func threshold(src *store.Batch, done func(bar ingest.Kline) bool) *store.Batch {
+	res := &store.Batch{}
+	var bar ingest.Kline
+	open := false
+	for i := 0; i < src.Len(); i++ {
+		k := aggregate.Row(src, i)
+		// quote volumes are unknown in old data
+		if math.IsNaN(k.Candle.QuoteVolume) {
+			k.Candle.QuoteVolume = k.Candle.Volume * k.Candle.Close
+		}
+		if open {
+			openTime := bar.Candle.OpenTime
+			bar = aggregate.Combine(bar, k)
+			bar.Candle.OpenTime = openTime
+			bar.CloseTime = k.CloseTime
+		} else {
+			bar, open = k, true
+		}
+		if done(bar) {
+			aggregate.AppendBar(res, bar)
+			open = false
+		}
+	}
+	return res
+}
+
+// renko lays a brick each time the close moves a box beyond the last brick,
+// so turning needs a move of two boxes. With atr set the box is the ATR of
+// the candle the brick forms on and no brick forms before the ATR is ready.
+// The bricks of one candle close with it and open a millisecond apart from
+// its open time, so each has an open time of its own to be keyed on; the
+// first carries the volumes.
+func renko(src *store.Batch, box float64, atr int) *store.Batch {
+	res := &store.Batch{}
+	var ind *stream.ATR
+	if atr > 0 {
+		ind = stream.NewATR(atr)
+	}
+	var lo, hi float64
+	started := false
+	// volumes of the candles since the last brick
+	var flow ingest.Kline
+	for i := 0; i < src.Len(); i++ {
+		k := aggregate.Row(src, i)
+		size := box
+		if ind != nil {
+			size = ind.Update(k.Candle)[0]
+			if !ind.Ready() {
+				continue
+			}
+		}
+		c := k.Candle
+		if !started {
+			lo, hi, started = c.Close, c.Close, true
+			flow = k
+			continue
+		}
+		flow.Candle.Volume += c.Volume
+		flow.Candle.QuoteVolume += c.QuoteVolume
+		flow.Candle.Trades += c.Trades
+		flow.Candle.TakerBuyVolume += c.TakerBuyVolume
+		flow.Candle.TakerBuyQuoteVolume += c.TakerBuyQuoteVolume
+		if size <= 0 {
+			continue
+		}
+		for j := int64(0); c.Close >= hi+size || c.Close <= lo-size; j++ {
+			open, close := hi, hi+size
+			if c.Close <= lo-size {
+				open, close = lo, lo-size
+			}
+			brick := flow
+			brick.Candle.OpenTime, brick.CloseTime = c.OpenTime+j, k.CloseTime
+			brick.Candle.Open, brick.Candle.Close = open, close
+			brick.Candle.High, brick.Candle.Low = math.Max(open, close), math.Min(open, close)
+			aggregate.AppendBar(res, brick)
+			lo, hi = brick.Candle.Low, brick.Candle.High
+			flow = ingest.Kline{}
+		}
+	}
+	return res
+}
@@ -0,0 +1,203 @@
+package bars
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/ingest"
+	"cryptoapi/internal/store"
+	"cryptoapi/internal/stream"
+)
+
+const minute = int64(60000)
+
+// batch returns 1m candles opened a minute apart from the rows of open,
+// high, low, close, volume and trades, the quote volume being the volume
+// times the close.
+func batch(rows ...[6]float64) *store.Batch {
+	b := &store.Batch{}
+	for i, r := range rows {
+		aggregate.AppendBar(b, ingest.Kline{
+			Candle: stream.Candle{
+				OpenTime:    int64(i) * minute,
+				Open:        r[0],
+				High:        r[1],
+				Low:         r[2],
+				Close:       r[3],
+				Volume:      r[4],
+				QuoteVolume: r[4] * r[3],
+				Trades:      int64(r[5]),
+			},
+			CloseTime: int64(i)*minute + minute - 1,
+		})
+	}
+	return b
+}
+
+// closes returns candles a price unit high and low around the closes, each
+// with a volume of one.
+func closes(prices ...float64) *store.Batch {
+	var rows [][6]float64
+	for _, p := range prices {
+		rows = append(rows, [6]float64{p, p + 1, p - 1, p, 1, 1})
+	}
+	return batch(rows...)
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		interval string
+		want     Spec
+	}{
+		{"ha:1h", Spec{Kind: HeikinAshi, Base: "1h"}},
+		{"renko:1m:50", Spec{Kind: Renko, Base: "1m", Size: 50}},
+		{"renko:1m:atr14", Spec{Kind: Renko, Base: "1m", ATR: 14}},
+		{"dollar:1m:1e8", Spec{Kind: Dollar, Base: "1m", Size: 1e8}},
+		{"tick:15m:20000", Spec{Kind: Tick, Base: "15m", Size: 20000}},
+		{"ha", Spec{}},
+		{"ha:1h:5", Spec{}},
+		{"ha:1x", Spec{}},
+		{"kagi:1m:5", Spec{}},
+		{"range:1m", Spec{}},
+		{"range:1m:0", Spec{}},
+		{"range:1m:-5", Spec{}},
+		{"range:1m:NaN", Spec{}},
+		{"volume:1m:Inf", Spec{}},
+		{"renko:1m:atr0", Spec{}},
+		{"range:1m:atr14", Spec{}},
+	} {
+		got, err := Parse(tt.interval)
+		if tt.want.Kind == "" {
+			if err == nil {
+				t.Errorf("%s: parsed to %+v", tt.interval, got)
+			}
+			continue
+		}
+		tt.want.name = tt.interval
+		if err != nil {
+			t.Errorf("%s: %v", tt.interval, err)
+		} else if got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	got := heikinAshi(batch(
+		[6]float64{10, 12, 9, 11, 1, 1},
+		[6]float64{11, 14, 10, 13, 1, 1},
+		[6]float64{9, 10, 8, 9, 1, 1},
+	))
+	// the second and third open halfway through the candle before, the last
+	// one reaching above its high
+	want := [][4]float64{
+		{10.5, 12, 9, 10.5},
+		{10.5, 14, 10, 12},
+		{11.25, 11.25, 8, 9},
+	}
+	for i, w := range want {
+		if g := [4]float64{got.Open[i], got.High[i], got.Low[i], got.Close[i]}; g != w {
+			t.Errorf("candle %d: got %v, want %v", i, g, w)
+		}
+	}
+	if !reflect.DeepEqual(got.OpenTime, []int64{0, minute, 2 * minute}) {
+		t.Errorf("opened at %v", got.OpenTime)
+	}
+}
+
+func TestRenko(t *testing.T) {
+	got := renko(closes(100, 105, 131, 115, 95, 89), 10, 0)
+	// three bricks up on the third candle, two down on the fifth once the
+	// close is two boxes below the top and one more on the last
+	wantOpen := []float64{100, 110, 120, 120, 110, 100}
+	wantClose := []float64{110, 120, 130, 110, 100, 90}
+	wantTime := []int64{2 * minute, 2*minute + 1, 2*minute + 2, 4 * minute, 4*minute + 1, 5 * minute}
+	wantCloseTime := []int64{3*minute - 1, 3*minute - 1, 3*minute - 1, 5*minute - 1, 5*minute - 1, 6*minute - 1}
+	// the first brick of a candle carries the volumes since the last one
+	wantVolume := []float64{3, 0, 0, 2, 0, 1}
+	if !reflect.DeepEqual(got.Open, wantOpen) || !reflect.DeepEqual(got.Close, wantClose) {
+		t.Errorf("bricks from %v to %v, want %v to %v", got.Open, got.Close, wantOpen, wantClose)
+	}
+	if !reflect.DeepEqual(got.OpenTime, wantTime) || !reflect.DeepEqual(got.CloseTime, wantCloseTime) {
+		t.Errorf("bricks opened at %v closing at %v, want %v and %v", got.OpenTime, got.CloseTime, wantTime, wantCloseTime)
+	}
+	if !reflect.DeepEqual(got.Volume, wantVolume) {
+		t.Errorf("volumes %v, want %v", got.Volume, wantVolume)
+	}
+	for i := range got.Open {
+		if got.High[i] != math.Max(got.Open[i], got.Close[i]) || got.Low[i] != math.Min(got.Open[i], got.Close[i]) {
+			t.Errorf("brick %d spans %v to %v", i, got.Low[i], got.High[i])
+		}
+	}
+}
+
+func TestRenkoATR(t *testing.T) {
+	src := closes(100, 200, 200, 300)
+	// the ATR is ready on the third candle, which the bricks start from, so
+	// the jump on the second lays none
+	ind := stream.NewATR(2)
+	var size float64
+	for i := 0; i < src.Len(); i++ {
+		size = ind.Update(aggregate.Row(src, i).Candle)[0]
+		if ready := ind.Ready(); ready != (i >= 2) {
+			t.Fatalf("ATR ready %v on candle %d", ready, i)
+		}
+	}
+	spec, err := Parse("renko:1m:atr2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := spec.Build(src)
+	if got.Len() != 1 || got.OpenTime[0] != 3*minute || got.Open[0] != 200 || got.Close[0] != 200+size {
+		t.Errorf("got bricks %v to %v opened at %v, want one from 200 to %v at %d", got.Open, got.Close, got.OpenTime, 200+size, 3*minute)
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	src := batch(
+		[6]float64{10, 11, 9, 10, 2, 100},
+		[6]float64{10, 12, 10, 11, 2, 100},
+		[6]float64{11, 16, 11, 15, 2, 300},
+		[6]float64{15, 15, 13, 14, 4, 50},
+		[6]float64{14, 14, 9, 10, 1, 50},
+		[6]float64{10, 10, 10, 10, 1, 50},
+	)
+	// a quote volume unknown is taken as the volume times the close
+	src.QuoteAssetVolume[1] = math.NaN()
+	for _, tt := range []struct {
+		interval string
+		// first and last candle of each bar, the last candles never making one
+		want [][2]int
+	}{
+		{"range:1m:5", [][2]int{{0, 2}, {3, 4}}},
+		{"volume:1m:4", [][2]int{{0, 1}, {2, 3}}},
+		{"dollar:1m:50", [][2]int{{0, 2}, {3, 3}}},
+		{"tick:1m:200", [][2]int{{0, 1}, {2, 2}}},
+	} {
+		spec, err := Parse(tt.interval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := spec.Build(src)
+		var open, close []int64
+		var wantOpen, wantClose []int64
+		for i := 0; i < got.Len(); i++ {
+			open, close = append(open, got.OpenTime[i]), append(close, got.CloseTime[i])
+		}
+		for _, w := range tt.want {
+			wantOpen, wantClose = append(wantOpen, src.OpenTime[w[0]]), append(wantClose, src.CloseTime[w[1]])
+		}
+		if !reflect.DeepEqual(open, wantOpen) || !reflect.DeepEqual(close, wantClose) {
+			t.Errorf("%s: bars from %v to %v, want %v to %v", tt.interval, open, close, wantOpen, wantClose)
+		}
+	}
+
+	spec, _ := Parse("volume:1m:4")
+	got := aggregate.Row(spec.Build(src), 0).Candle
+	want := stream.Candle{Open: 10, High: 12, Low: 9, Close: 11, Volume: 4, QuoteVolume: 42, Trades: 200}
+	if got != want {
+		t.Errorf("first volume bar %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,396 @@
+// Package binance is a client for the Binance REST API that keeps within
+// the request weight limits and retries what is worth retrying. With the
+// keys of an account it signs the requests of the trading endpoints.
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://api.binance.com"
+	// DefaultWeightLimit is the REQUEST_WEIGHT limit per minute of a spot IP.
+	DefaultWeightLimit = 1200
+
+	defaultMaxRetries = 5
+	backoffBase       = 500 * time.Millisecond
+	backoffMax        = 30 * time.Second
+	weightHeader      = "X-Mbx-Used-Weight-1m"
+	apiKeyHeader      = "X-MBX-APIKEY"
+	// DefaultRecvWindow is the milliseconds a signed request stays valid.
+	DefaultRecvWindow = 5000
+
+	// error code of a timestamp outside the recvWindow
+	codeTimestamp = -1021
+	// error code of an order the exchange does not know
+	codeNoSuchOrder = -2013
+)
+
+// APIError is an error answered by Binance as a {code,msg} body.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"code"`
+	Msg        string `json:"msg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("binance: %d %s (status %d)", e.Code, e.Msg, e.StatusCode)
+}
+
+// UnknownOrder tells whether err is Binance not knowing the order asked
+// about, which it forgets some time after it is done with.
+func UnknownOrder(err error) bool {
+	e, ok := err.(*APIError)
+	return ok && e.Code == codeNoSuchOrder
+}
+
+// RateLimitError is returned on a 429, or a 418 once the IP is banned, when
+// the retries are exhausted or the wait is longer than the context allows.
+type RateLimitError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.StatusCode == http.StatusTeapot {
+		return fmt.Sprintf("binance: ip banned, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("binance: rate limited, retry after %s", e.RetryAfter)
+}
+
+type Client struct {
+	BaseURL     string
+	HTTPClient  *http.Client
+	WeightLimit int
+	MaxRetries  int
+	// keys of the account, needed by the trading endpoints only
+	APIKey     string
+	SecretKey  string
+	RecvWindow int64
+
+	mu sync.Mutex
+	// weight used in the current minute as last reported by Binance
+	usedWeight   int
+	weightMinute time.Time
+	// no request is sent before this time after a 429 or 418
+	blockedUntil time.Time
+	blockStatus  int
+	// server time less the local time, see SyncTime
+	offset time.Duration
+}
+
+// New returns a client for baseURL, DefaultBaseURL when empty.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		BaseURL:     baseURL,
+		HTTPClient:  httpClient,
+		WeightLimit: DefaultWeightLimit,
+		MaxRetries:  defaultMaxRetries,
+		RecvWindow:  DefaultRecvWindow,
+	}
+}
+
+// UsedWeight is the request weight used in the current minute.
+func (c *Client) UsedWeight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.weightMinute.Equal(time.Now().Truncate(time.Minute)) {
+		return 0
+	}
+	return c.usedWeight
+}
+
+// security is how a request authenticates.
+type security int
+
+const (
+	public security = iota
+	// the API key header only
+	keyed
+	// the API key header and a signed timestamp
+	signed
+)
+
+// Get sends a GET request of the given weight and returns the body of the
+// successful response.
+func (c *Client) Get(ctx context.Context, path string, params url.Values, weight int) ([]byte, error) {
+	return c.send(ctx, http.MethodGet, path, params, weight, public)
+}
+
+// Signed sends a request to an endpoint of the account, adding the
+// timestamp, the recvWindow and the HMAC-SHA256 signature of the parameters.
+// Requests other than GET are only retried when Binance turned them down
+// unprocessed, so an order is never sent twice.
+func (c *Client) Signed(ctx context.Context, method, path string, params url.Values, weight int) ([]byte, error) {
+	return c.send(ctx, method, path, params, weight, signed)
+}
+
+// Keyed sends a request to an endpoint needing the API key but no signature,
+// such as the user data stream ones.
+func (c *Client) Keyed(ctx context.Context, method, path string, params url.Values, weight int) ([]byte, error) {
+	return c.send(ctx, method, path, params, weight, keyed)
+}
+
+// ServerTime returns the time of the Binance servers.
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	body, err := c.Get(ctx, "/api/v3/time", nil, 1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var res struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, res.ServerTime*int64(time.Millisecond)), nil
+}
+
+// SyncTime measures how far the local clock is from the server one, so the
+// timestamps of signed requests fall within their recvWindow. Signed
+// requests resync on their own when Binance finds them outside of it.
+func (c *Client) SyncTime(ctx context.Context) error {
+	sent := time.Now()
+	server, err := c.ServerTime(ctx)
+	if err != nil {
+		return err
+	}
+	received := time.Now()
+	c.mu.Lock()
+	c.offset = server.Sub(sent.Add(received.Sub(sent) / 2))
+	c.mu.Unlock()
+	return nil
+}
+
+// Now is the time of the Binance servers as last synced.
+func (c *Client) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Now().Add(c.offset)
+}
+
+// Sign returns the hex HMAC-SHA256 of payload under secret, as Binance signs
+// the parameters of a request.
+func Sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// url returns the URL of a request, signed at the current time.
+func (c *Client) url(path string, params url.Values, sec security) string {
+	u := c.BaseURL + path
+	query := params.Encode()
+	if sec == signed {
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		q.Set("timestamp", strconv.FormatInt(c.Now().UnixNano()/int64(time.Millisecond), 10))
+		if c.RecvWindow > 0 {
+			q.Set("recvWindow", strconv.FormatInt(c.RecvWindow, 10))
+		}
+		query = q.Encode()
+		query += "&signature=" + Sign(c.SecretKey, query)
+	}
+	if query != "" {
+		u += "?" + query
+	}
+	return u
+}
+
+func (c *Client) send(ctx context.Context, method, path string, params url.Values, weight int, sec security) ([]byte, error) {
+	if sec != public && c.APIKey == "" {
+		return nil, fmt.Errorf("binance: %s needs an api key", path)
+	}
+	var lastErr error
+	synced := false
+	for attempt := 0; ; attempt++ {
+		if err := c.reserve(ctx, weight); err != nil {
+			return nil, err
+		}
+		body, retryAfter, err := c.do(ctx, method, c.url(path, params, sec), sec)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if e, ok := err.(*APIError); ok && e.Code == codeTimestamp && !synced {
+			synced = true
+			if c.SyncTime(ctx) == nil {
+				continue
+			}
+		}
+		if !retryable(method, err) || attempt >= c.MaxRetries {
+			return nil, lastErr
+		}
+		wait := retryAfter
+		if wait == 0 {
+			wait = backoff(attempt)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, lastErr
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, u string, sec security) ([]byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sec != public {
+		req.Header.Set(apiKeyHeader, c.APIKey)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	c.updateWeight(resp.Header)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot:
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		c.block(resp.StatusCode, retryAfter)
+		return nil, retryAfter, &RateLimitError{StatusCode: resp.StatusCode, RetryAfter: retryAfter}
+	case resp.StatusCode >= 400:
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Msg == "" {
+			apiErr.Msg = http.StatusText(resp.StatusCode)
+		}
+		return nil, 0, apiErr
+	}
+	return body, 0, nil
+}
+
+// retryable tells the transient failures apart: network errors, server
+// errors and rate limits, but not a ban nor a rejected request. Only the
+// rate limits are sure not to have been processed, so they are the only
+// failures retried for the requests changing something.
+func retryable(method string, err error) bool {
+	if method != http.MethodGet {
+		e, ok := err.(*RateLimitError)
+		return ok && e.StatusCode == http.StatusTooManyRequests
+	}
+	switch e := err.(type) {
+	case *APIError:
+		return e.StatusCode >= 500
+	case *RateLimitError:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// reserve waits until a request of the given weight fits in the current
+// minute and no ban or rate limit is in force.
+func (c *Client) reserve(ctx context.Context, weight int) error {
+	for {
+		now := time.Now()
+		c.mu.Lock()
+		var wait time.Duration
+		switch {
+		case now.Before(c.blockedUntil):
+			if c.blockStatus == http.StatusTeapot {
+				err := &RateLimitError{StatusCode: c.blockStatus, RetryAfter: c.blockedUntil.Sub(now)}
+				c.mu.Unlock()
+				return err
+			}
+			wait = c.blockedUntil.Sub(now)
+		case c.weightMinute.Equal(now.Truncate(time.Minute)) && c.usedWeight+weight > c.WeightLimit:
+			wait = c.weightMinute.Add(time.Minute).Sub(now)
+		default:
+			if !c.weightMinute.Equal(now.Truncate(time.Minute)) {
+				c.weightMinute, c.usedWeight = now.Truncate(time.Minute), 0
+			}
+			// Counted right away so concurrent callers see it before
+			// Binance reports it back.
+			c.usedWeight += weight
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) updateWeight(h http.Header) {
+	used, err := strconv.Atoi(h.Get(weightHeader))
+	if err != nil {
+		return
+	}
+	minute := time.Now().Truncate(time.Minute)
+	c.mu.Lock()
+	if !c.weightMinute.Equal(minute) || used > c.usedWeight {
+		c.weightMinute, c.usedWeight = minute, used
+	}
+	c.mu.Unlock()
+}
+
+func (c *Client) block(status int, retryAfter time.Duration) {
+	if retryAfter == 0 {
+		retryAfter = backoffBase
+	}
+	c.mu.Lock()
+	if until := time.Now().Add(retryAfter); until.After(c.blockedUntil) {
+		c.blockedUntil, c.blockStatus = until, status
+	}
+	c.mu.Unlock()
+}
+
+// parseRetryAfter reads a Retry-After header, given in seconds by Binance.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// backoff is the full-jitter exponential backoff of the given attempt.
+func backoff(attempt int) time.Duration {
+	d := backoffBase << uint(attempt)
+	if d > backoffMax || d <= 0 {
+		d = backoffMax
+	}
+	return time.Duration(rand.Int63n(int64(d))) + time.Millisecond
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// server answers every request with handler, counting them.
+func server(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, hit int)) (*Client, *int32) {
+	hits := new(int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, int(atomic.AddInt32(hits, 1)))
+	}))
+	t.Cleanup(srv.Close)
+	c := New(srv.URL, nil)
+	c.APIKey, c.SecretKey = "key", "secret"
+	return c, hits
+}
+
+func TestWeight(t *testing.T) {
+	// the weight is per minute, so none of the test is let run across one
+	if left := time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)); left < 2*time.Second {
+		time.Sleep(left)
+	}
+	c, hits := server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		w.Header().Set(weightHeader, "37")
+		w.Write([]byte("{}"))
+	})
+	c.WeightLimit = 40
+	if _, err := c.Get(context.Background(), "/api/v3/time", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if used := c.UsedWeight(); used != 37 {
+		t.Errorf("used %d, want the 37 Binance reported", used)
+	}
+	// a request over the limit waits for the next minute
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(ctx, "/api/v3/exchangeInfo", nil, 10); err != context.DeadlineExceeded {
+		t.Errorf("over the limit: got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+	if _, err := c.Get(context.Background(), "/api/v3/time", nil, 3); err != nil {
+		t.Errorf("within the limit: %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	c, hits := server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		if hit == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	started := time.Now()
+	if _, err := c.Get(context.Background(), "/api/v3/time", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("%d requests sent, want 2", n)
+	}
+	if waited := time.Since(started); waited < time.Second {
+		t.Errorf("retried after %s, before the Retry-After", waited)
+	}
+}
+
+func TestBan(t *testing.T) {
+	c, hits := server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTeapot)
+	})
+	_, err := c.Get(context.Background(), "/api/v3/time", nil, 1)
+	e, ok := err.(*RateLimitError)
+	if !ok || e.StatusCode != http.StatusTeapot || e.RetryAfter != 2*time.Minute {
+		t.Fatalf("got %v", err)
+	}
+	// nothing is sent until the ban is over
+	if _, err := c.Get(context.Background(), "/api/v3/time", nil, 1); err == nil {
+		t.Error("request sent while banned")
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	c, hits := server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		switch r.URL.Path {
+		case "/api/v3/klines":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>bad gateway</html>"))
+		}
+	})
+	c.MaxRetries = 1
+	_, err := c.Get(context.Background(), "/api/v3/klines", nil, 1)
+	if e, ok := err.(*APIError); !ok || *e != (APIError{StatusCode: 400, Code: -1121, Msg: "Invalid symbol."}) {
+		t.Errorf("got %v", err)
+	}
+	// a rejected request is not retried
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+	// a server error is, and a body that is not JSON gets the status text
+	_, err = c.Get(context.Background(), "/api/v3/time", nil, 1)
+	if e, ok := err.(*APIError); !ok || e.StatusCode != http.StatusBadGateway || e.Msg != "Bad Gateway" {
+		t.Errorf("got %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 3 {
+		t.Errorf("%d requests sent, want 3", n)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	c, hits := server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		if r.Method != http.MethodPost || r.Header.Get(apiKeyHeader) != "key" || r.URL.Query().Get("signature") == "" {
+			t.Errorf("got %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	// the order may have gone through, so it is not sent again
+	if _, err := c.Signed(context.Background(), http.MethodPost, "/api/v3/order", nil, 1); err == nil {
+		t.Fatal("no error")
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("%d orders sent, want 1", n)
+	}
+
+	// a rate limited one was turned down unprocessed and is
+	c, hits = server(t, func(w http.ResponseWriter, r *http.Request, hit int) {
+		if hit == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	if _, err := c.Signed(context.Background(), http.MethodPost, "/api/v3/order", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("%d orders sent, want 2", n)
+	}
+}
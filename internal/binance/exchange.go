@@ -0,0 +1,174 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Symbol is the trading rules of one symbol from exchangeInfo, with the
+// filters the order layer needs already decoded.
+type Symbol struct {
+	Symbol     string
+	Status     string
+	BaseAsset  string
+	QuoteAsset string
+	// price and quantity increments and the smallest order allowed
+	TickSize    float64
+	StepSize    float64
+	MinQty      float64
+	MinNotional float64
+}
+
+type exchangeInfo struct {
+	Symbols []struct {
+		Symbol     string `json:"symbol"`
+		Status     string `json:"status"`
+		BaseAsset  string `json:"baseAsset"`
+		QuoteAsset string `json:"quoteAsset"`
+		Filters    []struct {
+			FilterType  string `json:"filterType"`
+			TickSize    string `json:"tickSize"`
+			StepSize    string `json:"stepSize"`
+			MinQty      string `json:"minQty"`
+			MinNotional string `json:"minNotional"`
+		} `json:"filters"`
+	} `json:"symbols"`
+}
+
+// ExchangeInfo returns the rules of every symbol listed on the exchange.
+func (c *Client) ExchangeInfo(ctx context.Context) ([]Symbol, error) {
+	body, err := c.Get(ctx, "/api/v3/exchangeInfo", nil, 10)
+	if err != nil {
+		return nil, err
+	}
+	var info exchangeInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, err
+	}
+	res := make([]Symbol, len(info.Symbols))
+	for i, s := range info.Symbols {
+		res[i] = Symbol{
+			Symbol:     s.Symbol,
+			Status:     s.Status,
+			BaseAsset:  s.BaseAsset,
+			QuoteAsset: s.QuoteAsset,
+		}
+		for _, f := range s.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				res[i].TickSize = parseFloat(f.TickSize)
+			case "LOT_SIZE":
+				res[i].StepSize = parseFloat(f.StepSize)
+				res[i].MinQty = parseFloat(f.MinQty)
+			case "MIN_NOTIONAL", "NOTIONAL":
+				res[i].MinNotional = parseFloat(f.MinNotional)
+			}
+		}
+	}
+	return res, nil
+}
+
+// Ticker24h is the rolling 24h statistics of a symbol.
+type Ticker24h struct {
+	Symbol      string
+	LastPrice   float64
+	Volume      float64
+	QuoteVolume float64
+}
+
+// RoundQty rounds qty down to the LOT_SIZE step.
+func (s Symbol) RoundQty(qty float64) float64 {
+	return roundStep(qty, s.StepSize, math.Floor)
+}
+
+// RoundPrice rounds price to the nearest PRICE_FILTER tick.
+func (s Symbol) RoundPrice(price float64) float64 {
+	return roundStep(price, s.TickSize, math.Round)
+}
+
+// FormatQty writes qty with the decimals of the LOT_SIZE step.
+func (s Symbol) FormatQty(qty float64) string {
+	return formatStep(qty, s.StepSize)
+}
+
+// FormatPrice writes price with the decimals of the PRICE_FILTER tick.
+func (s Symbol) FormatPrice(price float64) string {
+	return formatStep(price, s.TickSize)
+}
+
+// CheckQty tells whether an order of qty at price passes the LOT_SIZE and
+// notional filters, price being left unchecked when zero.
+func (s Symbol) CheckQty(qty, price float64) error {
+	if qty <= 0 || qty < s.MinQty {
+		return fmt.Errorf("%s: quantity %v below the minimum of %v", s.Symbol, qty, s.MinQty)
+	}
+	if price > 0 && qty*price < s.MinNotional {
+		return fmt.Errorf("%s: notional %v below the minimum of %v", s.Symbol, qty*price, s.MinNotional)
+	}
+	return nil
+}
+
+// roundStep rounds v to a multiple of step with round, leaving it as is
+// without a step. The small nudge keeps values already on a step, such as
+// 0.3 with a step of 0.1, from going down one.
+func roundStep(v, step float64, round func(float64) float64) float64 {
+	if step <= 0 {
+		return v
+	}
+	n := v / step
+	if round(n) != math.Round(n) && math.Abs(n-math.Round(n)) < 1e-9 {
+		n = math.Round(n)
+	}
+	res, _ := strconv.ParseFloat(formatStep(round(n)*step, step), 64)
+	return res
+}
+
+// formatStep writes v with as many decimals as step has.
+func formatStep(v, step float64) string {
+	if step <= 0 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	decimals := 0
+	if s := strconv.FormatFloat(step, 'f', -1, 64); strings.Contains(s, ".") {
+		decimals = len(s) - strings.Index(s, ".") - 1
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// Tickers24h returns the 24h statistics of every symbol.
+func (c *Client) Tickers24h(ctx context.Context) ([]Ticker24h, error) {
+	body, err := c.Get(ctx, "/api/v3/ticker/24hr", nil, 40)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Symbol      string `json:"symbol"`
+		LastPrice   string `json:"lastPrice"`
+		Volume      string `json:"volume"`
+		QuoteVolume string `json:"quoteVolume"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+	res := make([]Ticker24h, len(rows))
+	for i, r := range rows {
+		res[i] = Ticker24h{
+			Symbol:      r.Symbol,
+			LastPrice:   parseFloat(r.LastPrice),
+			Volume:      parseFloat(r.Volume),
+			QuoteVolume: parseFloat(r.QuoteVolume),
+		}
+	}
+	return res, nil
+}
+
+// parseFloat reads the decimal strings Binance uses for prices and sizes,
+// zero when absent.
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
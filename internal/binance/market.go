@@ -0,0 +1,44 @@
+package binance
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// MaxKlines is the most klines a single request returns.
+const MaxKlines = 1000
+
+// klinesWeight is the weight of /api/v3/klines for up to MaxKlines rows.
+func klinesWeight(limit int) int {
+	switch {
+	case limit <= 0:
+		// defaults to 500 rows
+		return 2
+	case limit > 1000:
+		return 10
+	case limit > 500:
+		return 5
+	case limit > 100:
+		return 2
+	}
+	return 1
+}
+
+// Klines returns the raw JSON rows of /api/v3/klines. Zero startTime and
+// endTime are left out, so the most recent candles come back.
+func (c *Client) Klines(ctx context.Context, symbol, interval string, startTime, endTime int64, limit int) ([]byte, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("interval", interval)
+	if startTime != 0 {
+		params.Set("startTime", strconv.FormatInt(startTime, 10))
+	}
+	if endTime != 0 {
+		params.Set("endTime", strconv.FormatInt(endTime, 10))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	return c.Get(ctx, "/api/v3/klines", params, klinesWeight(limit))
+}
@@ -0,0 +1,678 @@
+// Package mock is an in-process Binance spot exchange serving the endpoints
+// the trading client uses: server time, exchange info, orders, OCOs, the
+// account and the user data stream. It checks the API key, the signatures,
+// the recvWindow and the LOT_SIZE, PRICE_FILTER and notional filters like
+// Binance does, and fills the orders against the prices it is given.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/binance"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultFee is the commission rate of every trade.
+const DefaultFee = 0.001
+
+type order struct {
+	binance.Order
+	// funds held by the order until it is done with
+	lockAsset string
+	lock      float64
+	// the stop of a stop order was reached
+	triggered bool
+}
+
+type Exchange struct {
+	APIKey    string
+	SecretKey string
+	Fee       float64
+
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu       sync.Mutex
+	symbols  map[string]binance.Symbol
+	prices   map[string]float64
+	balances map[string]float64
+	locked   map[string]float64
+	orders   map[int64]*order
+	lists    map[int64][]int64
+	nextID   int64
+	trades   int64
+	streams  map[string][]*websocket.Conn
+}
+
+// New starts an exchange listing symbols, whose API key and secret are
+// apiKey and secretKey. Close stops it.
+func New(apiKey, secretKey string, symbols ...binance.Symbol) *Exchange {
+	e := &Exchange{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		Fee:       DefaultFee,
+		symbols:   make(map[string]binance.Symbol),
+		prices:    make(map[string]float64),
+		balances:  make(map[string]float64),
+		locked:    make(map[string]float64),
+		orders:    make(map[int64]*order),
+		lists:     make(map[int64][]int64),
+		streams:   make(map[string][]*websocket.Conn),
+	}
+	for _, s := range symbols {
+		e.symbols[s.Symbol] = s
+	}
+	e.server = httptest.NewServer(e)
+	return e
+}
+
+// URL is the base of the REST endpoints.
+func (e *Exchange) URL() string {
+	return e.server.URL
+}
+
+// StreamURL is the websocket endpoint the listen keys are appended to.
+func (e *Exchange) StreamURL() string {
+	return "ws" + strings.TrimPrefix(e.server.URL, "http") + "/ws"
+}
+
+func (e *Exchange) Close() {
+	e.mu.Lock()
+	for _, conns := range e.streams {
+		for _, c := range conns {
+			c.Close()
+		}
+	}
+	e.mu.Unlock()
+	e.server.Close()
+}
+
+// AddSymbol lists a symbol, or changes its filters.
+func (e *Exchange) AddSymbol(s binance.Symbol) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.symbols[s.Symbol] = s
+}
+
+// Deposit adds amount to the balance of asset.
+func (e *Exchange) Deposit(asset string, amount float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balances[asset] += amount
+}
+
+// Balance returns the free and locked amounts of asset.
+func (e *Exchange) Balance(asset string) (float64, float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.balances[asset] - e.locked[asset], e.locked[asset]
+}
+
+// SetPrice moves the market of symbol to price, filling the open orders it
+// reaches.
+func (e *Exchange) SetPrice(symbol string, price float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.prices[symbol] = price
+	for id := int64(1); id <= e.nextID; id++ {
+		o, ok := e.orders[id]
+		if !ok || o.Symbol != symbol || o.Status.Final() {
+			continue
+		}
+		e.match(o, price)
+	}
+}
+
+// Price returns the last price of symbol.
+func (e *Exchange) Price(symbol string) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.prices[symbol]
+}
+
+// apiError is a Binance {code,msg} answer.
+type apiError struct {
+	status int
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+func reject(status, code int, format string, args ...interface{}) *apiError {
+	return &apiError{status: status, Code: code, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Exchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/ws/") {
+		e.serveStream(w, r)
+		return
+	}
+	res, err := e.route(r)
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(err.status)
+		json.NewEncoder(w).Encode(err)
+		return
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+func (e *Exchange) route(r *http.Request) (interface{}, *apiError) {
+	q := r.URL.Query()
+	switch r.Method + " " + r.URL.Path {
+	case "GET /api/v3/time":
+		return map[string]int64{"serverTime": millis(time.Now())}, nil
+	case "GET /api/v3/exchangeInfo":
+		return e.exchangeInfo(), nil
+	case "POST /api/v3/userDataStream":
+		if err := e.checkKey(r); err != nil {
+			return nil, err
+		}
+		return map[string]string{"listenKey": fmt.Sprintf("mock%d", time.Now().UnixNano())}, nil
+	case "PUT /api/v3/userDataStream", "DELETE /api/v3/userDataStream":
+		if err := e.checkKey(r); err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	}
+	if err := e.checkSigned(r); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch r.Method + " " + r.URL.Path {
+	case "POST /api/v3/order":
+		return e.newOrder(q)
+	case "GET /api/v3/order":
+		o, err := e.find(q)
+		if err != nil {
+			return nil, err
+		}
+		return o.Order, nil
+	case "DELETE /api/v3/order":
+		o, err := e.find(q)
+		if err != nil {
+			return nil, err
+		}
+		if o.Status.Final() {
+			return nil, reject(http.StatusBadRequest, -2011, "Unknown order sent.")
+		}
+		if o.OrderListID != -1 {
+			e.cancelList(o.OrderListID)
+		} else {
+			e.cancel(o)
+		}
+		return o.Order, nil
+	case "GET /api/v3/openOrders":
+		res := []binance.Order{}
+		for id := int64(1); id <= e.nextID; id++ {
+			if o, ok := e.orders[id]; ok && !o.Status.Final() && (q.Get("symbol") == "" || o.Symbol == q.Get("symbol")) {
+				res = append(res, o.Order)
+			}
+		}
+		return res, nil
+	case "POST /api/v3/order/oco":
+		return e.newOCO(q)
+	case "DELETE /api/v3/orderList":
+		id, _ := strconv.ParseInt(q.Get("orderListId"), 10, 64)
+		// a list done with cannot be canceled any more
+		if legs, ok := e.lists[id]; !ok || e.orders[legs[0]].Status.Final() {
+			return nil, reject(http.StatusBadRequest, -2011, "Unknown order list sent.")
+		}
+		e.cancelList(id)
+		return e.orderList(id, q.Get("symbol")), nil
+	case "GET /api/v3/account":
+		var res struct {
+			Balances []binance.Balance `json:"balances"`
+		}
+		for asset, amount := range e.balances {
+			res.Balances = append(res.Balances, binance.Balance{Asset: asset, Free: amount - e.locked[asset], Locked: e.locked[asset]})
+		}
+		return res, nil
+	}
+	return nil, reject(http.StatusNotFound, -1000, "Unknown endpoint %s %s.", r.Method, r.URL.Path)
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func (e *Exchange) checkKey(r *http.Request) *apiError {
+	if r.Header.Get("X-MBX-APIKEY") != e.APIKey || e.APIKey == "" {
+		return reject(http.StatusUnauthorized, -2015, "Invalid API-key, IP, or permissions for action.")
+	}
+	return nil
+}
+
+// checkSigned checks the key, the signature of the query and that it was
+// sent within its recvWindow.
+func (e *Exchange) checkSigned(r *http.Request) *apiError {
+	if err := e.checkKey(r); err != nil {
+		return err
+	}
+	raw := r.URL.RawQuery
+	i := strings.LastIndex(raw, "&signature=")
+	if i < 0 || binance.Sign(e.SecretKey, raw[:i]) != raw[i+len("&signature="):] {
+		return reject(http.StatusBadRequest, -1022, "Signature for this request is not valid.")
+	}
+	q := r.URL.Query()
+	ts, err := strconv.ParseInt(q.Get("timestamp"), 10, 64)
+	if err != nil {
+		return reject(http.StatusBadRequest, -1102, "Mandatory parameter 'timestamp' was not sent, was empty/null, or malformed.")
+	}
+	window := int64(binance.DefaultRecvWindow)
+	if v := q.Get("recvWindow"); v != "" {
+		window, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if now := millis(time.Now()); ts > now+1000 || now-ts > window {
+		return reject(http.StatusBadRequest, -1021, "Timestamp for this request is outside of the recvWindow.")
+	}
+	return nil
+}
+
+func (e *Exchange) exchangeInfo() interface{} {
+	type filter struct {
+		FilterType  string `json:"filterType"`
+		TickSize    string `json:"tickSize,omitempty"`
+		StepSize    string `json:"stepSize,omitempty"`
+		MinQty      string `json:"minQty,omitempty"`
+		MinNotional string `json:"minNotional,omitempty"`
+	}
+	type symbol struct {
+		Symbol     string   `json:"symbol"`
+		Status     string   `json:"status"`
+		BaseAsset  string   `json:"baseAsset"`
+		QuoteAsset string   `json:"quoteAsset"`
+		Filters    []filter `json:"filters"`
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	var res struct {
+		Symbols []symbol `json:"symbols"`
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range e.symbols {
+		res.Symbols = append(res.Symbols, symbol{
+			Symbol:     s.Symbol,
+			Status:     "TRADING",
+			BaseAsset:  s.BaseAsset,
+			QuoteAsset: s.QuoteAsset,
+			Filters: []filter{
+				{FilterType: "PRICE_FILTER", TickSize: format(s.TickSize)},
+				{FilterType: "LOT_SIZE", StepSize: format(s.StepSize), MinQty: format(s.MinQty)},
+				{FilterType: "NOTIONAL", MinNotional: format(s.MinNotional)},
+			},
+		})
+	}
+	return res
+}
+
+// onStep tells whether v is a multiple of step.
+func onStep(v, step float64) bool {
+	if step <= 0 {
+		return true
+	}
+	n := v / step
+	return math.Abs(n-math.Round(n)) < 1e-6
+}
+
+func filterFailure(name string) *apiError {
+	return reject(http.StatusBadRequest, -1013, "Filter failure: %s", name)
+}
+
+// parseOrder reads and checks an order of the query.
+func (e *Exchange) parseOrder(q url.Values) (*order, *apiError) {
+	s, ok := e.symbols[q.Get("symbol")]
+	if !ok {
+		return nil, reject(http.StatusBadRequest, -1121, "Invalid symbol.")
+	}
+	o := &order{Order: binance.Order{
+		Symbol:        s.Symbol,
+		OrderListID:   -1,
+		ClientOrderID: q.Get("newClientOrderId"),
+		Side:          binance.OrderSide(q.Get("side")),
+		Type:          binance.OrderType(q.Get("type")),
+		TimeInForce:   q.Get("timeInForce"),
+		Status:        binance.StatusNew,
+	}}
+	if o.Side != binance.Buy && o.Side != binance.Sell {
+		return nil, reject(http.StatusBadRequest, -1117, "Invalid side.")
+	}
+	price := e.prices[s.Symbol]
+	if price <= 0 {
+		return nil, reject(http.StatusBadRequest, -2010, "Market is closed.")
+	}
+	var err error
+	switch o.Type {
+	case binance.Market:
+		if v := q.Get("quoteOrderQty"); v != "" {
+			quote, _ := strconv.ParseFloat(v, 64)
+			o.Qty = quote / price
+			if s.StepSize > 0 {
+				o.Qty = math.Floor(o.Qty/s.StepSize+1e-9) * s.StepSize
+			}
+		} else if o.Qty, err = strconv.ParseFloat(q.Get("quantity"), 64); err != nil {
+			return nil, reject(http.StatusBadRequest, -1102, "Mandatory parameter 'quantity' was not sent, was empty/null, or malformed.")
+		}
+	case binance.Limit, binance.LimitMaker, binance.StopLossLimit, binance.TakeProfitLimit:
+		if o.Qty, err = strconv.ParseFloat(q.Get("quantity"), 64); err != nil {
+			return nil, reject(http.StatusBadRequest, -1102, "Mandatory parameter 'quantity' was not sent, was empty/null, or malformed.")
+		}
+		if o.Price, err = strconv.ParseFloat(q.Get("price"), 64); err != nil {
+			return nil, reject(http.StatusBadRequest, -1102, "Mandatory parameter 'price' was not sent, was empty/null, or malformed.")
+		}
+		if o.Type != binance.LimitMaker && o.TimeInForce == "" {
+			return nil, reject(http.StatusBadRequest, -1102, "Mandatory parameter 'timeInForce' was not sent, was empty/null, or malformed.")
+		}
+		if o.Type == binance.StopLossLimit || o.Type == binance.TakeProfitLimit {
+			if o.StopPrice, err = strconv.ParseFloat(q.Get("stopPrice"), 64); err != nil {
+				return nil, reject(http.StatusBadRequest, -1102, "Mandatory parameter 'stopPrice' was not sent, was empty/null, or malformed.")
+			}
+			if !onStep(o.StopPrice, s.TickSize) {
+				return nil, filterFailure("PRICE_FILTER")
+			}
+		}
+		if !onStep(o.Price, s.TickSize) {
+			return nil, filterFailure("PRICE_FILTER")
+		}
+		price = o.Price
+	default:
+		return nil, reject(http.StatusBadRequest, -1116, "Invalid orderType.")
+	}
+	if o.Qty < s.MinQty || !onStep(o.Qty, s.StepSize) {
+		return nil, filterFailure("LOT_SIZE")
+	}
+	if o.Qty*price < s.MinNotional {
+		return nil, filterFailure("NOTIONAL")
+	}
+	return o, nil
+}
+
+// hold locks the funds of a resting order, failing when they are short.
+func (e *Exchange) hold(o *order) *apiError {
+	s := e.symbols[o.Symbol]
+	o.lockAsset, o.lock = s.BaseAsset, o.Qty
+	if o.Side == binance.Buy {
+		o.lockAsset, o.lock = s.QuoteAsset, o.Qty*o.Price
+	}
+	if e.balances[o.lockAsset]-e.locked[o.lockAsset] < o.lock-1e-9 {
+		return reject(http.StatusBadRequest, -2010, "Account has insufficient balance for requested action.")
+	}
+	e.locked[o.lockAsset] += o.lock
+	return nil
+}
+
+func (e *Exchange) add(o *order) {
+	e.nextID++
+	o.OrderID = e.nextID
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = fmt.Sprintf("mock%d", o.OrderID)
+	}
+	o.Time = millis(time.Now())
+	o.UpdateTime, o.TransactTime = o.Time, o.Time
+	e.orders[o.OrderID] = o
+}
+
+func (e *Exchange) newOrder(q url.Values) (interface{}, *apiError) {
+	o, err := e.parseOrder(q)
+	if err != nil {
+		return nil, err
+	}
+	price := e.prices[o.Symbol]
+	if o.Type == binance.LimitMaker && crosses(o.Side, o.Price, price) {
+		return nil, reject(http.StatusBadRequest, -2010, "Order would immediately match and take.")
+	}
+	if o.Type == binance.Market {
+		s := e.symbols[o.Symbol]
+		asset, need := s.BaseAsset, o.Qty
+		if o.Side == binance.Buy {
+			asset, need = s.QuoteAsset, o.Qty*price
+		}
+		if e.balances[asset]-e.locked[asset] < need-1e-9 {
+			return nil, reject(http.StatusBadRequest, -2010, "Account has insufficient balance for requested action.")
+		}
+	} else if err := e.hold(o); err != nil {
+		return nil, err
+	}
+	e.add(o)
+	e.report(o, "NEW", nil)
+	e.match(o, price)
+	return o.Order, nil
+}
+
+func (e *Exchange) newOCO(q url.Values) (interface{}, *apiError) {
+	limit, stop := url.Values{}, url.Values{}
+	for k, v := range q {
+		limit[k], stop[k] = v, v
+	}
+	limit.Set("type", string(binance.LimitMaker))
+	stop.Set("type", string(binance.StopLossLimit))
+	stop.Set("price", q.Get("stopLimitPrice"))
+	stop.Set("timeInForce", q.Get("stopLimitTimeInForce"))
+	lo, err := e.parseOrder(limit)
+	if err != nil {
+		return nil, err
+	}
+	so, err := e.parseOrder(stop)
+	if err != nil {
+		return nil, err
+	}
+	price := e.prices[lo.Symbol]
+	// the limit above the market and the stop below to sell, the other way
+	// around to buy
+	if lo.Side == binance.Sell && !(lo.Price > price && so.StopPrice < price) ||
+		lo.Side == binance.Buy && !(lo.Price < price && so.StopPrice > price) {
+		return nil, reject(http.StatusBadRequest, -1013, "The relationship of the prices for the orders is not correct.")
+	}
+	// Both legs sell or buy the same funds, so only one holds them.
+	if err := e.hold(lo); err != nil {
+		return nil, err
+	}
+	e.nextID++
+	list := e.nextID
+	lo.OrderListID, so.OrderListID = list, list
+	e.add(lo)
+	e.add(so)
+	e.lists[list] = []int64{so.OrderID, lo.OrderID}
+	e.report(so, "NEW", nil)
+	e.report(lo, "NEW", nil)
+	return e.orderList(list, lo.Symbol), nil
+}
+
+func (e *Exchange) orderList(id int64, symbol string) *binance.OrderList {
+	res := &binance.OrderList{
+		OrderListID:     id,
+		ContingencyType: "OCO",
+		ListStatusType:  "EXEC_STARTED",
+		ListOrderStatus: "EXECUTING",
+		TransactionTime: millis(time.Now()),
+		Symbol:          symbol,
+	}
+	for _, oid := range e.lists[id] {
+		o := e.orders[oid]
+		if o.Status.Final() {
+			res.ListStatusType, res.ListOrderStatus = "ALL_DONE", "ALL_DONE"
+		}
+		res.Orders = append(res.Orders, struct {
+			Symbol        string `json:"symbol"`
+			OrderID       int64  `json:"orderId"`
+			ClientOrderID string `json:"clientOrderId"`
+		}{o.Symbol, o.OrderID, o.ClientOrderID})
+		res.OrderReports = append(res.OrderReports, o.Order)
+	}
+	return res
+}
+
+func (e *Exchange) find(q url.Values) (*order, *apiError) {
+	if id, err := strconv.ParseInt(q.Get("orderId"), 10, 64); err == nil {
+		if o, ok := e.orders[id]; ok && o.Symbol == q.Get("symbol") {
+			return o, nil
+		}
+	} else if client := q.Get("origClientOrderId"); client != "" {
+		for _, o := range e.orders {
+			if o.ClientOrderID == client && o.Symbol == q.Get("symbol") {
+				return o, nil
+			}
+		}
+	}
+	return nil, reject(http.StatusBadRequest, -2013, "Order does not exist.")
+}
+
+// crosses tells whether a limit order of side at limit trades with the
+// market at price.
+func crosses(side binance.OrderSide, limit, price float64) bool {
+	if side == binance.Buy {
+		return price <= limit
+	}
+	return price >= limit
+}
+
+// match fills o if the market at price reaches it.
+func (e *Exchange) match(o *order, price float64) {
+	switch o.Type {
+	case binance.Market:
+		e.fill(o, price)
+		return
+	case binance.StopLossLimit, binance.TakeProfitLimit:
+		if !o.triggered {
+			// stop losses trigger as the price moves against the order, take
+			// profits as it moves for it
+			against := o.Side == binance.Sell && price <= o.StopPrice || o.Side == binance.Buy && price >= o.StopPrice
+			if against != (o.Type == binance.StopLossLimit) {
+				return
+			}
+			o.triggered = true
+		}
+	}
+	if crosses(o.Side, o.Price, price) {
+		// at the limit, or better when the market went through it
+		e.fill(o, price)
+	}
+}
+
+// fill trades all of o at price, charging the fee on the asset received,
+// and cancels the other leg of its OCO.
+func (e *Exchange) fill(o *order, price float64) {
+	s := e.symbols[o.Symbol]
+	e.locked[o.lockAsset] -= o.lock
+	o.lock = 0
+	quote := o.Qty * price
+	e.trades++
+	f := binance.Fill{Price: price, Qty: o.Qty, TradeID: e.trades}
+	if o.Side == binance.Buy {
+		f.Commission, f.CommissionAsset = o.Qty*e.Fee, s.BaseAsset
+		e.balances[s.QuoteAsset] -= quote
+		e.balances[s.BaseAsset] += o.Qty - f.Commission
+	} else {
+		f.Commission, f.CommissionAsset = quote*e.Fee, s.QuoteAsset
+		e.balances[s.BaseAsset] -= o.Qty
+		e.balances[s.QuoteAsset] += quote - f.Commission
+	}
+	o.Fills = append(o.Fills, f)
+	o.ExecutedQty, o.QuoteQty = o.Qty, quote
+	o.Status = binance.StatusFilled
+	o.UpdateTime = millis(time.Now())
+	e.report(o, "TRADE", &f)
+	if o.OrderListID != -1 {
+		e.cancelList(o.OrderListID)
+	}
+}
+
+func (e *Exchange) cancel(o *order) {
+	if o.Status.Final() {
+		return
+	}
+	e.locked[o.lockAsset] -= o.lock
+	o.lock = 0
+	o.Status = binance.StatusCanceled
+	o.UpdateTime = millis(time.Now())
+	e.report(o, "CANCELED", nil)
+}
+
+// cancelList cancels the legs of an OCO still open. The funds of the list
+// are held by one leg, released by whichever cancel gets to it.
+func (e *Exchange) cancelList(id int64) {
+	for _, oid := range e.lists[id] {
+		e.cancel(e.orders[oid])
+	}
+}
+
+// report sends an execution of o to the user data streams.
+func (e *Exchange) report(o *order, execType string, f *binance.Fill) {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	msg := map[string]interface{}{
+		"e": "executionReport",
+		"E": millis(time.Now()),
+		"s": o.Symbol,
+		"c": o.ClientOrderID,
+		"S": o.Side,
+		"o": o.Type,
+		"f": o.TimeInForce,
+		"q": format(o.Qty),
+		"p": format(o.Price),
+		"P": format(o.StopPrice),
+		"g": o.OrderListID,
+		"C": "",
+		"x": execType,
+		"X": o.Status,
+		"r": "NONE",
+		"i": o.OrderID,
+		"l": "0",
+		"z": format(o.ExecutedQty),
+		"L": "0",
+		"n": "0",
+		"N": nil,
+		"T": o.UpdateTime,
+		"t": -1,
+		"Z": format(o.QuoteQty),
+	}
+	if f != nil {
+		msg["l"], msg["L"], msg["n"], msg["N"], msg["t"] = format(f.Qty), format(f.Price), format(f.Commission), f.CommissionAsset, f.TradeID
+	}
+	if execType == "CANCELED" {
+		msg["c"], msg["C"] = fmt.Sprintf("cancel%d", o.OrderID), o.ClientOrderID
+	}
+	b, _ := json.Marshal(msg)
+	for key, conns := range e.streams {
+		open := conns[:0]
+		for _, c := range conns {
+			c.SetWriteDeadline(time.Now().Add(time.Second))
+			if c.WriteMessage(websocket.TextMessage, b) == nil {
+				open = append(open, c)
+			} else {
+				c.Close()
+			}
+		}
+		e.streams[key] = open
+	}
+}
+
+// serveStream upgrades /ws/<listen key> to a user data stream.
+func (e *Exchange) serveStream(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/ws/")
+	conn, err := e.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	e.streams[key] = append(e.streams[key], conn)
+	e.mu.Unlock()
+	// reads only to notice the close
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}()
+}
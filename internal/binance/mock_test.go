@@ -0,0 +1,146 @@
+package binance_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/binance/mock"
+)
+
+var btcusdt = binance.Symbol{
+	Symbol:      "BTCUSDT",
+	BaseAsset:   "BTC",
+	QuoteAsset:  "USDT",
+	TickSize:    0.01,
+	StepSize:    0.00001,
+	MinQty:      0.00001,
+	MinNotional: 5,
+}
+
+func newExchange(t *testing.T) (*mock.Exchange, *binance.Client) {
+	ex := mock.New("key", "secret", btcusdt)
+	t.Cleanup(ex.Close)
+	ex.Deposit("USDT", 1000)
+	ex.SetPrice("BTCUSDT", 30000)
+	c := binance.New(ex.URL(), nil)
+	c.APIKey, c.SecretKey = "key", "secret"
+	return ex, c
+}
+
+func apiCode(err error) int {
+	if e, ok := err.(*binance.APIError); ok {
+		return e.Code
+	}
+	return 0
+}
+
+func TestSignature(t *testing.T) {
+	_, c := newExchange(t)
+	c.SecretKey = "wrong"
+	if _, err := c.Balances(context.Background()); apiCode(err) != -1022 {
+		t.Fatalf("wrong secret: got %v, want -1022", err)
+	}
+	c.SecretKey = "secret"
+	if _, err := c.Balances(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecvWindow(t *testing.T) {
+	ex, _ := newExchange(t)
+	get := func(sent time.Time, window int64) int {
+		q := url.Values{}
+		q.Set("timestamp", strconv.FormatInt(sent.UnixNano()/int64(time.Millisecond), 10))
+		q.Set("recvWindow", strconv.FormatInt(window, 10))
+		query := q.Encode()
+		req, _ := http.NewRequest(http.MethodGet, ex.URL()+"/api/v3/account?"+query+"&signature="+binance.Sign("secret", query), nil)
+		req.Header.Set("X-MBX-APIKEY", "key")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	stale := time.Now().Add(-10 * time.Second)
+	if status := get(stale, 5000); status != http.StatusBadRequest {
+		t.Errorf("10s old request with a 5s window: got status %d", status)
+	}
+	if status := get(stale, 60000); status != http.StatusOK {
+		t.Errorf("10s old request with a 60s window: got status %d", status)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	_, c := newExchange(t)
+	ctx := context.Background()
+	if got := btcusdt.RoundQty(0.123456789); got != 0.12345 {
+		t.Errorf("RoundQty: got %v", got)
+	}
+	if got := btcusdt.RoundQty(0.3); got != 0.3 {
+		t.Errorf("RoundQty of a multiple: got %v", got)
+	}
+	if got := btcusdt.RoundPrice(29000.126); got != 29000.13 {
+		t.Errorf("RoundPrice: got %v", got)
+	}
+	// the mock turns down quantities and prices off the filters
+	o, err := c.PlaceOrder(ctx, btcusdt, binance.OrderRequest{Symbol: "BTCUSDT", Side: binance.Buy, Type: binance.Limit, Qty: 0.0012345678, Price: 29000.1234})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Qty != 0.00123 || o.Price != 29000.12 || o.Status != binance.StatusNew {
+		t.Errorf("limit order: got %v at %v, %s", o.Qty, o.Price, o.Status)
+	}
+	if _, err := c.PlaceOrder(ctx, btcusdt, binance.OrderRequest{Symbol: "BTCUSDT", Side: binance.Buy, Type: binance.Market, Qty: 0.000001}); err == nil {
+		t.Error("order below the minimum quantity went through")
+	}
+}
+
+func TestOCO(t *testing.T) {
+	ex, c := newExchange(t)
+	ctx := context.Background()
+	ex.Deposit("BTC", 0.01)
+	list, err := c.PlaceOCO(ctx, btcusdt, binance.OCORequest{
+		Symbol:         "BTCUSDT",
+		Side:           binance.Sell,
+		Qty:            0.01,
+		Price:          31000,
+		StopPrice:      29000,
+		StopLimitPrice: 28900,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Orders) != 2 {
+		t.Fatalf("got %d orders in the list", len(list.Orders))
+	}
+	if _, locked := ex.Balance("BTC"); locked != 0.01 {
+		t.Errorf("locked %v BTC, want 0.01", locked)
+	}
+	ex.SetPrice("BTCUSDT", 31050)
+	statuses := make(map[binance.OrderType]binance.OrderStatus)
+	for _, o := range list.Orders {
+		res, err := c.QueryOrder(ctx, "BTCUSDT", o.OrderID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses[res.Type] = res.Status
+	}
+	if statuses[binance.LimitMaker] != binance.StatusFilled || statuses[binance.StopLossLimit] != binance.StatusCanceled {
+		t.Errorf("after the target: got %v", statuses)
+	}
+	if free, locked := ex.Balance("BTC"); free != 0 || locked != 0 {
+		t.Errorf("BTC left: %v free, %v locked", free, locked)
+	}
+	if _, err := c.CancelOCO(ctx, "BTCUSDT", list.OrderListID); err == nil {
+		t.Error("canceled an OCO done with")
+	}
+	if _, err := c.QueryOrder(ctx, "BTCUSDT", 1000, ""); !binance.UnknownOrder(err) {
+		t.Errorf("unknown order: got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type OrderSide string
+
+const (
+	Buy  OrderSide = "BUY"
+	Sell OrderSide = "SELL"
+)
+
+type OrderType string
+
+const (
+	Market          OrderType = "MARKET"
+	Limit           OrderType = "LIMIT"
+	LimitMaker      OrderType = "LIMIT_MAKER"
+	StopLossLimit   OrderType = "STOP_LOSS_LIMIT"
+	TakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
+)
+
+type OrderStatus string
+
+const (
+	StatusNew             OrderStatus = "NEW"
+	StatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	StatusFilled          OrderStatus = "FILLED"
+	StatusCanceled        OrderStatus = "CANCELED"
+	StatusRejected        OrderStatus = "REJECTED"
+	StatusExpired         OrderStatus = "EXPIRED"
+)
+
+// Final tells whether an order in the status is done with.
+func (s OrderStatus) Final() bool {
+	switch s {
+	case StatusNew, StatusPartiallyFilled, "PENDING_NEW", "PENDING_CANCEL":
+		return false
+	}
+	return true
+}
+
+// OrderRequest is a new order. Quantities and prices are rounded to the
+// filters of the symbol when sent.
+type OrderRequest struct {
+	Symbol string
+	Side   OrderSide
+	Type   OrderType
+	Qty    float64
+	// amount of the quote asset a market order spends or receives, instead
+	// of Qty
+	QuoteQty  float64
+	Price     float64
+	StopPrice float64
+	// GTC when empty for the orders needing one
+	TimeInForce   string
+	ClientOrderID string
+}
+
+// Fill is a trade of an order.
+type Fill struct {
+	Price           float64 `json:"price,string"`
+	Qty             float64 `json:"qty,string"`
+	Commission      float64 `json:"commission,string"`
+	CommissionAsset string  `json:"commissionAsset"`
+	TradeID         int64   `json:"tradeId"`
+}
+
+// Order is an order as Binance answers it.
+type Order struct {
+	Symbol        string      `json:"symbol"`
+	OrderID       int64       `json:"orderId"`
+	OrderListID   int64       `json:"orderListId"`
+	ClientOrderID string      `json:"clientOrderId"`
+	Price         float64     `json:"price,string"`
+	Qty           float64     `json:"origQty,string"`
+	ExecutedQty   float64     `json:"executedQty,string"`
+	QuoteQty      float64     `json:"cummulativeQuoteQty,string"`
+	Status        OrderStatus `json:"status"`
+	TimeInForce   string      `json:"timeInForce"`
+	Type          OrderType   `json:"type"`
+	Side          OrderSide   `json:"side"`
+	StopPrice     float64     `json:"stopPrice,string"`
+	// creation time, only in the answers to queries
+	Time       int64 `json:"time,omitempty"`
+	UpdateTime int64 `json:"updateTime,omitempty"`
+	// time of a new order
+	TransactTime int64 `json:"transactTime,omitempty"`
+	// trades filling a new order right away
+	Fills []Fill `json:"fills,omitempty"`
+}
+
+// AvgPrice is the average price of what filled, zero when nothing did.
+func (o *Order) AvgPrice() float64 {
+	if o.ExecutedQty == 0 {
+		return 0
+	}
+	return o.QuoteQty / o.ExecutedQty
+}
+
+func (r OrderRequest) params(s Symbol) (url.Values, error) {
+	p := url.Values{}
+	p.Set("symbol", r.Symbol)
+	p.Set("side", string(r.Side))
+	p.Set("type", string(r.Type))
+	p.Set("newOrderRespType", "FULL")
+	if r.ClientOrderID != "" {
+		p.Set("newClientOrderId", r.ClientOrderID)
+	}
+	price := s.RoundPrice(r.Price)
+	if r.Type != Market {
+		if price <= 0 {
+			return nil, errors.New("binance: the order needs a price")
+		}
+		p.Set("price", s.FormatPrice(price))
+		tif := r.TimeInForce
+		if tif == "" {
+			tif = "GTC"
+		}
+		if r.Type != LimitMaker {
+			p.Set("timeInForce", tif)
+		}
+	}
+	if r.Type == StopLossLimit || r.Type == TakeProfitLimit {
+		if r.StopPrice <= 0 {
+			return nil, errors.New("binance: the order needs a stop price")
+		}
+		p.Set("stopPrice", s.FormatPrice(s.RoundPrice(r.StopPrice)))
+	}
+	if r.Type == Market && r.QuoteQty > 0 {
+		p.Set("quoteOrderQty", formatStep(roundStep(r.QuoteQty, 1e-8, math.Floor), 1e-8))
+		return p, nil
+	}
+	qty := s.RoundQty(r.Qty)
+	if err := s.CheckQty(qty, price); err != nil {
+		return nil, err
+	}
+	p.Set("quantity", s.FormatQty(qty))
+	return p, nil
+}
+
+// PlaceOrder sends a new order, its quantity and prices rounded to the
+// filters of s.
+func (c *Client) PlaceOrder(ctx context.Context, s Symbol, r OrderRequest) (*Order, error) {
+	params, err := r.params(s)
+	if err != nil {
+		return nil, err
+	}
+	return c.order(ctx, http.MethodPost, params, 1)
+}
+
+// QueryOrder returns an order by its id, or by its client id when zero.
+func (c *Client) QueryOrder(ctx context.Context, symbol string, id int64, clientID string) (*Order, error) {
+	return c.order(ctx, http.MethodGet, orderParams(symbol, id, clientID), 4)
+}
+
+// CancelOrder cancels an order by its id, or by its client id when zero.
+func (c *Client) CancelOrder(ctx context.Context, symbol string, id int64, clientID string) (*Order, error) {
+	return c.order(ctx, http.MethodDelete, orderParams(symbol, id, clientID), 1)
+}
+
+func orderParams(symbol string, id int64, clientID string) url.Values {
+	p := url.Values{}
+	p.Set("symbol", symbol)
+	if id != 0 {
+		p.Set("orderId", strconv.FormatInt(id, 10))
+	} else {
+		p.Set("origClientOrderId", clientID)
+	}
+	return p
+}
+
+func (c *Client) order(ctx context.Context, method string, params url.Values, weight int) (*Order, error) {
+	body, err := c.Signed(ctx, method, "/api/v3/order", params, weight)
+	if err != nil {
+		return nil, err
+	}
+	res := new(Order)
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// OpenOrders returns the orders of symbol still open.
+func (c *Client) OpenOrders(ctx context.Context, symbol string) ([]Order, error) {
+	p := url.Values{}
+	p.Set("symbol", symbol)
+	body, err := c.Signed(ctx, http.MethodGet, "/api/v3/openOrders", p, 6)
+	if err != nil {
+		return nil, err
+	}
+	var res []Order
+	err = json.Unmarshal(body, &res)
+	return res, err
+}
+
+// WaitOrder polls an order every interval until it is done with.
+func (c *Client) WaitOrder(ctx context.Context, symbol string, id int64, every time.Duration) (*Order, error) {
+	for {
+		o, err := c.QueryOrder(ctx, symbol, id, "")
+		if err != nil {
+			return nil, err
+		}
+		if o.Status.Final() {
+			return o, nil
+		}
+		if err := sleep(ctx, every); err != nil {
+			return o, err
+		}
+	}
+}
+
+// OCORequest is a pair of orders where one filling cancels the other: a
+// limit order at Price, above the market to sell or below to buy, and a
+// stop-limit order triggered at StopPrice, at StopLimitPrice.
+type OCORequest struct {
+	Symbol         string
+	Side           OrderSide
+	Qty            float64
+	Price          float64
+	StopPrice      float64
+	StopLimitPrice float64
+	ClientListID   string
+}
+
+// OrderList is an OCO as Binance answers it.
+type OrderList struct {
+	OrderListID       int64  `json:"orderListId"`
+	ContingencyType   string `json:"contingencyType"`
+	ListStatusType    string `json:"listStatusType"`
+	ListOrderStatus   string `json:"listOrderStatus"`
+	ListClientOrderID string `json:"listClientOrderId"`
+	TransactionTime   int64  `json:"transactionTime"`
+	Symbol            string `json:"symbol"`
+	Orders            []struct {
+		Symbol        string `json:"symbol"`
+		OrderID       int64  `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+	} `json:"orders"`
+	OrderReports []Order `json:"orderReports"`
+}
+
+// PlaceOCO sends an OCO, its quantity and prices rounded to the filters of
+// s.
+func (c *Client) PlaceOCO(ctx context.Context, s Symbol, r OCORequest) (*OrderList, error) {
+	qty := s.RoundQty(r.Qty)
+	if err := s.CheckQty(qty, s.RoundPrice(r.StopLimitPrice)); err != nil {
+		return nil, err
+	}
+	p := url.Values{}
+	p.Set("symbol", r.Symbol)
+	p.Set("side", string(r.Side))
+	p.Set("quantity", s.FormatQty(qty))
+	p.Set("price", s.FormatPrice(s.RoundPrice(r.Price)))
+	p.Set("stopPrice", s.FormatPrice(s.RoundPrice(r.StopPrice)))
+	p.Set("stopLimitPrice", s.FormatPrice(s.RoundPrice(r.StopLimitPrice)))
+	p.Set("stopLimitTimeInForce", "GTC")
+	p.Set("newOrderRespType", "FULL")
+	if r.ClientListID != "" {
+		p.Set("listClientOrderId", r.ClientListID)
+	}
+	return c.orderList(ctx, http.MethodPost, "/api/v3/order/oco", p)
+}
+
+// CancelOCO cancels both orders of an OCO.
+func (c *Client) CancelOCO(ctx context.Context, symbol string, listID int64) (*OrderList, error) {
+	p := url.Values{}
+	p.Set("symbol", symbol)
+	p.Set("orderListId", strconv.FormatInt(listID, 10))
+	return c.orderList(ctx, http.MethodDelete, "/api/v3/orderList", p)
+}
+
+func (c *Client) orderList(ctx context.Context, method, path string, params url.Values) (*OrderList, error) {
+	body, err := c.Signed(ctx, method, path, params, 1)
+	if err != nil {
+		return nil, err
+	}
+	res := new(OrderList)
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Balance is the holding of an asset, Locked being held by open orders.
+type Balance struct {
+	Asset  string  `json:"asset"`
+	Free   float64 `json:"free,string"`
+	Locked float64 `json:"locked,string"`
+}
+
+// Balances returns the holdings of the account.
+func (c *Client) Balances(ctx context.Context) ([]Balance, error) {
+	body, err := c.Signed(ctx, http.MethodGet, "/api/v3/account", nil, 20)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Balances []Balance `json:"balances"`
+	}
+	err = json.Unmarshal(body, &res)
+	return res.Balances, err
+}
@@ -0,0 +1,239 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Binance expires a listen key not kept alive within an hour.
+	defaultKeepAlive = 30 * time.Minute
+	// The server pings every 3 minutes; missing a few means the link is dead.
+	userReadTimeout  = 10 * time.Minute
+	userWriteTimeout = 10 * time.Second
+)
+
+// ListenKey opens a user data stream and returns its key.
+func (c *Client) ListenKey(ctx context.Context) (string, error) {
+	body, err := c.Keyed(ctx, http.MethodPost, "/api/v3/userDataStream", nil, 2)
+	if err != nil {
+		return "", err
+	}
+	var res struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", err
+	}
+	if res.ListenKey == "" {
+		return "", errors.New("binance: no listen key")
+	}
+	return res.ListenKey, nil
+}
+
+// KeepAlive extends the life of a listen key by an hour.
+func (c *Client) KeepAlive(ctx context.Context, key string) error {
+	_, err := c.Keyed(ctx, http.MethodPut, "/api/v3/userDataStream", url.Values{"listenKey": {key}}, 2)
+	return err
+}
+
+// CloseListenKey closes a user data stream.
+func (c *Client) CloseListenKey(ctx context.Context, key string) error {
+	_, err := c.Keyed(ctx, http.MethodDelete, "/api/v3/userDataStream", url.Values{"listenKey": {key}}, 2)
+	return err
+}
+
+// Execution is an update of an order from the user data stream: placed,
+// traded, canceled, expired or rejected.
+type Execution struct {
+	Symbol        string    `json:"symbol"`
+	OrderID       int64     `json:"order_id"`
+	OrderListID   int64     `json:"order_list_id"`
+	ClientOrderID string    `json:"client_order_id"`
+	Side          OrderSide `json:"side"`
+	Type          OrderType `json:"type"`
+	// NEW, TRADE, CANCELED, EXPIRED, REJECTED...
+	ExecType     string      `json:"exec_type"`
+	Status       OrderStatus `json:"status"`
+	RejectReason string      `json:"reject_reason,omitempty"`
+	Qty          float64     `json:"qty"`
+	Price        float64     `json:"price"`
+	StopPrice    float64     `json:"stop_price"`
+	// the trade of a TRADE execution
+	LastQty         float64 `json:"last_qty"`
+	LastPrice       float64 `json:"last_price"`
+	Commission      float64 `json:"commission"`
+	CommissionAsset string  `json:"commission_asset"`
+	TradeID         int64   `json:"trade_id"`
+	// filled so far
+	CumQty   float64 `json:"cum_qty"`
+	CumQuote float64 `json:"cum_quote"`
+	Time     int64   `json:"time"`
+}
+
+// encoding/json matches keys case-insensitively, so the Binance fields
+// differing only in case from the ones used must be declared to be skipped.
+type executionReport struct {
+	Event         string      `json:"e"`
+	EventTime     int64       `json:"E"`
+	Symbol        string      `json:"s"`
+	Side          OrderSide   `json:"S"`
+	ClientOrderID string      `json:"c"`
+	OrigClientID  string      `json:"C"`
+	Type          OrderType   `json:"o"`
+	Created       int64       `json:"O"`
+	TimeInForce   string      `json:"f"`
+	IcebergQty    json.Number `json:"F"`
+	Qty           json.Number `json:"q"`
+	QuoteOrderQty json.Number `json:"Q"`
+	Price         json.Number `json:"p"`
+	StopPrice     json.Number `json:"P"`
+	ExecType      string      `json:"x"`
+	Status        OrderStatus `json:"X"`
+	RejectReason  string      `json:"r"`
+	OrderID       int64       `json:"i"`
+	Ignore        int64       `json:"I"`
+	LastQty       json.Number `json:"l"`
+	LastPrice     json.Number `json:"L"`
+	Commission    json.Number `json:"n"`
+	// null until a trade
+	CommissionAsset *string     `json:"N"`
+	TradeID         int64       `json:"t"`
+	TransactTime    int64       `json:"T"`
+	OnBook          bool        `json:"w"`
+	WorkingTime     int64       `json:"W"`
+	Maker           bool        `json:"m"`
+	IgnoreM         bool        `json:"M"`
+	CumQty          json.Number `json:"z"`
+	CumQuote        json.Number `json:"Z"`
+	OrderListID     int64       `json:"g"`
+	PreventedMatch  int64       `json:"v"`
+	STPMode         string      `json:"V"`
+}
+
+func (r *executionReport) execution() Execution {
+	e := Execution{
+		Symbol:        r.Symbol,
+		OrderID:       r.OrderID,
+		OrderListID:   r.OrderListID,
+		ClientOrderID: r.ClientOrderID,
+		Side:          r.Side,
+		Type:          r.Type,
+		ExecType:      r.ExecType,
+		Status:        r.Status,
+		RejectReason:  r.RejectReason,
+		Qty:           parseFloat(string(r.Qty)),
+		Price:         parseFloat(string(r.Price)),
+		StopPrice:     parseFloat(string(r.StopPrice)),
+		LastQty:       parseFloat(string(r.LastQty)),
+		LastPrice:     parseFloat(string(r.LastPrice)),
+		Commission:    parseFloat(string(r.Commission)),
+		TradeID:       r.TradeID,
+		CumQty:        parseFloat(string(r.CumQty)),
+		CumQuote:      parseFloat(string(r.CumQuote)),
+		Time:          r.TransactTime,
+	}
+	// A canceled order carries its own id in C and the one of the cancel
+	// request in c.
+	if r.ExecType == "CANCELED" && r.OrigClientID != "" {
+		e.ClientOrderID = r.OrigClientID
+	}
+	if r.CommissionAsset != nil {
+		e.CommissionAsset = *r.CommissionAsset
+	}
+	return e
+}
+
+// UserStream reads the executions of the account off its user data stream.
+type UserStream struct {
+	Client *Client
+	// websocket endpoint the listen key is appended to, e.g.
+	// wss://stream.binance.com:9443/ws
+	URL       string
+	Dialer    *websocket.Dialer
+	KeepAlive time.Duration
+}
+
+func NewUserStream(client *Client, url string) *UserStream {
+	return &UserStream{
+		Client:    client,
+		URL:       url,
+		Dialer:    websocket.DefaultDialer,
+		KeepAlive: defaultKeepAlive,
+	}
+}
+
+// Run opens a stream and calls fn on every execution, in order, until the
+// stream fails or ctx is done. Executions made while no stream is open are
+// lost, so the caller polls the orders it waits on after reconnecting.
+func (s *UserStream) Run(ctx context.Context, fn func(Execution)) error {
+	key, err := s.Client.ListenKey(ctx)
+	if err != nil {
+		return err
+	}
+	conn, _, err := s.Dialer.DialContext(ctx, strings.TrimSuffix(s.URL, "/")+"/"+key, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Closing the connection is the only way to interrupt a blocked read.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		t := time.NewTicker(s.KeepAlive)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				s.Client.CloseListenKey(context.Background(), key)
+				return
+			case <-t.C:
+				if err := s.Client.KeepAlive(ctx, key); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(userReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(userReadTimeout))
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(userWriteTimeout))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		conn.SetReadDeadline(time.Now().Add(userReadTimeout))
+		var r executionReport
+		if err := json.Unmarshal(msg, &r); err != nil {
+			continue
+		}
+		switch r.Event {
+		case "executionReport":
+			fn(r.execution())
+		case "listenKeyExpired":
+			return errors.New("binance: listen key expired")
+		}
+	}
+}
@@ -16,6 +16,27 @@ func Create() error {
 }
 
 func setKeys() {
-	viper.Set("binance-without-endtime", "https://api.binance.com/api/v3/klines?symbol=%s&interval=%s&limit=1000")
-	viper.Set("binance-with-endtime", "https://api.binance.com/api/v3/klines?symbol=%s&interval=%s&endTime=%d&limit=1000")
+	viper.SetDefault("base.port", 8088)
+	viper.SetDefault("binance-rest", "https://api.binance.com")
+	viper.SetDefault("binance-stream", "wss://stream.binance.com:9443/stream")
+	viper.SetDefault("universe.quote", []string{"USDT"})
+	viper.SetDefault("universe.include", []string{"BTCUSDT", "ETHUSDT", "XRPUSDT"})
+	viper.SetDefault("universe.top", 20)
+	viper.SetDefault("universe.refresh", "1h")
+	viper.SetDefault("bars", []string{})
+	viper.SetDefault("derived", []string{})
+	viper.SetDefault("backtest.cash", 10000)
+	viper.SetDefault("backtest.size", "10%")
+	viper.SetDefault("paper.enabled", false)
+	viper.SetDefault("paper.cash", 10000)
+	viper.SetDefault("execution.enabled", false)
+	viper.SetDefault("execution.stream", "wss://stream.binance.com:9443/ws")
+	viper.SetDefault("execution.recv-window", 5000)
+	viper.SetDefault("execution.stop-limit-gap", 0.002)
+	viper.SetDefault("execution.poll", "30s")
+	viper.SetDefault("execution.mock-cash", 10000)
+	viper.SetDefault("risk.interval", "1h")
+	viper.SetDefault("risk.correlation-window", 100)
+	viper.SetDefault("hub.buffer", 256)
+	viper.SetDefault("hub.indicators", []string{})
 }
@@ -0,0 +1,605 @@
+// Package execution trades the signals on a Binance spot account. Buy
+// signals spend a fixed amount of the quote asset at the market and protect
+// the position with a stop loss and a take profit, as an OCO when both are
+// set; sell signals cancel the protection and sell what the position holds.
+// Fills are read off the user data stream, and the protective orders are
+// polled in case the stream missed them.
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/risk"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// signals waiting for the orders of the previous ones
+	queueSize  = 100
+	maxFills   = 1000
+	maxBackoff = time.Minute
+)
+
+type Config struct {
+	// amount of the quote asset each buy spends
+	Size float64
+	// distances of the protective orders from the entry as fractions of
+	// it, e.g. 0.02 for 2%, none when zero
+	StopLoss   float64
+	TakeProfit float64
+	// how far past its stop the limit of the stop loss sits, as a fraction
+	// of it
+	StopLimitGap float64
+	// how often the protective orders are polled
+	Poll time.Duration
+}
+
+// Position is what a buy holds until sold.
+type Position struct {
+	Symbol string  `json:"symbol"`
+	Qty    float64 `json:"qty"`
+	Entry  float64 `json:"entry"`
+	Opened int64   `json:"opened"`
+	Rule   string  `json:"rule"`
+	// protective orders, and the OCO holding them
+	Orders []int64 `json:"orders,omitempty"`
+	ListID int64   `json:"list_id,omitempty"`
+}
+
+// State is what the executor keeps across restarts.
+type State struct {
+	Positions map[string]*Position `json:"positions"`
+	Fills     []binance.Execution  `json:"fills"`
+}
+
+type Executor struct {
+	Client *binance.Client
+	Stream *binance.UserStream
+	Config
+	*logging.Logger
+	// called after executing a signal or polling, to save the state
+	OnChange func()
+	// sizes and limits the new positions when set, the positions valued at
+	// the last prices
+	Gate   *risk.Gate
+	Prices func(symbol string) (float64, bool)
+
+	signals chan rules.Signal
+
+	mu        sync.Mutex
+	symbols   map[string]binance.Symbol
+	positions map[string]*Position
+	fills     []binance.Execution
+	changed   bool
+}
+
+func New(client *binance.Client, stream *binance.UserStream, cfg Config, logger *logging.Logger) *Executor {
+	return &Executor{
+		Client:    client,
+		Stream:    stream,
+		Config:    cfg,
+		Logger:    logger,
+		signals:   make(chan rules.Signal, queueSize),
+		symbols:   make(map[string]binance.Symbol),
+		positions: make(map[string]*Position),
+	}
+}
+
+// FromConfig makes the executor of the execution section of the config. The
+// keys are read from BINANCE_API_KEY and BINANCE_SECRET_KEY when not set
+// there.
+func FromConfig(logger *logging.Logger) (*Executor, error) {
+	rest := viper.GetString("execution.rest")
+	if rest == "" {
+		rest = viper.GetString("binance-rest")
+	}
+	client := binance.New(rest, nil)
+	client.APIKey = viper.GetString("execution.api-key")
+	if client.APIKey == "" {
+		client.APIKey = os.Getenv("BINANCE_API_KEY")
+	}
+	client.SecretKey = viper.GetString("execution.secret-key")
+	if client.SecretKey == "" {
+		client.SecretKey = os.Getenv("BINANCE_SECRET_KEY")
+	}
+	if w := viper.GetInt64("execution.recv-window"); w > 0 {
+		client.RecvWindow = w
+	}
+	cfg := Config{
+		Size:         viper.GetFloat64("execution.size"),
+		StopLoss:     viper.GetFloat64("execution.stop-loss"),
+		TakeProfit:   viper.GetFloat64("execution.take-profit"),
+		StopLimitGap: viper.GetFloat64("execution.stop-limit-gap"),
+		Poll:         viper.GetDuration("execution.poll"),
+	}
+	if cfg.Size <= 0 {
+		return nil, errors.New("execution size must be positive")
+	}
+	stream := binance.NewUserStream(client, viper.GetString("execution.stream"))
+	return New(client, stream, cfg, logger), nil
+}
+
+// Handle queues a signal for execution. Signals are dropped while the queue
+// is full rather than holding up the rules.
+func (x *Executor) Handle(s rules.Signal) {
+	select {
+	case x.signals <- s:
+	default:
+		x.Debugf("execution: queue full, dropped signal %s on %s", s.Rule, s.Symbol)
+	}
+}
+
+// Run executes the queued signals and follows the fills until ctx is done.
+func (x *Executor) Run(ctx context.Context) error {
+	if x.Client.APIKey == "" || x.Client.SecretKey == "" {
+		return errors.New("execution needs the api and secret keys")
+	}
+	if err := x.Client.SyncTime(ctx); err != nil {
+		x.WithError(err).Debug("execution: failed syncing the server time")
+	}
+	go x.follow(ctx)
+	poll := x.Poll
+	if poll <= 0 {
+		poll = 30 * time.Second
+	}
+	t := time.NewTicker(poll)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s := <-x.signals:
+			x.execute(ctx, s)
+		case <-t.C:
+			x.poll(ctx)
+		}
+		if x.OnChange != nil {
+			x.OnChange()
+		}
+	}
+}
+
+// follow reads the user data stream, reopening it with a growing delay when
+// it fails.
+func (x *Executor) follow(ctx context.Context) {
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := x.Stream.Run(ctx, x.onExecution)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > maxBackoff {
+			backoff = time.Second
+		}
+		x.WithError(err).Debugf("execution: user data stream ended, reconnecting in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// symbol returns the filters of a symbol, loading the exchange info the
+// first time.
+func (x *Executor) symbol(ctx context.Context, name string) (binance.Symbol, error) {
+	x.mu.Lock()
+	s, ok := x.symbols[name]
+	x.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+	symbols, err := x.Client.ExchangeInfo(ctx)
+	if err != nil {
+		return s, err
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, s := range symbols {
+		x.symbols[s.Symbol] = s
+	}
+	if s, ok = x.symbols[name]; !ok {
+		return s, fmt.Errorf("unknown symbol %s", name)
+	}
+	return s, nil
+}
+
+// clientID returns a new client order id, within the 36 characters Binance
+// allows.
+func clientID() string {
+	return "cs-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func (x *Executor) execute(ctx context.Context, s rules.Signal) {
+	var err error
+	switch s.Direction {
+	case rules.Buy:
+		err = x.buy(ctx, s)
+	case rules.Sell:
+		err = x.sell(ctx, s)
+	default:
+		return
+	}
+	if err != nil {
+		x.WithError(err).Debugf("execution: failed executing %s %s on %s", s.Rule, s.Direction, s.Symbol)
+	}
+}
+
+func (x *Executor) buy(ctx context.Context, s rules.Signal) error {
+	x.mu.Lock()
+	_, open := x.positions[s.Symbol]
+	x.mu.Unlock()
+	if open {
+		return nil
+	}
+	sym, err := x.symbol(ctx, s.Symbol)
+	if err != nil {
+		return err
+	}
+	size := x.Size
+	if x.Gate != nil {
+		p, price, err := x.portfolio(ctx, sym, s)
+		if err != nil {
+			return err
+		}
+		if size = x.Gate.Check(p, s, price, size) * price; size <= 0 {
+			return nil
+		}
+	}
+	o, err := x.Client.PlaceOrder(ctx, sym, binance.OrderRequest{
+		Symbol:        s.Symbol,
+		Side:          binance.Buy,
+		Type:          binance.Market,
+		QuoteQty:      size,
+		ClientOrderID: clientID(),
+	})
+	if err != nil {
+		return err
+	}
+	// The commission is taken from what was bought unless paid otherwise.
+	qty := o.ExecutedQty
+	for _, f := range o.Fills {
+		if f.CommissionAsset == sym.BaseAsset {
+			qty -= f.Commission
+		}
+	}
+	if qty <= 0 {
+		return fmt.Errorf("market buy %d filled nothing", o.OrderID)
+	}
+	pos := Position{Symbol: s.Symbol, Qty: qty, Entry: o.AvgPrice(), Opened: o.TransactTime, Rule: s.Rule}
+	x.Debugf("execution: bought %v %s at %v on %s", qty, s.Symbol, pos.Entry, s.Rule)
+	x.mu.Lock()
+	stored := pos
+	x.positions[s.Symbol] = &stored
+	x.changed = true
+	x.mu.Unlock()
+	// The protection is recorded once placed, and found filled by the next
+	// poll if it fills before.
+	if err := x.protect(ctx, sym, &pos); err != nil {
+		x.WithError(err).Debugf("execution: failed protecting %s", s.Symbol)
+	}
+	return nil
+}
+
+// portfolio returns the account as the risk gate sees it and the price of
+// the symbol of s. The equity is the quote asset of the symbol held and the
+// positions, taken to be quoted in it too.
+func (x *Executor) portfolio(ctx context.Context, sym binance.Symbol, s rules.Signal) (risk.Portfolio, float64, error) {
+	price := func(symbol string, fallback float64) float64 {
+		if x.Prices != nil {
+			if p, ok := x.Prices(symbol); ok {
+				return p
+			}
+		}
+		return fallback
+	}
+	balances, err := x.Client.Balances(ctx)
+	if err != nil {
+		return risk.Portfolio{}, 0, err
+	}
+	var res risk.Portfolio
+	for _, b := range balances {
+		if b.Asset == sym.QuoteAsset {
+			res.Equity = b.Free + b.Locked
+		}
+	}
+	for _, pos := range x.Positions() {
+		value := pos.Qty * price(pos.Symbol, pos.Entry)
+		res.Equity += value
+		res.Positions = append(res.Positions, risk.Exposure{Symbol: pos.Symbol, Value: value})
+	}
+	return res, price(s.Symbol, s.Price), nil
+}
+
+// protect places the stop loss and take profit of pos, a copy of the
+// position recorded.
+func (x *Executor) protect(ctx context.Context, sym binance.Symbol, pos *Position) error {
+	stop := pos.Entry * (1 - x.StopLoss)
+	target := pos.Entry * (1 + x.TakeProfit)
+	defer func() {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+		if cur, ok := x.positions[pos.Symbol]; ok {
+			cur.Orders, cur.ListID = pos.Orders, pos.ListID
+			x.changed = true
+		}
+	}()
+	switch {
+	case x.StopLoss > 0 && x.TakeProfit > 0:
+		list, err := x.Client.PlaceOCO(ctx, sym, binance.OCORequest{
+			Symbol:         pos.Symbol,
+			Side:           binance.Sell,
+			Qty:            pos.Qty,
+			Price:          target,
+			StopPrice:      stop,
+			StopLimitPrice: stop * (1 - x.StopLimitGap),
+			ClientListID:   clientID(),
+		})
+		if err != nil {
+			return err
+		}
+		pos.ListID = list.OrderListID
+		for _, o := range list.Orders {
+			pos.Orders = append(pos.Orders, o.OrderID)
+		}
+	case x.StopLoss > 0:
+		o, err := x.Client.PlaceOrder(ctx, sym, binance.OrderRequest{
+			Symbol:        pos.Symbol,
+			Side:          binance.Sell,
+			Type:          binance.StopLossLimit,
+			Qty:           pos.Qty,
+			StopPrice:     stop,
+			Price:         stop * (1 - x.StopLimitGap),
+			ClientOrderID: clientID(),
+		})
+		if err != nil {
+			return err
+		}
+		pos.Orders = []int64{o.OrderID}
+	case x.TakeProfit > 0:
+		o, err := x.Client.PlaceOrder(ctx, sym, binance.OrderRequest{
+			Symbol:        pos.Symbol,
+			Side:          binance.Sell,
+			Type:          binance.Limit,
+			Qty:           pos.Qty,
+			Price:         target,
+			ClientOrderID: clientID(),
+		})
+		if err != nil {
+			return err
+		}
+		pos.Orders = []int64{o.OrderID}
+	}
+	return nil
+}
+
+func (x *Executor) sell(ctx context.Context, s rules.Signal) error {
+	x.mu.Lock()
+	cur, ok := x.positions[s.Symbol]
+	var pos Position
+	if ok {
+		pos = *cur
+	}
+	x.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	sym, err := x.symbol(ctx, s.Symbol)
+	if err != nil {
+		return err
+	}
+	if err := x.unprotect(ctx, &pos); err != nil {
+		return err
+	}
+	if x.closed(pos.Symbol) {
+		return nil
+	}
+	qty := sym.RoundQty(pos.Qty)
+	if qty < sym.MinQty || qty <= 0 {
+		x.Debugf("execution: %v %s left is too little to sell", pos.Qty, s.Symbol)
+		x.drop(pos.Symbol)
+		return nil
+	}
+	o, err := x.Client.PlaceOrder(ctx, sym, binance.OrderRequest{
+		Symbol:        s.Symbol,
+		Side:          binance.Sell,
+		Type:          binance.Market,
+		Qty:           qty,
+		ClientOrderID: clientID(),
+	})
+	if err != nil {
+		return err
+	}
+	x.Debugf("execution: sold %v %s at %v on %s", o.ExecutedQty, s.Symbol, o.AvgPrice(), s.Rule)
+	x.drop(pos.Symbol)
+	return nil
+}
+
+// unprotect cancels the protective orders of pos. Those done with already
+// are checked instead, one having filled closing the position, and those
+// the exchange does not know are taken to be gone.
+func (x *Executor) unprotect(ctx context.Context, pos *Position) error {
+	if pos.ListID != 0 {
+		if _, err := x.Client.CancelOCO(ctx, pos.Symbol, pos.ListID); err == nil {
+			return nil
+		}
+	}
+	for _, id := range pos.Orders {
+		if _, err := x.Client.CancelOrder(ctx, pos.Symbol, id, ""); err == nil {
+			continue
+		}
+		o, err := x.Client.QueryOrder(ctx, pos.Symbol, id, "")
+		if binance.UnknownOrder(err) {
+			x.Debugf("execution: %s order %d is unknown, taking it as gone", pos.Symbol, id)
+			x.forget(pos.Symbol, id)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if o.Status == binance.StatusFilled {
+			x.drop(pos.Symbol)
+		}
+	}
+	return nil
+}
+
+// closed tells whether the position of symbol is gone.
+func (x *Executor) closed(symbol string) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	_, ok := x.positions[symbol]
+	return !ok
+}
+
+func (x *Executor) drop(symbol string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.positions[symbol]; ok {
+		delete(x.positions, symbol)
+		x.changed = true
+	}
+}
+
+// forget drops a protective order the exchange does not know from the
+// position of symbol.
+func (x *Executor) forget(symbol string, id int64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	pos := x.owner(symbol, id)
+	if pos == nil {
+		return
+	}
+	var orders []int64
+	for _, o := range pos.Orders {
+		if o != id {
+			orders = append(orders, o)
+		}
+	}
+	pos.Orders = orders
+	if len(orders) == 0 {
+		pos.ListID = 0
+	}
+	x.changed = true
+}
+
+// owner returns the position an order protects.
+func (x *Executor) owner(symbol string, id int64) *Position {
+	pos, ok := x.positions[symbol]
+	if !ok {
+		return nil
+	}
+	for _, o := range pos.Orders {
+		if o == id {
+			return pos
+		}
+	}
+	return nil
+}
+
+// onExecution records the trades of the account and closes the positions
+// whose protective order filled.
+func (x *Executor) onExecution(e binance.Execution) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if e.ExecType == "TRADE" {
+		x.fills = append(x.fills, e)
+		if len(x.fills) > maxFills {
+			x.fills = append([]binance.Execution(nil), x.fills[len(x.fills)-maxFills:]...)
+		}
+		x.changed = true
+	}
+	if e.Status == binance.StatusFilled && x.owner(e.Symbol, e.OrderID) != nil {
+		x.Debugf("execution: %s %s order %d filled at %v", e.Symbol, e.Type, e.OrderID, e.LastPrice)
+		delete(x.positions, e.Symbol)
+		x.changed = true
+	}
+}
+
+// poll checks the protective orders, in case their fills were missed.
+func (x *Executor) poll(ctx context.Context) {
+	x.mu.Lock()
+	var open []Position
+	for _, pos := range x.positions {
+		open = append(open, *pos)
+	}
+	x.mu.Unlock()
+	for _, pos := range open {
+		for _, id := range pos.Orders {
+			o, err := x.Client.QueryOrder(ctx, pos.Symbol, id, "")
+			if binance.UnknownOrder(err) {
+				x.Debugf("execution: %s order %d is unknown, no longer polling it", pos.Symbol, id)
+				x.forget(pos.Symbol, id)
+				continue
+			}
+			if err != nil {
+				x.WithError(err).Debugf("execution: failed polling %s order %d", pos.Symbol, id)
+				continue
+			}
+			if o.Status == binance.StatusFilled {
+				x.Debugf("execution: %s %s order %d found filled at %v", o.Symbol, o.Type, id, o.AvgPrice())
+				x.drop(pos.Symbol)
+				break
+			}
+		}
+	}
+}
+
+// Positions returns the positions open.
+func (x *Executor) Positions() []Position {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	res := make([]Position, 0, len(x.positions))
+	for _, pos := range x.positions {
+		res = append(res, *pos)
+	}
+	return res
+}
+
+// Fills returns the trades of the account seen on the user data stream,
+// oldest first.
+func (x *Executor) Fills() []binance.Execution {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]binance.Execution(nil), x.fills...)
+}
+
+// Snapshot returns the state and whether it changed since the last
+// snapshot.
+func (x *Executor) Snapshot() (State, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	changed := x.changed
+	x.changed = false
+	res := State{Positions: make(map[string]*Position, len(x.positions)), Fills: append([]binance.Execution(nil), x.fills...)}
+	for symbol, pos := range x.positions {
+		pos := *pos
+		res.Positions[symbol] = &pos
+	}
+	return res, changed
+}
+
+// Restore brings back a state saved before.
+func (x *Executor) Restore(s State) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for symbol, pos := range s.Positions {
+		pos := *pos
+		x.positions[symbol] = &pos
+	}
+	x.fills = append([]binance.Execution(nil), s.Fills...)
+}
@@ -0,0 +1,132 @@
+package execution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/binance/mock"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/rules"
+
+	"github.com/sirupsen/logrus"
+)
+
+var btcusdt = binance.Symbol{
+	Symbol:      "BTCUSDT",
+	BaseAsset:   "BTC",
+	QuoteAsset:  "USDT",
+	TickSize:    0.01,
+	StepSize:    0.00001,
+	MinQty:      0.00001,
+	MinNotional: 5,
+}
+
+// start runs an executor against a mock exchange until the test ends. The
+// poll is slow enough for the fills to come off the user data stream.
+func start(t *testing.T) (*mock.Exchange, *Executor) {
+	ex := mock.New("key", "secret", btcusdt)
+	t.Cleanup(ex.Close)
+	ex.Deposit("USDT", 1000)
+	ex.SetPrice("BTCUSDT", 30000)
+	c := binance.New(ex.URL(), nil)
+	c.APIKey, c.SecretKey = "key", "secret"
+	cfg := Config{Size: 100, StopLoss: 0.02, TakeProfit: 0.04, StopLimitGap: 0.002, Poll: time.Hour}
+	x := New(c, binance.NewUserStream(c, ex.StreamURL()), cfg, &logging.Logger{Logger: logrus.New()})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go x.Run(ctx)
+	return ex, x
+}
+
+func eventually(t *testing.T, what string, ok func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if ok() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestBuyProtects(t *testing.T) {
+	_, x := start(t)
+	x.Handle(rules.Signal{Rule: "r", Symbol: "BTCUSDT", Direction: rules.Buy})
+	eventually(t, "the protection", func() bool {
+		pos := x.Positions()
+		return len(pos) == 1 && len(pos[0].Orders) == 2
+	})
+	pos := x.Positions()[0]
+	if pos.Entry != 30000 || pos.ListID == 0 {
+		t.Errorf("got %+v", pos)
+	}
+	// the commission is taken off the BTC bought
+	if want := btcusdt.RoundQty(100./30000) * (1 - mock.DefaultFee); pos.Qty < want-1e-12 || pos.Qty > want+1e-12 {
+		t.Errorf("holding %v, want %v", pos.Qty, want)
+	}
+}
+
+func TestStreamFillCloses(t *testing.T) {
+	ex, x := start(t)
+	// the stream is up before the fill it has to report
+	time.Sleep(100 * time.Millisecond)
+	x.Handle(rules.Signal{Rule: "r", Symbol: "BTCUSDT", Direction: rules.Buy})
+	eventually(t, "the protection", func() bool {
+		pos := x.Positions()
+		return len(pos) == 1 && len(pos[0].Orders) == 2
+	})
+	// the target sits 4% above the entry
+	ex.SetPrice("BTCUSDT", 31300)
+	eventually(t, "the position to close", func() bool { return len(x.Positions()) == 0 })
+	var trades []binance.Execution
+	for _, f := range x.Fills() {
+		if f.ExecType == "TRADE" {
+			trades = append(trades, f)
+		}
+	}
+	if len(trades) != 2 || trades[1].Type != binance.LimitMaker || trades[1].Side != binance.Sell {
+		t.Errorf("got trades %+v", trades)
+	}
+	if free, locked := ex.Balance("BTC"); free > btcusdt.StepSize || locked != 0 {
+		t.Errorf("BTC left: %v free, %v locked", free, locked)
+	}
+}
+
+func TestSellCancelsProtection(t *testing.T) {
+	ex, x := start(t)
+	x.Handle(rules.Signal{Rule: "r", Symbol: "BTCUSDT", Direction: rules.Buy})
+	eventually(t, "the protection", func() bool {
+		pos := x.Positions()
+		return len(pos) == 1 && len(pos[0].Orders) == 2
+	})
+	x.Handle(rules.Signal{Rule: "r", Symbol: "BTCUSDT", Direction: rules.Sell})
+	eventually(t, "the position to close", func() bool { return len(x.Positions()) == 0 })
+	if open, err := x.Client.OpenOrders(context.Background(), "BTCUSDT"); err != nil || len(open) != 0 {
+		t.Errorf("open orders left: %v %v", open, err)
+	}
+	if _, locked := ex.Balance("BTC"); locked != 0 {
+		t.Errorf("%v BTC still locked", locked)
+	}
+}
+
+func TestUnknownOrdersAreGone(t *testing.T) {
+	ex, x := start(t)
+	ex.Deposit("BTC", 0.01)
+	// a position restored with orders the exchange never saw
+	x.Restore(State{Positions: map[string]*Position{
+		"BTCUSDT": {Symbol: "BTCUSDT", Qty: 0.01, Entry: 29000, Orders: []int64{1000, 1001}, ListID: 999},
+	}})
+	x.poll(context.Background())
+	if pos := x.Positions(); len(pos) != 1 || len(pos[0].Orders) != 0 || pos[0].ListID != 0 {
+		t.Fatalf("after polling: got %+v", pos)
+	}
+	x.Restore(State{Positions: map[string]*Position{
+		"BTCUSDT": {Symbol: "BTCUSDT", Qty: 0.01, Entry: 29000, Orders: []int64{1000}},
+	}})
+	x.Handle(rules.Signal{Rule: "r", Symbol: "BTCUSDT", Direction: rules.Sell})
+	eventually(t, "the position to be sold", func() bool { return len(x.Positions()) == 0 })
+	if free, _ := ex.Balance("BTC"); free != 0 {
+		t.Errorf("%v BTC left", free)
+	}
+}
@@ -0,0 +1,219 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "cryptoapi/internal/protofiles/websocket"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+)
+
+// Client is a hub client, mostly for tests and tools. The frames pushed are
+// read with Next; the requests wait for their answer.
+type Client struct {
+	// JSON is set when the hub agreed to speak JSON.
+	JSON bool
+
+	ws     *websocket.Conn
+	frames chan *pb.Publish
+	// answers to the requests, one request being sent at a time
+	answers chan *pb.Publish
+	// closed by Close, then by the reader once it stopped
+	quit chan struct{}
+	once sync.Once
+	done chan struct{}
+	err  error
+
+	mu sync.Mutex
+}
+
+// Dial connects to the hub at url, e.g. ws://localhost:8088/v1/ws, asking
+// for JSON when json is set and for protobuf otherwise.
+func Dial(ctx context.Context, url string, json bool) (*Client, error) {
+	protocol := Protobuf
+	if json {
+		protocol = JSON
+	}
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = []string{protocol}
+	ws, _, err := dialer.DialContext(ctx, url, http.Header{})
+	if err != nil {
+		return nil, err
+	}
+	if ws.Subprotocol() != protocol {
+		ws.Close()
+		return nil, fmt.Errorf("hub does not speak %s", protocol)
+	}
+	c := &Client{
+		JSON:    json,
+		ws:      ws,
+		frames:  make(chan *pb.Publish, DefaultBuffer),
+		answers: make(chan *pb.Publish, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go c.read()
+	return c, nil
+}
+
+func (c *Client) read() {
+	defer close(c.done)
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			c.err = err
+			return
+		}
+		var f pb.Publish
+		if c.JSON {
+			var raw frame
+			if err = json.Unmarshal(data, &raw); err == nil {
+				f = pb.Publish{Type: raw.Type, Sender: raw.Sender, Message: raw.Message}
+			}
+		} else {
+			err = proto.Unmarshal(data, &f)
+		}
+		if err != nil {
+			c.err = fmt.Errorf("bad frame: %v", err)
+			c.ws.Close()
+			return
+		}
+		ch := c.frames
+		if f.Type >= TypeSubscribe {
+			ch = c.answers
+		}
+		// a slow reader of the frames slows the hub down, which drops or
+		// disconnects as it would for any client
+		select {
+		case ch <- &f:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// Next returns the next frame pushed, its Message holding the JSON payload.
+func (c *Client) Next(ctx context.Context) (*pb.Publish, error) {
+	select {
+	case f := <-c.frames:
+		return f, nil
+	case <-c.done:
+		return nil, c.closed()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// closed returns why the connection closed, once it has.
+func (c *Client) closed() error {
+	if c.err == nil {
+		return errors.New("hub: connection closed")
+	}
+	return c.err
+}
+
+// Subscribe subscribes to channels, returning once the hub applied them.
+func (c *Client) Subscribe(ctx context.Context, channels ...string) error {
+	for _, ch := range channels {
+		if err := c.request(ctx, TypeSubscribe, ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) Unsubscribe(ctx context.Context, channels ...string) error {
+	for _, ch := range channels {
+		if err := c.request(ctx, TypeUnsubscribe, ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mute silences the frames of a type, 0 for any, from sender about target,
+// either being empty for any.
+func (c *Client) Mute(ctx context.Context, m *pb.Mute) error {
+	return c.request(ctx, TypeMute, m)
+}
+
+func (c *Client) Unmute(ctx context.Context, m *pb.Mute) error {
+	return c.request(ctx, TypeUnmute, m)
+}
+
+// request sends a request and waits for its answer. Message is a channel
+// or a Mute.
+func (c *Client) request(ctx context.Context, t uint32, message interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var data []byte
+	var err error
+	if c.JSON {
+		var raw []byte
+		if raw, err = json.Marshal(message); err == nil {
+			data, err = json.Marshal(frame{Type: t, Message: raw})
+		}
+	} else {
+		req := &pb.Publish{Type: t}
+		switch m := message.(type) {
+		case string:
+			req.Message = []byte(m)
+		case proto.Message:
+			req.Message, err = proto.Marshal(m)
+		}
+		if err == nil {
+			data, err = proto.Marshal(req)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	kind := websocket.BinaryMessage
+	if c.JSON {
+		kind = websocket.TextMessage
+	}
+	if err := c.ws.WriteMessage(kind, data); err != nil {
+		return err
+	}
+	select {
+	case f := <-c.answers:
+		if f.Type == TypeError {
+			return fmt.Errorf("hub: %s", c.text(f.Message))
+		}
+		if f.Type != t {
+			return fmt.Errorf("hub: answered %d to %d", f.Type, t)
+		}
+		return nil
+	case <-c.done:
+		return c.closed()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) text(message []byte) string {
+	var s string
+	if c.JSON && json.Unmarshal(message, &s) == nil {
+		return s
+	}
+	return string(message)
+}
+
+// Close hangs up.
+func (c *Client) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.quit)
+		err = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+		c.ws.Close()
+		<-c.done
+	})
+	return err
+}
@@ -0,0 +1,534 @@
+// Package hub pushes signals, candles and indicator values to websocket
+// clients as Publish frames of internal/protofiles/websocket. Clients
+// subscribe to channels such as signals:BTCUSDT:1h, silence senders or
+// targets with Mute frames, and speak protobuf over binary frames or, when
+// they negotiate it, JSON over text frames.
+//
+// Every frame either way is a Publish. The requests of the clients are:
+//
+//	TypeSubscribe, TypeUnsubscribe  Message is a channel
+//	TypeMute, TypeUnmute            Message is a Mute
+//
+// and are acknowledged by echoing them once applied, or answered with a
+// TypeError frame whose Message is the reason. The data frames carry their
+// payload as JSON in Message.
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/logging"
+	pb "cryptoapi/internal/protofiles/websocket"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+)
+
+// Publish types, the data pushed first, then the requests and the error.
+const (
+	TypeSignal uint32 = iota + 1
+	TypeCandle
+	TypeIndicator
+)
+
+const (
+	TypeSubscribe uint32 = iota + 10
+	TypeUnsubscribe
+	TypeMute
+	TypeUnmute
+	TypeError uint32 = 20
+)
+
+// Channel kinds, a channel being kind:symbol:interval where the symbol and
+// interval can be * for any, e.g. candles:*:1m. A bare kind is kind:*:*.
+const (
+	Signals    = "signals"
+	Candles    = "candles"
+	Indicators = "indicators"
+)
+
+// Subprotocols a client can ask for, protobuf when it asks for none.
+// Browsers can also ask for ?format=json.
+const (
+	Protobuf = "cryptosignals.protobuf"
+	JSON     = "cryptosignals.json"
+)
+
+const (
+	DefaultBuffer = 256
+	writeTimeout  = 10 * time.Second
+	// clients are pinged more often than they time out
+	readTimeout  = time.Minute
+	pingInterval = readTimeout * 9 / 10
+	maxRequest   = 4096
+	// most subscriptions and mutes of a connection
+	maxEntries = 256
+)
+
+// Message is a frame to push to the clients subscribed to its channel.
+type Message struct {
+	Type uint32
+	// concrete channel, e.g. signals:BTCUSDT:1h
+	Channel string
+	// who produced it, e.g. the rule of a signal
+	Sender string
+	// what it is about, the symbol, muted independently of the sender
+	Target string
+	// encoded to JSON into the Message of the frame
+	Payload interface{}
+	// dropped for a client too slow to keep up rather than disconnecting
+	// it, for updates the next one supersedes
+	Droppable bool
+}
+
+// frame is a Publish as a JSON text frame.
+type frame struct {
+	Type    uint32          `json:"type"`
+	Sender  string          `json:"sender,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+}
+
+// encoded is a message in both formats, encoded once for all clients.
+type encoded struct {
+	msg      Message
+	protobuf []byte
+	json     []byte
+}
+
+// encodeFrame encodes a Publish in one format. In JSON its message must be
+// JSON itself.
+func encodeFrame(t uint32, sender string, message []byte, jsonFormat bool) ([]byte, error) {
+	if !jsonFormat {
+		return proto.Marshal(&pb.Publish{Type: t, Sender: sender, Message: message})
+	}
+	if len(message) == 0 {
+		message = nil
+	}
+	return json.Marshal(frame{Type: t, Sender: sender, Message: message})
+}
+
+func encode(msg Message, payload []byte) (*encoded, error) {
+	e := &encoded{msg: msg}
+	var err error
+	if e.protobuf, err = encodeFrame(msg.Type, msg.Sender, payload, false); err != nil {
+		return nil, err
+	}
+	if e.json, err = encodeFrame(msg.Type, msg.Sender, payload, true); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// pattern is a parsed channel subscription.
+type pattern struct {
+	kind, symbol, interval string
+}
+
+// parseChannel parses a channel, a subscription when it has wildcards.
+func parseChannel(channel string) (pattern, error) {
+	// virtual intervals have colons too, e.g. signals:BTCUSDT:ha:1h
+	parts := strings.SplitN(channel, ":", 3)
+	switch len(parts) {
+	case 1:
+		parts = append(parts, "*", "*")
+	case 2:
+		return pattern{}, fmt.Errorf("bad channel %q", channel)
+	}
+	switch parts[0] {
+	case Signals, Candles, Indicators:
+	default:
+		return pattern{}, fmt.Errorf("unknown channel kind %q", parts[0])
+	}
+	if parts[1] == "" || parts[2] == "" {
+		return pattern{}, fmt.Errorf("bad channel %q", channel)
+	}
+	return pattern{kind: parts[0], symbol: strings.ToUpper(parts[1]), interval: parts[2]}, nil
+}
+
+// Channel is the channel of the messages of a kind on a ticker.
+func Channel(kind, symbol, interval string) string {
+	return kind + ":" + symbol + ":" + interval
+}
+
+func (p pattern) String() string {
+	return Channel(p.kind, p.symbol, p.interval)
+}
+
+func (p pattern) matches(c pattern) bool {
+	return p.kind == c.kind &&
+		(p.symbol == "*" || p.symbol == c.symbol) &&
+		(p.interval == "*" || p.interval == c.interval)
+}
+
+// silences tells whether a mute applies to a message.
+func silences(m *pb.Mute, msg *Message) bool {
+	return (m.Type == 0 || m.Type == msg.Type) &&
+		(m.Sender == "" || m.Sender == msg.Sender) &&
+		(m.Target == "" || m.Target == msg.Target)
+}
+
+// Hub keeps the connections of the clients and pushes them the messages of
+// the channels they subscribed to.
+type Hub struct {
+	// frames queued per connection; a client lagging further behind loses
+	// the droppable messages and is disconnected on the others
+	Buffer   int
+	Upgrader websocket.Upgrader
+	*logging.Logger
+
+	mu    sync.RWMutex
+	conns map[*conn]bool
+}
+
+func New(buffer int, logger *logging.Logger) *Hub {
+	if buffer < 1 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{
+		Buffer: buffer,
+		Upgrader: websocket.Upgrader{
+			Subprotocols: []string{Protobuf, JSON},
+			CheckOrigin:  func(*http.Request) bool { return true },
+		},
+		Logger: logger,
+		conns:  make(map[*conn]bool),
+	}
+}
+
+// FromConfig reads the hub section of the config.
+func FromConfig(logger *logging.Logger) *Hub {
+	return New(viper.GetInt("hub.buffer"), logger)
+}
+
+// conn is a client connection. Its writer alone writes to ws.
+type conn struct {
+	hub  *Hub
+	ws   *websocket.Conn
+	json bool
+	send chan *encoded
+	// closed to stop the writer, with the close frame to send in reason
+	quit   chan struct{}
+	once   sync.Once
+	reason []byte
+
+	mu      sync.Mutex
+	subs    []pattern
+	mutes   []*pb.Mute
+	dropped int
+}
+
+// ServeHTTP upgrades a request to a websocket client connection.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader answered already
+		return
+	}
+	c := &conn{
+		hub:  h,
+		ws:   ws,
+		json: ws.Subprotocol() == JSON || ws.Subprotocol() == "" && r.URL.Query().Get("format") == "json",
+		send: make(chan *encoded, h.Buffer),
+		quit: make(chan struct{}),
+	}
+	h.mu.Lock()
+	h.conns[c] = true
+	h.mu.Unlock()
+	go c.write()
+	c.read()
+}
+
+// Clients is the number of clients connected.
+func (h *Hub) Clients() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// Subscribed tells whether any client listens to a concrete channel, so the
+// messages nobody reads need not be computed.
+func (h *Hub) Subscribed(channel string) bool {
+	p, err := parseChannel(channel)
+	if err != nil {
+		return false
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.conns {
+		if c.subscribed(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Publish queues msg for every client subscribed to its channel that did not
+// mute it. It never blocks: a client whose queue is full loses the message
+// if it is droppable and is disconnected otherwise.
+func (h *Hub) Publish(msg Message) {
+	p, err := parseChannel(msg.Channel)
+	if err != nil {
+		h.WithError(err).Debug("not publishing to a bad channel")
+		return
+	}
+	var e *encoded
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.conns {
+		if !c.wants(p, &msg) {
+			continue
+		}
+		if e == nil {
+			payload, err := json.Marshal(msg.Payload)
+			if err == nil {
+				e, err = encode(msg, payload)
+			}
+			if err != nil {
+				h.WithError(err).Debugf("failed encoding a message of %s", msg.Channel)
+				return
+			}
+		}
+		c.push(e)
+	}
+}
+
+func (c *conn) subscribed(p pattern) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.subs {
+		if s.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *conn) wants(p pattern, msg *Message) bool {
+	if !c.subscribed(p) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, m := range c.mutes {
+		if silences(m, msg) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *conn) push(e *encoded) {
+	select {
+	case c.send <- e:
+		return
+	default:
+	}
+	if !e.msg.Droppable {
+		c.close(websocket.CloseTryAgainLater, "too slow")
+		return
+	}
+	c.mu.Lock()
+	c.dropped++
+	dropped := c.dropped
+	c.mu.Unlock()
+	if dropped == 1 || dropped%1000 == 0 {
+		c.hub.Debugf("client %s too slow, %d messages dropped", c.ws.RemoteAddr(), dropped)
+	}
+}
+
+// close makes the writer send a close frame and hang up.
+func (c *conn) close(code int, text string) {
+	c.once.Do(func() {
+		c.reason = websocket.FormatCloseMessage(code, text)
+		close(c.quit)
+	})
+}
+
+// reply queues an answer to a request, in the format of the connection
+// alone. Answers are never dropped.
+func (c *conn) reply(t uint32, message []byte) {
+	data, err := encodeFrame(t, "", message, c.json)
+	if err != nil {
+		c.hub.WithError(err).Debug("failed encoding a reply")
+		return
+	}
+	e := &encoded{msg: Message{Type: t}}
+	if c.json {
+		e.json = data
+	} else {
+		e.protobuf = data
+	}
+	c.push(e)
+}
+
+func (c *conn) fail(format string, a ...interface{}) {
+	text := fmt.Sprintf(format, a...)
+	if c.json {
+		b, _ := json.Marshal(text)
+		c.reply(TypeError, b)
+		return
+	}
+	c.reply(TypeError, []byte(text))
+}
+
+func (c *conn) write() {
+	ping := time.NewTicker(pingInterval)
+	defer func() {
+		ping.Stop()
+		c.ws.Close()
+	}()
+	kind := websocket.BinaryMessage
+	if c.json {
+		kind = websocket.TextMessage
+	}
+	for {
+		select {
+		case e := <-c.send:
+			data := e.protobuf
+			if c.json {
+				data = e.json
+			}
+			c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := c.ws.WriteMessage(kind, data); err != nil {
+				c.close(websocket.CloseGoingAway, "")
+				return
+			}
+		case <-ping.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				c.close(websocket.CloseGoingAway, "")
+				return
+			}
+		case <-c.quit:
+			c.ws.WriteControl(websocket.CloseMessage, c.reason, time.Now().Add(writeTimeout))
+			return
+		}
+	}
+}
+
+func (c *conn) read() {
+	defer func() {
+		c.hub.mu.Lock()
+		delete(c.hub.conns, c)
+		c.hub.mu.Unlock()
+		c.close(websocket.CloseNormalClosure, "")
+	}()
+	c.ws.SetReadLimit(maxRequest)
+	c.ws.SetReadDeadline(time.Now().Add(readTimeout))
+	c.ws.SetPongHandler(func(string) error {
+		c.ws.SetReadDeadline(time.Now().Add(readTimeout))
+		return nil
+	})
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(readTimeout))
+		if err := c.handle(data); err != nil {
+			c.fail("%v", err)
+		}
+	}
+}
+
+// handle applies a request and echoes it.
+func (c *conn) handle(data []byte) error {
+	var req pb.Publish
+	if c.json {
+		var f frame
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("bad frame: %v", err)
+		}
+		req = pb.Publish{Type: f.Type, Sender: f.Sender, Message: f.Message}
+	} else if err := proto.Unmarshal(data, &req); err != nil {
+		return fmt.Errorf("bad frame: %v", err)
+	}
+
+	switch req.Type {
+	case TypeSubscribe, TypeUnsubscribe:
+		channel := string(req.Message)
+		if c.json {
+			if err := json.Unmarshal(req.Message, &channel); err != nil {
+				return errors.New("the message of a subscription must be a channel")
+			}
+		}
+		p, err := parseChannel(channel)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		err = c.subscribe(p, req.Type == TypeSubscribe)
+		c.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	case TypeMute, TypeUnmute:
+		var m pb.Mute
+		var err error
+		if c.json {
+			err = json.Unmarshal(req.Message, &m)
+		} else {
+			err = proto.Unmarshal(req.Message, &m)
+		}
+		if err != nil {
+			return errors.New("the message of a mute must be a Mute")
+		}
+		if m.Sender == "" && m.Target == "" {
+			return errors.New("a mute needs a sender or a target")
+		}
+		c.mu.Lock()
+		err = c.mute(&m, req.Type == TypeMute)
+		c.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown request type %d", req.Type)
+	}
+	c.reply(req.Type, req.Message)
+	return nil
+}
+
+func (c *conn) subscribe(p pattern, on bool) error {
+	for i, s := range c.subs {
+		if s == p {
+			if !on {
+				c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			}
+			return nil
+		}
+	}
+	if !on {
+		return nil
+	}
+	if len(c.subs) >= maxEntries {
+		return fmt.Errorf("at most %d subscriptions", maxEntries)
+	}
+	c.subs = append(c.subs, p)
+	return nil
+}
+
+func (c *conn) mute(m *pb.Mute, on bool) error {
+	for i, x := range c.mutes {
+		if x.Type == m.Type && x.Sender == m.Sender && x.Target == m.Target {
+			if !on {
+				c.mutes = append(c.mutes[:i], c.mutes[i+1:]...)
+			}
+			return nil
+		}
+	}
+	if !on {
+		return nil
+	}
+	if len(c.mutes) >= maxEntries {
+		return fmt.Errorf("at most %d mutes", maxEntries)
+	}
+	c.mutes = append(c.mutes, m)
+	return nil
+}
@@ -0,0 +1,226 @@
+package hub
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/logging"
+	pb "cryptoapi/internal/protofiles/websocket"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+// serve starts a hub queuing buffer frames per client and returns its url.
+func serve(t *testing.T, buffer int) (*Hub, string) {
+	h := New(buffer, &logging.Logger{Logger: logrus.New()})
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return h, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func dial(t *testing.T, url string, json bool) *Client {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := Dial(ctx, url, json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func next(t *testing.T, c *Client) *pb.Publish {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	f, err := c.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func signal(rule, symbol string) Message {
+	return Message{
+		Type:    TypeSignal,
+		Channel: Channel(Signals, symbol, "1h"),
+		Sender:  rule,
+		Target:  symbol,
+		Payload: map[string]string{"rule": rule, "symbol": symbol},
+	}
+}
+
+func TestNegotiation(t *testing.T) {
+	h, url := serve(t, 0)
+	ctx := context.Background()
+	for _, json := range []bool{false, true} {
+		c := dial(t, url, json)
+		if c.JSON != json {
+			t.Errorf("asked for json %v, got %v", json, c.JSON)
+		}
+		if err := c.Subscribe(ctx, Signals); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// without a subprotocol, protobuf unless the query asks for json
+	for _, query := range []string{"", "?format=json"} {
+		ws, _, err := websocket.DefaultDialer.Dial(url+query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ws.Close()
+		req, _ := proto.Marshal(&pb.Publish{Type: TypeSubscribe, Message: []byte(Signals)})
+		kind := websocket.BinaryMessage
+		if query != "" {
+			req, kind = []byte(`{"type":10,"message":"signals"}`), websocket.TextMessage
+		}
+		if err := ws.WriteMessage(kind, req); err != nil {
+			t.Fatal(err)
+		}
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+		got, data, err := ws.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != kind {
+			t.Errorf("%q: answered in a frame of kind %d, want %d: %s", query, got, kind, data)
+		}
+	}
+	if n := h.Clients(); n != 4 {
+		t.Errorf("%d clients, want 4", n)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	h, url := serve(t, 0)
+	ctx := context.Background()
+	pbc, jc := dial(t, url, false), dial(t, url, true)
+	if err := pbc.Subscribe(ctx, "signals:btcusdt:1h"); err != nil {
+		t.Fatal(err)
+	}
+	if err := jc.Subscribe(ctx, "candles:*:1h"); err != nil {
+		t.Fatal(err)
+	}
+	for _, channel := range []string{"nope", "signals:x", "signals::1h"} {
+		if err := jc.Subscribe(ctx, channel); err == nil {
+			t.Errorf("subscribed to %q", channel)
+		}
+	}
+	if !h.Subscribed("signals:BTCUSDT:1h") || h.Subscribed("signals:ETHUSDT:1h") || !h.Subscribed("candles:ETHUSDT:1h") {
+		t.Error("wrong subscriptions")
+	}
+
+	// frames come in order, so getting the last one means the ones before
+	// were not sent
+	h.Publish(signal("up", "ETHUSDT"))
+	h.Publish(signal("up", "BTCUSDT"))
+	f := next(t, pbc)
+	if f.Type != TypeSignal || f.Sender != "up" || string(f.Message) != `{"rule":"up","symbol":"BTCUSDT"}` {
+		t.Errorf("protobuf got %v", f)
+	}
+	h.Publish(Message{Type: TypeCandle, Channel: "candles:ETHUSDT:4h", Payload: 1})
+	h.Publish(Message{Type: TypeCandle, Channel: "candles:ETHUSDT:1h", Payload: map[string]float64{"close": 2.5}})
+	if f := next(t, jc); f.Type != TypeCandle || string(f.Message) != `{"close":2.5}` {
+		t.Errorf("json got %v", f)
+	}
+
+	if err := jc.Unsubscribe(ctx, "candles:*:1h"); err != nil {
+		t.Fatal(err)
+	}
+	if h.Subscribed("candles:ETHUSDT:1h") {
+		t.Error("still subscribed")
+	}
+}
+
+func TestMute(t *testing.T) {
+	h, url := serve(t, 0)
+	ctx := context.Background()
+	c := dial(t, url, true)
+	if err := c.Subscribe(ctx, Signals); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Mute(ctx, &pb.Mute{}); err == nil {
+		t.Error("muted without a sender nor a target")
+	}
+	if err := c.Mute(ctx, &pb.Mute{Type: TypeSignal, Sender: "down"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Mute(ctx, &pb.Mute{Target: "ETHUSDT"}); err != nil {
+		t.Fatal(err)
+	}
+	h.Publish(signal("down", "BTCUSDT"))
+	h.Publish(signal("up", "ETHUSDT"))
+	h.Publish(signal("up", "BTCUSDT"))
+	if f := next(t, c); string(f.Message) != `{"rule":"up","symbol":"BTCUSDT"}` {
+		t.Errorf("got %s", f.Message)
+	}
+
+	if err := c.Unmute(ctx, &pb.Mute{Target: "ETHUSDT"}); err != nil {
+		t.Fatal(err)
+	}
+	h.Publish(signal("down", "ETHUSDT"))
+	h.Publish(signal("up", "ETHUSDT"))
+	if f := next(t, c); string(f.Message) != `{"rule":"up","symbol":"ETHUSDT"}` {
+		t.Errorf("after the unmute got %s", f.Message)
+	}
+}
+
+func TestSlowConsumer(t *testing.T) {
+	h, url := serve(t, 4)
+	// a client subscribing and then never reading
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	req, _ := proto.Marshal(&pb.Publish{Type: TypeSubscribe, Message: []byte(Candles)})
+	if err := ws.WriteMessage(websocket.BinaryMessage, req); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); !h.Subscribed("candles:BTCUSDT:1m"); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the subscription")
+		}
+	}
+
+	// big enough for the socket buffers to fill up and the queue behind them
+	update := Message{Type: TypeCandle, Channel: "candles:BTCUSDT:1m", Payload: strings.Repeat("x", 1<<16), Droppable: true}
+	for i := 0; i < 1000; i++ {
+		h.Publish(update)
+	}
+	h.mu.RLock()
+	var dropped int
+	for c := range h.conns {
+		c.mu.Lock()
+		dropped = c.dropped
+		c.mu.Unlock()
+	}
+	h.mu.RUnlock()
+	if dropped == 0 || h.Clients() != 1 {
+		t.Fatalf("%d updates dropped, %d clients", dropped, h.Clients())
+	}
+
+	// a closed candle cannot be dropped
+	closed := update
+	closed.Droppable = false
+	h.Publish(closed)
+	ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		if _, _, err = ws.ReadMessage(); err != nil {
+			break
+		}
+	}
+	if !websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+		t.Errorf("got %v, want a close with %d", err, websocket.CloseTryAgainLater)
+	}
+	for deadline := time.Now().Add(5 * time.Second); h.Clients() > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the slow client is still connected")
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package indicator
+
+import (
+	"math"
+)
+
+// The flow library reads the order flow columns of Binance klines. Like
+// talib, outputs are zero before the lookback; a zero denominator gives NaN.
+
+func flow(name, fullName string, category Category, inputs []Input, options []Option,
+	lookback func(o []float64) int, run func(in [][]float64, o []float64) []float64) *Definition {
+	return &Definition{
+		Name:     name,
+		Library:  "flow",
+		FullName: fullName,
+		Category: category,
+		Inputs:   inputs,
+		Options:  options,
+		Outputs:  single,
+		lookback: lookback,
+		run: func(in [][]float64, o []float64) ([][]float64, error) {
+			return [][]float64{run(in, o)}, nil
+		},
+	}
+}
+
+func periodLookback(o []float64) int {
+	return int(o[0]) - 1
+}
+
+// rollingRatio divides the sums of num and den over the last period values.
+func rollingRatio(num, den []float64, period int) []float64 {
+	res := make([]float64, len(num))
+	var n, d float64
+	for i := range num {
+		n += num[i]
+		d += den[i]
+		if i >= period {
+			n -= num[i-period]
+			d -= den[i-period]
+		}
+		if i >= period-1 {
+			res[i] = divide(n, d)
+		}
+	}
+	return res
+}
+
+func divide(a, b float64) float64 {
+	if b == 0 {
+		return math.NaN()
+	}
+	return a / b
+}
+
+func init() {
+	mustRegister(
+		flow("takerratio", "Taker Buy Ratio", Oscillator, []Input{TakerBuyVolume, Volume}, timePeriod(14, 1),
+			periodLookback,
+			func(in [][]float64, o []float64) []float64 {
+				return rollingRatio(in[0], in[1], int(o[0]))
+			}),
+		flow("cvd", "Cumulative Volume Delta", Oscillator, []Input{TakerBuyVolume, Volume}, nil,
+			none,
+			func(in [][]float64, o []float64) []float64 {
+				res := make([]float64, len(in[0]))
+				var sum float64
+				for i := range res {
+					// taker buys minus taker sells
+					sum += 2*in[0][i] - in[1][i]
+					res[i] = sum
+				}
+				return res
+			}),
+		flow("tradespike", "Trade Count Spike", Oscillator, []Input{Trades}, timePeriod(20, 1),
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) []float64 {
+				// trades of a candle over the average of the period before
+				p := int(o[0])
+				res := make([]float64, len(in[0]))
+				var sum float64
+				for i, v := range in[0] {
+					if i >= p {
+						res[i] = divide(v, sum/float64(p))
+						sum -= in[0][i-p]
+					}
+					sum += v
+				}
+				return res
+			}),
+		flow("qvwap", "Quote Volume Weighted Average Price", Overlay, []Input{QuoteVolume, Volume}, timePeriod(14, 1),
+			periodLookback,
+			func(in [][]float64, o []float64) []float64 {
+				return rollingRatio(in[0], in[1], int(o[0]))
+			}),
+	)
+}
@@ -0,0 +1,94 @@
+package indicator
+
+import (
+	"math"
+
+	"cryptoapi/internal/talib"
+)
+
+// The candlestick lookbacks are the pattern length plus the longest average
+// period among the candle settings each pattern compares against.
+
+var patternOut = []string{"integer"}
+
+func pattern(name, fullName string, lookback int, fn func(o, h, l, c []float64) []int) *Definition {
+	return ta(name, fullName, Pattern, ohlc, nil, patternOut,
+		func(o []float64) int { return lookback },
+		func(in [][]float64, o []float64) [][]float64 {
+			return [][]float64{ints(fn(in[0], in[1], in[2], in[3]))}
+		})
+}
+
+func penetration(name, fullName string, def float64, lookback int, fn func(o, h, l, c []float64, p float64) []int) *Definition {
+	return ta(name, fullName, Pattern, ohlc, []Option{ratio("penetration", def, 0, math.MaxFloat64)}, patternOut,
+		func(o []float64) int { return lookback },
+		func(in [][]float64, o []float64) [][]float64 {
+			return [][]float64{ints(fn(in[0], in[1], in[2], in[3], o[0]))}
+		})
+}
+
+func registerPatterns() {
+	mustRegister(
+		pattern("cdl2crows", "Two Crows", 12, talib.Cdl2Crows),
+		pattern("cdl3blackcrows", "Three Black Crows", 13, talib.Cdl3BlackCrows),
+		pattern("cdl3inside", "Three Inside Up/Down", 12, talib.Cdl3InSide),
+		pattern("cdl3linestrike", "Three-Line Strike", 8, talib.Cdl3LineStrike),
+		pattern("cdl3outside", "Three Outside Up/Down", 3, talib.Cdl3OutSide),
+		pattern("cdl3starsinsouth", "Three Stars In The South", 12, talib.Cdl3StarsInSouth),
+		pattern("cdl3whitesoldiers", "Three Advancing White Soldiers", 12, talib.Cdl3WhiteSoldiers),
+		pattern("cdladvanceblock", "Advance Block", 12, talib.CdlAdvanceBlock),
+		pattern("cdlbelthold", "Belt-hold", 10, talib.CdlBeltHold),
+		pattern("cdlbreakaway", "Breakaway", 14, talib.CdlBreakaway),
+		pattern("cdlclosingmarubozu", "Closing Marubozu", 10, talib.CdlCloSingMarubozu),
+		pattern("cdlconcealbabyswall", "Concealing Baby Swallow", 13, talib.CdlCOncealBaBySwall),
+		pattern("cdlcounterattack", "Counterattack", 11, talib.CdlCounterattack),
+		pattern("cdldoji", "Doji", 10, talib.CdlDoji),
+		pattern("cdldojistar", "Doji Star", 11, talib.CdlDojiStar),
+		pattern("cdldragonflydoji", "Dragonfly Doji", 10, talib.CdlDragOnflyDoji),
+		pattern("cdlengulfing", "Engulfing Pattern", 2, talib.CdlEngulfing),
+		pattern("cdlgapsidesidewhite", "Up/Down-gap side-by-side white lines", 7, talib.CdlGapSidesideWhite),
+		pattern("cdlgravestonedoji", "Gravestone Doji", 10, talib.CdlGravestOneDoji),
+		pattern("cdlhammer", "Hammer", 11, talib.CdlHammer),
+		pattern("cdlhangingman", "Hanging Man", 11, talib.CdlHangingMan),
+		pattern("cdlharami", "Harami Pattern", 11, talib.CdlHarami),
+		pattern("cdlharamicross", "Harami Cross Pattern", 11, talib.CdlHaramiCross),
+		pattern("cdlhighwave", "High-Wave Candle", 10, talib.CdlHighWave),
+		pattern("cdlhikkake", "Hikkake Pattern", 5, talib.CdlHikkake),
+		pattern("cdlhikkakemod", "Modified Hikkake Pattern", 10, talib.CdlHikkakeMod),
+		pattern("cdlhomingpigeon", "Homing Pigeon", 11, talib.CdlHoMingPigeOn),
+		pattern("cdlidentical3crows", "Identical Three Crows", 12, talib.CdlIdentical3Crows),
+		pattern("cdlinneck", "In-Neck Pattern", 11, talib.CdlinNeck),
+		pattern("cdlinvertedhammer", "Inverted Hammer", 11, talib.CdlinvertedHammer),
+		pattern("cdlkicking", "Kicking", 11, talib.CdlKicking),
+		pattern("cdlkickingbylength", "Kicking - bull/bear determined by the longer marubozu", 11, talib.CdlKickingByLength),
+		pattern("cdlladderbottom", "Ladder Bottom", 14, talib.CdlLadderBottom),
+		pattern("cdllongleggeddoji", "Long Legged Doji", 10, talib.CdlLOngLeggedDoji),
+		pattern("cdllongline", "Long Line Candle", 10, talib.CdlLOngLine),
+		pattern("cdlmarubozu", "Marubozu", 10, talib.CdlMarubozu),
+		pattern("cdlmatchinglow", "Matching Low", 6, talib.CdlMatchingLow),
+		pattern("cdlonneck", "On-Neck Pattern", 11, talib.CdlOnNeck),
+		pattern("cdlpiercing", "Piercing Pattern", 11, talib.CdlPiercing),
+		pattern("cdlrickshawman", "Rickshaw Man", 10, talib.CdlRickshawMan),
+		pattern("cdlrisefall3methods", "Rising/Falling Three Methods", 14, talib.CdlRiseFall3Methods),
+		pattern("cdlseparatinglines", "Separating Lines", 11, talib.CdlSeparatingLines),
+		pattern("cdlshootingstar", "Shooting Star", 11, talib.CdlShootingStar),
+		pattern("cdlshortline", "Short Line Candle", 10, talib.CdlShortLine),
+		pattern("cdlspinningtop", "Spinning Top", 10, talib.CdlSpinningTop),
+		pattern("cdlstalledpattern", "Stalled Pattern", 12, talib.CdlStalledPattern),
+		pattern("cdlsticksandwich", "Stick Sandwich", 7, talib.CdlStickSandwich),
+		pattern("cdltakuri", "Takuri (Dragonfly Doji with very long lower shadow)", 10, talib.CdlTakuri),
+		pattern("cdltasukigap", "Tasuki Gap", 7, talib.CdlTasukiGap),
+		pattern("cdlthrusting", "Thrusting Pattern", 11, talib.CdlThrusting),
+		pattern("cdltristar", "Tristar Pattern", 12, talib.CdltriStar),
+		pattern("cdlunique3river", "Unique 3 River", 12, talib.CdlUnique3River),
+		pattern("cdlupsidegap2crows", "Upside Gap Two Crows", 12, talib.CdlupSideGap2Crows),
+		pattern("cdlxsidegap3methods", "Upside/Downside Gap Three Methods", 2, talib.CdlxSideGap3Methods),
+		penetration("cdlabandonedbaby", "Abandoned Baby", 0.3, 12, talib.CdlAbandOnedBaBy),
+		penetration("cdldarkcloudcover", "Dark Cloud Cover", 0.5, 11, talib.CdlDarkCloudCover),
+		penetration("cdleveningdojistar", "Evening Doji Star", 0.3, 12, talib.CdlEveningDojiStar),
+		penetration("cdleveningstar", "Evening Star", 0.3, 12, talib.CdlEveningStar),
+		penetration("cdlmathold", "Mat Hold", 0.5, 14, talib.CdlMatHold),
+		penetration("cdlmorningdojistar", "Morning Doji Star", 0.3, 12, talib.CdlMorningDojiStar),
+		penetration("cdlmorningstar", "Morning Star", 0.3, 12, talib.CdlMorningStar),
+	)
+}
@@ -0,0 +1,273 @@
+// Package indicator describes every TA-Lib and Tulip indicator in one
+// registry so they can be listed, configured by name and run against any
+// candle source without hard-coding the function calls.
+package indicator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Category mirrors the TI_TYPE_* groups, with candlestick patterns on top.
+type Category int
+
+const (
+	Overlay Category = iota + 1
+	Oscillator
+	Math
+	Pattern
+)
+
+var categoryNames = map[Category]string{
+	Overlay:    "overlay",
+	Oscillator: "oscillator",
+	Math:       "math",
+	Pattern:    "pattern",
+}
+
+func (c Category) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("category(%d)", int(c))
+}
+
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Input names a candle column an indicator reads.
+type Input string
+
+const (
+	Open   Input = "open"
+	High   Input = "high"
+	Low    Input = "low"
+	Close  Input = "close"
+	Volume Input = "volume"
+	// Real is any price series, the close unless the caller binds another.
+	Real Input = "real"
+
+	// Order flow columns of Binance klines.
+	QuoteVolume         Input = "quote_volume"
+	Trades              Input = "trades"
+	TakerBuyVolume      Input = "taker_buy_volume"
+	TakerBuyQuoteVolume Input = "taker_buy_quote_volume"
+)
+
+// Source is a set of candles an indicator can be invoked against.
+type Source interface {
+	Series(in Input) []float64
+}
+
+// OptionType tells how an option value is interpreted.
+type OptionType int
+
+const (
+	Float OptionType = iota
+	Int
+	// MAType is an int selecting one of the talib moving averages.
+	MAType
+)
+
+var optionTypeNames = map[OptionType]string{
+	Float:  "float",
+	Int:    "int",
+	MAType: "matype",
+}
+
+func (t OptionType) String() string {
+	return optionTypeNames[t]
+}
+
+func (t OptionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// Option is a tunable parameter with its default and accepted range.
+type Option struct {
+	Name    string     `json:"name"`
+	Type    OptionType `json:"type"`
+	Default float64    `json:"default"`
+	Min     float64    `json:"min"`
+	Max     float64    `json:"max"`
+}
+
+func (o Option) check(v float64) error {
+	if math.IsNaN(v) || v < o.Min || v > o.Max {
+		return fmt.Errorf("option %s: %v is outside [%v, %v]", o.Name, v, o.Min, o.Max)
+	}
+	if o.Type != Float && v != math.Trunc(v) {
+		return fmt.Errorf("option %s: %v is not an integer", o.Name, v)
+	}
+	return nil
+}
+
+// Definition describes one indicator of one library.
+type Definition struct {
+	Name     string   `json:"name"`
+	Library  string   `json:"library"`
+	FullName string   `json:"full_name"`
+	Category Category `json:"category"`
+	Inputs   []Input  `json:"inputs"`
+	Options  []Option `json:"options"`
+	Outputs  []string `json:"outputs"`
+
+	lookback func(opts []float64) int
+	run      func(inputs [][]float64, opts []float64) ([][]float64, error)
+}
+
+// ID is the name qualified by its library, e.g. "tulip.vidya".
+func (d *Definition) ID() string {
+	return d.Library + "." + d.Name
+}
+
+// Resolve fills in defaults for the options not set and checks them against
+// their ranges, returning the values in declaration order.
+func (d *Definition) Resolve(options map[string]float64) ([]float64, error) {
+	for name := range options {
+		if d.option(name) < 0 {
+			return nil, fmt.Errorf("%s has no option %q", d.ID(), name)
+		}
+	}
+	opts := make([]float64, len(d.Options))
+	for i, o := range d.Options {
+		v, ok := options[o.Name]
+		if !ok {
+			v = o.Default
+		}
+		if err := o.check(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", d.ID(), err)
+		}
+		opts[i] = v
+	}
+	return opts, nil
+}
+
+func (d *Definition) option(name string) int {
+	for i, o := range d.Options {
+		if o.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Lookback is the number of leading candles consumed before the first
+// output with the given options.
+func (d *Definition) Lookback(options map[string]float64) (int, error) {
+	opts, err := d.Resolve(options)
+	if err != nil {
+		return 0, err
+	}
+	return d.lookback(opts), nil
+}
+
+// Run computes the indicator on explicit inputs, one slice per entry of
+// Inputs, with options already resolved.
+func (d *Definition) Run(inputs [][]float64, opts []float64) ([][]float64, error) {
+	if len(inputs) != len(d.Inputs) {
+		return nil, fmt.Errorf("%s takes %d inputs, got %d", d.ID(), len(d.Inputs), len(inputs))
+	}
+	if len(opts) != len(d.Options) {
+		return nil, fmt.Errorf("%s takes %d options, got %d", d.ID(), len(d.Options), len(opts))
+	}
+	for i := 1; i < len(inputs); i++ {
+		if len(inputs[i]) != len(inputs[0]) {
+			return nil, fmt.Errorf("%s inputs have different lengths", d.ID())
+		}
+	}
+	return d.run(inputs, opts)
+}
+
+// Invoke computes the indicator on the candles of src. Real inputs read the
+// close unless reals binds them, in order, to other columns. The outputs
+// come back in the order of Outputs, each as long as the candles.
+func (d *Definition) Invoke(src Source, options map[string]float64, reals ...Input) ([][]float64, error) {
+	opts, err := d.Resolve(options)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([][]float64, len(d.Inputs))
+	for i, in := range d.Inputs {
+		if in == Real {
+			in = Close
+			if len(reals) > 0 {
+				in, reals = reals[0], reals[1:]
+			}
+		}
+		inputs[i] = src.Series(in)
+		if inputs[i] == nil {
+			return nil, fmt.Errorf("%s: source has no %s series", d.ID(), in)
+		}
+	}
+	return d.Run(inputs, opts)
+}
+
+var registry = make(map[string]*Definition)
+
+// Register adds an indicator, failing if its ID is already taken.
+func Register(d *Definition) error {
+	if d.Name == "" || d.Library == "" || d.run == nil || d.lookback == nil {
+		return errors.New("incomplete indicator definition")
+	}
+	if _, ok := registry[d.ID()]; ok {
+		return fmt.Errorf("indicator %s already registered", d.ID())
+	}
+	registry[d.ID()] = d
+	return nil
+}
+
+func mustRegister(defs ...*Definition) {
+	for _, d := range defs {
+		if err := Register(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// libraries lists the order unqualified names are looked up in.
+var libraries = []string{"talib", "tulip", "flow"}
+
+// Find returns the indicator with the given ID. A bare name such as "rsi"
+// resolves to the first library that has it, talib before tulip before flow.
+func Find(name string) (*Definition, error) {
+	name = strings.ToLower(name)
+	if d, ok := registry[name]; ok {
+		return d, nil
+	}
+	if !strings.Contains(name, ".") {
+		for _, lib := range libraries {
+			if d, ok := registry[lib+"."+name]; ok {
+				return d, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown indicator %q", name)
+}
+
+// List returns every registered indicator sorted by ID.
+func List() []*Definition {
+	res := make([]*Definition, 0, len(registry))
+	for _, d := range registry {
+		res = append(res, d)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID() < res[j].ID()
+	})
+	return res
+}
+
+// ByCategory returns the indicators of one category sorted by ID.
+func ByCategory(c Category) []*Definition {
+	var res []*Definition
+	for _, d := range List() {
+		if d.Category == c {
+			res = append(res, d)
+		}
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package indicator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	for _, tt := range []struct {
+		name, want string
+	}{
+		{"rsi", "talib.rsi"},
+		{"RSI", "talib.rsi"},
+		{"tulip.rsi", "tulip.rsi"},
+		{"vidya", "tulip.vidya"},
+		{"cvd", "flow.cvd"},
+		{"talib.vidya", ""},
+		{"nope", ""},
+	} {
+		d, err := Find(tt.name)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s: found %s", tt.name, d.ID())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if d.ID() != tt.want {
+			t.Errorf("%s: found %s, want %s", tt.name, d.ID(), tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	for _, tt := range []struct {
+		id      string
+		options map[string]float64
+		want    []float64
+	}{
+		{"talib.bbands", nil, []float64{5, 2, 2, 0}},
+		{"talib.bbands", map[string]float64{"nbdevdn": -1, "matype": 1}, []float64{5, 2, -1, 1}},
+		{"tulip.bbands", nil, []float64{20, 2}},
+		{"tulip.vidya", map[string]float64{"alpha": 1}, []float64{2, 5, 1}},
+		{"tulip.lag", map[string]float64{"period": 0}, []float64{0}},
+		{"talib.bbands", map[string]float64{"nope": 1}, nil},
+		{"talib.sma", map[string]float64{"timeperiod": 1}, nil},
+		{"talib.sma", map[string]float64{"timeperiod": 2.5}, nil},
+		{"talib.bbands", map[string]float64{"matype": 9}, nil},
+		{"tulip.vidya", map[string]float64{"alpha": 1.5}, nil},
+		{"tulip.adx", map[string]float64{"period": 1}, nil},
+		{"tulip.psar", map[string]float64{"acceleration_factor_step": 0}, nil},
+	} {
+		d, err := Find(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := d.Resolve(tt.options)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("%s %v: resolved to %v", tt.id, tt.options, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %v: %v", tt.id, tt.options, err)
+		} else if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %v: got %v, want %v", tt.id, tt.options, got, tt.want)
+		}
+	}
+}
+
+func TestLookback(t *testing.T) {
+	for _, tt := range []struct {
+		id      string
+		options map[string]float64
+		want    int
+	}{
+		{"talib.sma", map[string]float64{"timeperiod": 10}, 9},
+		{"tulip.sma", map[string]float64{"period": 10}, 9},
+		{"talib.macd", nil, 33},
+		{"tulip.macd", nil, 25},
+		{"talib.rsi", nil, 14},
+	} {
+		d, err := Find(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := d.Lookback(tt.options); err != nil || got != tt.want {
+			t.Errorf("%s %v: got %d, %v, want %d", tt.id, tt.options, got, err, tt.want)
+		}
+	}
+	d, _ := Find("sma")
+	if _, err := d.Lookback(map[string]float64{"timeperiod": -1}); err == nil {
+		t.Error("no error for a negative period")
+	}
+}
+
+// TestDefaults checks that every indicator accepts its own defaults, which
+// the libraries would otherwise only reject when run.
+func TestDefaults(t *testing.T) {
+	for _, d := range List() {
+		if _, err := d.Resolve(nil); err != nil {
+			t.Errorf("%s: %v", d.ID(), err)
+			continue
+		}
+		if n, err := d.Lookback(nil); err != nil || n < 0 {
+			t.Errorf("%s: lookback %d, %v with the defaults", d.ID(), n, err)
+		}
+	}
+}
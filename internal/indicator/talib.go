@@ -0,0 +1,496 @@
+package indicator
+
+import (
+	"math"
+
+	"cryptoapi/internal/talib"
+)
+
+// Option defaults and ranges follow the TA-Lib function descriptions.
+
+const maxPeriod = 100000
+
+var (
+	realIn = []Input{Real}
+	real2  = []Input{Real, Real}
+	hl     = []Input{High, Low}
+	hlc    = []Input{High, Low, Close}
+	hlcv   = []Input{High, Low, Close, Volume}
+	ohlc   = []Input{Open, High, Low, Close}
+	single = []string{"real"}
+)
+
+func period(name string, def, min float64) Option {
+	return Option{Name: name, Type: Int, Default: def, Min: min, Max: maxPeriod}
+}
+
+func ratio(name string, def, min, max float64) Option {
+	return Option{Name: name, Type: Float, Default: def, Min: min, Max: max}
+}
+
+func maType(name string) Option {
+	return Option{Name: name, Type: MAType, Default: talib.SMA, Min: talib.SMA, Max: talib.T3MA}
+}
+
+func timePeriod(def, min float64) []Option {
+	return []Option{period("timeperiod", def, min)}
+}
+
+func ints(v []int) []float64 {
+	res := make([]float64, len(v))
+	for i, x := range v {
+		res[i] = float64(x)
+	}
+	return res
+}
+
+func ta(name, fullName string, category Category, inputs []Input, options []Option, outputs []string,
+	lookback func(o []float64) int, run func(in [][]float64, o []float64) [][]float64) *Definition {
+	return &Definition{
+		Name:     name,
+		Library:  "talib",
+		FullName: fullName,
+		Category: category,
+		Inputs:   inputs,
+		Options:  options,
+		Outputs:  outputs,
+		lookback: lookback,
+		run: func(in [][]float64, o []float64) ([][]float64, error) {
+			return run(in, o), nil
+		},
+	}
+}
+
+// transform registers a function applied to each value on its own.
+func transform(name, fullName string, fn func([]float64) []float64) *Definition {
+	return ta(name, fullName, Math, realIn, nil, single, none, func(in [][]float64, o []float64) [][]float64 {
+		return [][]float64{fn(in[0])}
+	})
+}
+
+func operator(name, fullName string, fn func(a, b []float64) []float64) *Definition {
+	return ta(name, fullName, Math, real2, nil, single, none, func(in [][]float64, o []float64) [][]float64 {
+		return [][]float64{fn(in[0], in[1])}
+	})
+}
+
+// periodic registers a single input, single output function of a period.
+func periodic(name, fullName string, category Category, def, min float64, lookback func(p int) int, fn func([]float64, int) []float64) *Definition {
+	return ta(name, fullName, category, realIn, timePeriod(def, min), single,
+		func(o []float64) int { return lookback(int(o[0])) },
+		func(in [][]float64, o []float64) [][]float64 {
+			return [][]float64{fn(in[0], int(o[0]))}
+		})
+}
+
+func none(o []float64) int {
+	return 0
+}
+
+func minusOne(p int) int {
+	return p - 1
+}
+
+func same(p int) int {
+	return p
+}
+
+// dmLookback is the lookback of the directional movement family, which
+// skips the smoothing altogether for a period of one.
+func dmLookback(p, offset int) int {
+	if p > 1 {
+		return p + offset
+	}
+	return 1
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func init() {
+	mustRegister(
+		// Overlap studies
+		ta("bbands", "Bollinger Bands", Overlay, realIn,
+			[]Option{period("timeperiod", 5, 2), ratio("nbdevup", 2, -math.MaxFloat64, math.MaxFloat64),
+				ratio("nbdevdn", 2, -math.MaxFloat64, math.MaxFloat64), maType("matype")},
+			[]string{"upperband", "middleband", "lowerband"},
+			func(o []float64) int { return talib.MaLookback(int(o[0]), int(o[3])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				upper, middle, lower := talib.BBands(in[0], int(o[0]), o[1], o[2], int(o[3]))
+				return [][]float64{upper, middle, lower}
+			}),
+		periodic("dema", "Double Exponential Moving Average", Overlay, 30, 2,
+			func(p int) int { return talib.MaLookback(p, talib.DEMA) }, talib.Dema),
+		periodic("ema", "Exponential Moving Average", Overlay, 30, 2, minusOne, talib.Ema),
+		ta("ht_trendline", "Hilbert Transform - Instantaneous Trendline", Overlay, realIn, nil, single,
+			func(o []float64) int { return 63 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.HtTrendLine(in[0])}
+			}),
+		periodic("kama", "Kaufman Adaptive Moving Average", Overlay, 30, 2, same, talib.Kama),
+		ta("ma", "Moving average", Overlay, realIn, []Option{period("timeperiod", 30, 1), maType("matype")}, single,
+			func(o []float64) int { return talib.MaLookback(int(o[0]), int(o[1])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Ma(in[0], int(o[0]), int(o[1]))}
+			}),
+		ta("mama", "MESA Adaptive Moving Average", Overlay, realIn,
+			[]Option{ratio("fastlimit", 0.5, 0.01, 0.99), ratio("slowlimit", 0.05, 0.01, 0.99)},
+			[]string{"mama", "fama"},
+			func(o []float64) int { return 32 },
+			func(in [][]float64, o []float64) [][]float64 {
+				mama, fama := talib.Mama(in[0], o[0], o[1])
+				return [][]float64{mama, fama}
+			}),
+		periodic("midpoint", "MidPoint over period", Overlay, 14, 2, minusOne, talib.MidPoint),
+		ta("midprice", "Midpoint Price over period", Overlay, hl, timePeriod(14, 2), single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.MidPrice(in[0], in[1], int(o[0]))}
+			}),
+		ta("sar", "Parabolic SAR", Overlay, hl,
+			[]Option{ratio("acceleration", 0.02, 0, math.MaxFloat64), ratio("maximum", 0.2, 0, math.MaxFloat64)}, single,
+			func(o []float64) int { return 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Sar(in[0], in[1], o[0], o[1])}
+			}),
+		ta("sarext", "Parabolic SAR - Extended", Overlay, hl,
+			[]Option{
+				ratio("startvalue", 0, -math.MaxFloat64, math.MaxFloat64),
+				ratio("offsetonreverse", 0, 0, math.MaxFloat64),
+				ratio("accelerationinitlong", 0.02, 0, math.MaxFloat64),
+				ratio("accelerationlong", 0.02, 0, math.MaxFloat64),
+				ratio("accelerationmaxlong", 0.2, 0, math.MaxFloat64),
+				ratio("accelerationinitshort", 0.02, 0, math.MaxFloat64),
+				ratio("accelerationshort", 0.02, 0, math.MaxFloat64),
+				ratio("accelerationmaxshort", 0.2, 0, math.MaxFloat64),
+			}, single,
+			func(o []float64) int { return 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.SarExt(in[0], in[1], o[0], o[1], o[2], o[3], o[4], o[5], o[6], o[7])}
+			}),
+		periodic("sma", "Simple Moving Average", Overlay, 30, 2, minusOne, talib.Sma),
+		ta("t3", "Triple Exponential Moving Average (T3)", Overlay, realIn,
+			[]Option{period("timeperiod", 5, 2), ratio("vfactor", 0.7, 0, 1)}, single,
+			func(o []float64) int { return talib.MaLookback(int(o[0]), talib.T3MA) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.T3(in[0], int(o[0]), o[1])}
+			}),
+		periodic("tema", "Triple Exponential Moving Average", Overlay, 30, 2,
+			func(p int) int { return talib.MaLookback(p, talib.TEMA) }, talib.Tema),
+		periodic("trima", "Triangular Moving Average", Overlay, 30, 2, minusOne, talib.Trima),
+		periodic("wma", "Weighted Moving Average", Overlay, 30, 2, minusOne, talib.Wma),
+
+		// Momentum indicators
+		ta("adx", "Average Directional Movement Index", Oscillator, hlc, timePeriod(14, 2), single,
+			func(o []float64) int { return 2*int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Adx(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("adxr", "Average Directional Movement Index Rating", Oscillator, hlc, timePeriod(14, 2), single,
+			func(o []float64) int { return 3*int(o[0]) - 2 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Adxr(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("apo", "Absolute Price Oscillator", Oscillator, realIn,
+			[]Option{period("fastperiod", 12, 2), period("slowperiod", 26, 2), maType("matype")}, single,
+			func(o []float64) int { return talib.MaLookback(maxInt(int(o[0]), int(o[1])), int(o[2])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Apo(in[0], int(o[0]), int(o[1]), int(o[2]))}
+			}),
+		ta("aroon", "Aroon", Oscillator, hl, timePeriod(14, 2), []string{"aroondown", "aroonup"},
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				down, up := talib.AroOn(in[0], in[1], int(o[0]))
+				return [][]float64{down, up}
+			}),
+		ta("aroonosc", "Aroon Oscillator", Oscillator, hl, timePeriod(14, 2), single,
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.AroOnOsc(in[0], in[1], int(o[0]))}
+			}),
+		ta("bop", "Balance Of Power", Oscillator, ohlc, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Bop(in[0], in[1], in[2], in[3])}
+			}),
+		ta("cci", "Commodity Channel Index", Oscillator, hlc, timePeriod(14, 2), single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Cci(in[0], in[1], in[2], int(o[0]))}
+			}),
+		periodic("cmo", "Chande Momentum Oscillator", Oscillator, 14, 2, same, talib.Cmo),
+		ta("dx", "Directional Movement Index", Oscillator, hlc, timePeriod(14, 2), single,
+			func(o []float64) int { return dmLookback(int(o[0]), 0) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Dx(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("macd", "Moving Average Convergence/Divergence", Oscillator, realIn,
+			[]Option{period("fastperiod", 12, 2), period("slowperiod", 26, 2), period("signalperiod", 9, 1)},
+			[]string{"macd", "macdsignal", "macdhist"},
+			func(o []float64) int {
+				return talib.MaLookback(maxInt(int(o[0]), int(o[1])), talib.EMA) + talib.MaLookback(int(o[2]), talib.EMA)
+			},
+			func(in [][]float64, o []float64) [][]float64 {
+				macd, signal, hist := talib.Macd(in[0], int(o[0]), int(o[1]), int(o[2]))
+				return [][]float64{macd, signal, hist}
+			}),
+		ta("macdext", "MACD with controllable MA type", Oscillator, realIn,
+			[]Option{period("fastperiod", 12, 2), maType("fastmatype"), period("slowperiod", 26, 2), maType("slowmatype"),
+				period("signalperiod", 9, 1), maType("signalmatype")},
+			[]string{"macd", "macdsignal", "macdhist"},
+			func(o []float64) int {
+				return maxInt(talib.MaLookback(int(o[0]), int(o[1])), talib.MaLookback(int(o[2]), int(o[3]))) +
+					talib.MaLookback(int(o[4]), int(o[5]))
+			},
+			func(in [][]float64, o []float64) [][]float64 {
+				macd, signal, hist := talib.MacdExt(in[0], int(o[0]), int(o[1]), int(o[2]), int(o[3]), int(o[4]), int(o[5]))
+				return [][]float64{macd, signal, hist}
+			}),
+		ta("macdfix", "Moving Average Convergence/Divergence Fix 12/26", Oscillator, realIn,
+			[]Option{period("signalperiod", 9, 1)}, []string{"macd", "macdsignal", "macdhist"},
+			func(o []float64) int { return talib.MaLookback(26, talib.EMA) + talib.MaLookback(int(o[0]), talib.EMA) },
+			func(in [][]float64, o []float64) [][]float64 {
+				macd, signal, hist := talib.MacdFix(in[0], int(o[0]))
+				return [][]float64{macd, signal, hist}
+			}),
+		ta("mfi", "Money Flow Index", Oscillator, hlcv, timePeriod(14, 2), single,
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Mfi(in[0], in[1], in[2], in[3], int(o[0]))}
+			}),
+		ta("minus_di", "Minus Directional Indicator", Oscillator, hlc, timePeriod(14, 1), single,
+			func(o []float64) int { return dmLookback(int(o[0]), 0) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.MinusDi(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("minus_dm", "Minus Directional Movement", Oscillator, hl, timePeriod(14, 1), single,
+			func(o []float64) int { return dmLookback(int(o[0]), -1) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.MinusDm(in[0], in[1], int(o[0]))}
+			}),
+		periodic("mom", "Momentum", Oscillator, 10, 1, same, talib.Mom),
+		ta("plus_di", "Plus Directional Indicator", Oscillator, hlc, timePeriod(14, 1), single,
+			func(o []float64) int { return dmLookback(int(o[0]), 0) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.PlusDi(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("plus_dm", "Plus Directional Movement", Oscillator, hl, timePeriod(14, 1), single,
+			func(o []float64) int { return dmLookback(int(o[0]), -1) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.PlusDm(in[0], in[1], int(o[0]))}
+			}),
+		ta("ppo", "Percentage Price Oscillator", Oscillator, realIn,
+			[]Option{period("fastperiod", 12, 2), period("slowperiod", 26, 2), maType("matype")}, single,
+			func(o []float64) int { return talib.MaLookback(maxInt(int(o[0]), int(o[1])), int(o[2])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Ppo(in[0], int(o[0]), int(o[1]), int(o[2]))}
+			}),
+		periodic("roc", "Rate of change : ((price/prevPrice)-1)*100", Oscillator, 10, 1, same, talib.Roc),
+		periodic("rocp", "Rate of change Percentage: (price-prevPrice)/prevPrice", Oscillator, 10, 1, same, talib.Rocp),
+		periodic("rocr", "Rate of change ratio: (price/prevPrice)", Oscillator, 10, 1, same, talib.Rocr),
+		periodic("rocr100", "Rate of change ratio 100 scale: (price/prevPrice)*100", Oscillator, 10, 1, same, talib.Rocr100),
+		periodic("rsi", "Relative Strength Index", Oscillator, 14, 2, same, talib.Rsi),
+		ta("stoch", "Stochastic", Oscillator, hlc,
+			[]Option{period("fastk_period", 5, 1), period("slowk_period", 3, 1), maType("slowk_matype"),
+				period("slowd_period", 3, 1), maType("slowd_matype")},
+			[]string{"slowk", "slowd"},
+			func(o []float64) int {
+				return int(o[0]) - 1 + talib.MaLookback(int(o[1]), int(o[2])) + talib.MaLookback(int(o[3]), int(o[4]))
+			},
+			func(in [][]float64, o []float64) [][]float64 {
+				k, d := talib.Stoch(in[0], in[1], in[2], int(o[0]), int(o[1]), int(o[2]), int(o[3]), int(o[4]))
+				return [][]float64{k, d}
+			}),
+		ta("stochf", "Stochastic Fast", Oscillator, hlc,
+			[]Option{period("fastk_period", 5, 1), period("fastd_period", 3, 1), maType("fastd_matype")},
+			[]string{"fastk", "fastd"},
+			func(o []float64) int { return int(o[0]) - 1 + talib.MaLookback(int(o[1]), int(o[2])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				k, d := talib.Stochf(in[0], in[1], in[2], int(o[0]), int(o[1]), int(o[2]))
+				return [][]float64{k, d}
+			}),
+		ta("stochrsi", "Stochastic Relative Strength Index", Oscillator, realIn,
+			[]Option{period("timeperiod", 14, 2), period("fastk_period", 5, 1), period("fastd_period", 3, 1), maType("fastd_matype")},
+			[]string{"fastk", "fastd"},
+			func(o []float64) int { return int(o[0]) + int(o[1]) - 1 + talib.MaLookback(int(o[2]), int(o[3])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				k, d := talib.StochRsi(in[0], int(o[0]), int(o[1]), int(o[2]), int(o[3]))
+				return [][]float64{k, d}
+			}),
+		periodic("trix", "1-day Rate-Of-Change (ROC) of a Triple Smooth EMA", Oscillator, 30, 1,
+			func(p int) int { return 3*talib.MaLookback(p, talib.EMA) + 1 }, talib.Trix),
+		ta("ultosc", "Ultimate Oscillator", Oscillator, hlc,
+			[]Option{period("timeperiod1", 7, 1), period("timeperiod2", 14, 1), period("timeperiod3", 28, 1)}, single,
+			func(o []float64) int { return maxInt(maxInt(int(o[0]), int(o[1])), int(o[2])) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.UltOsc(in[0], in[1], in[2], int(o[0]), int(o[1]), int(o[2]))}
+			}),
+		ta("willr", "Williams' %R", Oscillator, hlc, timePeriod(14, 2), single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Willr(in[0], in[1], in[2], int(o[0]))}
+			}),
+
+		// Volume indicators
+		ta("ad", "Chaikin A/D Line", Oscillator, hlcv, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Ad(in[0], in[1], in[2], in[3])}
+			}),
+		ta("adosc", "Chaikin A/D Oscillator", Oscillator, hlcv,
+			[]Option{period("fastperiod", 3, 2), period("slowperiod", 10, 2)}, single,
+			func(o []float64) int { return talib.MaLookback(maxInt(int(o[0]), int(o[1])), talib.EMA) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.AdOsc(in[0], in[1], in[2], in[3], int(o[0]), int(o[1]))}
+			}),
+		ta("obv", "On Balance Volume", Oscillator, []Input{Real, Volume}, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Obv(in[0], in[1])}
+			}),
+
+		// Volatility indicators
+		ta("atr", "Average True Range", Oscillator, hlc, timePeriod(14, 1), single,
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Atr(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("natr", "Normalized Average True Range", Oscillator, hlc, timePeriod(14, 1), single,
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Natr(in[0], in[1], in[2], int(o[0]))}
+			}),
+		ta("trange", "True Range", Oscillator, hlc, nil, single,
+			func(o []float64) int { return 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Trange(in[0], in[1], in[2])}
+			}),
+
+		// Price transform
+		ta("avgprice", "Average Price", Overlay, ohlc, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.AvgPrice(in[0], in[1], in[2], in[3])}
+			}),
+		ta("medprice", "Median Price", Overlay, hl, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.MedPrice(in[0], in[1])}
+			}),
+		ta("typprice", "Typical Price", Overlay, hlc, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.TypPrice(in[0], in[1], in[2])}
+			}),
+		ta("wclprice", "Weighted Close Price", Overlay, hlc, nil, single, none,
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.WclPrice(in[0], in[1], in[2])}
+			}),
+
+		// Cycle indicators
+		ta("ht_dcperiod", "Hilbert Transform - Dominant Cycle Period", Oscillator, realIn, nil, single,
+			func(o []float64) int { return 32 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.HtDcPeriod(in[0])}
+			}),
+		ta("ht_dcphase", "Hilbert Transform - Dominant Cycle Phase", Oscillator, realIn, nil, single,
+			func(o []float64) int { return 63 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.HtDcPhase(in[0])}
+			}),
+		ta("ht_phasor", "Hilbert Transform - Phasor Components", Oscillator, realIn, nil, []string{"inphase", "quadrature"},
+			func(o []float64) int { return 32 },
+			func(in [][]float64, o []float64) [][]float64 {
+				inPhase, quadrature := talib.HtPhasor(in[0])
+				return [][]float64{inPhase, quadrature}
+			}),
+		ta("ht_sine", "Hilbert Transform - SineWave", Oscillator, realIn, nil, []string{"sine", "leadsine"},
+			func(o []float64) int { return 63 },
+			func(in [][]float64, o []float64) [][]float64 {
+				sine, leadSine := talib.HtSine(in[0])
+				return [][]float64{sine, leadSine}
+			}),
+		ta("ht_trendmode", "Hilbert Transform - Trend vs Cycle Mode", Oscillator, realIn, nil, []string{"integer"},
+			func(o []float64) int { return 63 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{ints(talib.HtTrendMode(in[0]))}
+			}),
+
+		// Statistic functions
+		ta("beta", "Beta", Math, real2, timePeriod(5, 1), single,
+			func(o []float64) int { return int(o[0]) },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Beta(in[0], in[1], int(o[0]))}
+			}),
+		ta("correl", "Pearson's Correlation Coefficient (r)", Math, real2, timePeriod(30, 1), single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Correl(in[0], in[1], int(o[0]))}
+			}),
+		periodic("linearreg", "Linear Regression", Overlay, 14, 2, minusOne, talib.LinearReg),
+		periodic("linearreg_angle", "Linear Regression Angle", Math, 14, 2, minusOne, talib.LinearRegAngle),
+		periodic("linearreg_intercept", "Linear Regression Intercept", Overlay, 14, 2, minusOne, talib.LinearRegIntercept),
+		periodic("linearreg_slope", "Linear Regression Slope", Math, 14, 2, minusOne, talib.LinearRegSlope),
+		ta("stddev", "Standard Deviation", Math, realIn,
+			[]Option{period("timeperiod", 5, 2), ratio("nbdev", 1, -math.MaxFloat64, math.MaxFloat64)}, single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.StdDev(in[0], int(o[0]), o[1])}
+			}),
+		periodic("tsf", "Time Series Forecast", Overlay, 14, 2, minusOne, talib.Tsf),
+		ta("var", "Variance", Math, realIn,
+			[]Option{period("timeperiod", 5, 1), ratio("nbdev", 1, -math.MaxFloat64, math.MaxFloat64)}, single,
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{talib.Var(in[0], int(o[0]), o[1])}
+			}),
+
+		// Math transform
+		transform("acos", "Vector Trigonometric ACos", talib.Acos),
+		transform("asin", "Vector Trigonometric ASin", talib.ASin),
+		transform("atan", "Vector Trigonometric ATan", talib.Atan),
+		transform("ceil", "Vector Ceil", talib.Ceil),
+		transform("cos", "Vector Trigonometric Cos", talib.Cos),
+		transform("cosh", "Vector Trigonometric Cosh", talib.Cosh),
+		transform("exp", "Vector Arithmetic Exp", talib.Exp),
+		transform("floor", "Vector Floor", talib.Floor),
+		transform("ln", "Vector Log Natural", talib.Ln),
+		transform("log10", "Vector Log10", talib.Log10),
+		transform("sin", "Vector Trigonometric Sin", talib.Sin),
+		transform("sinh", "Vector Trigonometric Sinh", talib.Sinh),
+		transform("sqrt", "Vector Square Root", talib.Sqrt),
+		transform("tan", "Vector Trigonometric Tan", talib.Tan),
+		transform("tanh", "Vector Trigonometric Tanh", talib.Tanh),
+
+		// Math operators
+		operator("add", "Vector Arithmetic Add", talib.Add),
+		operator("div", "Vector Arithmetic Div", talib.Div),
+		operator("mult", "Vector Arithmetic Mult", talib.Mult),
+		operator("sub", "Vector Arithmetic Substraction", talib.Sub),
+		periodic("max", "Highest value over a specified period", Math, 30, 2, minusOne, talib.Max),
+		ta("maxindex", "Index of highest value over a specified period", Math, realIn, timePeriod(30, 2), []string{"integer"},
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{ints(talib.MaxIndex(in[0], int(o[0])))}
+			}),
+		periodic("min", "Lowest value over a specified period", Math, 30, 2, minusOne, talib.Min),
+		ta("minindex", "Index of lowest value over a specified period", Math, realIn, timePeriod(30, 2), []string{"integer"},
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				return [][]float64{ints(talib.MinIndex(in[0], int(o[0])))}
+			}),
+		ta("minmax", "Lowest and highest values over a specified period", Math, realIn, timePeriod(30, 2), []string{"min", "max"},
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				min, max := talib.MinMax(in[0], int(o[0]))
+				return [][]float64{min, max}
+			}),
+		ta("minmaxindex", "Indexes of lowest and highest values over a specified period", Math, realIn, timePeriod(30, 2),
+			[]string{"minidx", "maxidx"},
+			func(o []float64) int { return int(o[0]) - 1 },
+			func(in [][]float64, o []float64) [][]float64 {
+				min, max := talib.MinMaxIndex(in[0], int(o[0]))
+				return [][]float64{ints(min), ints(max)}
+			}),
+		periodic("sum", "Summation", Math, 30, 2, minusOne, talib.Sum),
+	)
+	registerPatterns()
+}
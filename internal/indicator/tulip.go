@@ -0,0 +1,100 @@
+package indicator
+
+import (
+	"fmt"
+	"math"
+
+	"cryptoapi/internal/tulip"
+)
+
+var tulipCategories = map[tulip.Type]Category{
+	tulip.Overlay:     Overlay,
+	tulip.Oscillator:  Oscillator,
+	tulip.Math:        Math,
+	tulip.Simple:      Math,
+	tulip.Comparative: Math,
+}
+
+// tulipOptions are the usual settings and accepted ranges of the tulip
+// options, which the library itself only checks when run. Every option of
+// the library must be in here.
+var tulipOptions = map[string]Option{
+	"period":                      period("period", 14, 1),
+	"short period":                period("short_period", 12, 1),
+	"medium period":               period("medium_period", 14, 1),
+	"long period":                 period("long_period", 26, 2),
+	"signal period":               period("signal_period", 9, 1),
+	"%k period":                   period("%k_period", 5, 1),
+	"%k slowing period":           period("%k_slowing_period", 3, 1),
+	"%d period":                   period("%d_period", 3, 1),
+	"stddev":                      ratio("stddev", 2, 0, math.MaxFloat64),
+	"alpha":                       ratio("alpha", 0.2, 0, 1),
+	"acceleration factor step":    ratio("acceleration_factor_step", 0.02, 0.001, 1),
+	"acceleration factor maximum": ratio("acceleration_factor_maximum", 0.2, 0.001, 1),
+}
+
+// tulipOverrides holds the indicators whose customary settings differ from
+// tulipOptions.
+var tulipOverrides = map[string]map[string]float64{
+	"adosc":  {"short period": 3, "long period": 10},
+	"bbands": {"period": 20},
+	"kvo":    {"short period": 34, "long period": 55},
+	"ultosc": {"short period": 7, "medium period": 14, "long period": 28},
+	"vidya":  {"short period": 2, "long period": 5},
+	"vosc":   {"short period": 5, "long period": 10},
+}
+
+// tulipMins holds the indicators accepting other shortest periods.
+var tulipMins = map[string]map[string]float64{
+	"adx":      {"period": 2},
+	"adxr":     {"period": 2},
+	"lag":      {"period": 0},
+	"stochrsi": {"period": 2},
+}
+
+func tulipOption(ind *tulip.Indicator, name string) (Option, error) {
+	o, ok := tulipOptions[name]
+	if !ok {
+		return o, fmt.Errorf("tulip.%s: no settings for option %q", ind.Name, name)
+	}
+	if v, ok := tulipOverrides[ind.Name][name]; ok {
+		o.Default = v
+	}
+	if v, ok := tulipMins[ind.Name][name]; ok {
+		o.Min = v
+	}
+	return o, nil
+}
+
+func tulipDefinition(ind *tulip.Indicator) (*Definition, error) {
+	d := &Definition{
+		Name:     ind.Name,
+		Library:  "tulip",
+		FullName: ind.FullName,
+		Category: tulipCategories[ind.Type],
+		Outputs:  ind.Outputs,
+		lookback: ind.Start,
+		run:      ind.Run,
+	}
+	for _, in := range ind.Inputs {
+		d.Inputs = append(d.Inputs, Input(in))
+	}
+	for _, name := range ind.Options {
+		o, err := tulipOption(ind, name)
+		if err != nil {
+			return nil, err
+		}
+		d.Options = append(d.Options, o)
+	}
+	return d, nil
+}
+
+func init() {
+	for _, ind := range tulip.Indicators() {
+		d, err := tulipDefinition(ind)
+		if err != nil {
+			panic(err)
+		}
+		mustRegister(d)
+	}
+}
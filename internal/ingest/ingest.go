@@ -0,0 +1,424 @@
+// Package ingest keeps klines flowing from the Binance combined websocket
+// streams: it subscribes to <symbol>@kline_<interval> streams, answers the
+// server pings, reconnects before the 24h cut-off and asks for a REST
+// backfill whenever candles may have been missed.
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/stream"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Binance drops connections after 24h, so reconnect a bit earlier.
+	defaultReconnectAfter = 23 * time.Hour
+	// The server pings every 3 minutes; missing a few means the link is dead.
+	defaultReadTimeout = 10 * time.Minute
+	writeTimeout       = 10 * time.Second
+	// Binance accepts at most 5 messages a second and 1024 streams per
+	// connection; subscriptions are sent in chunks to stay below both.
+	subscribeChunk = 200
+	subscribeDelay = 250 * time.Millisecond
+	maxBackoff     = time.Minute
+)
+
+// Kline is one kline update. Closed is set on the final update of a candle.
+type Kline struct {
+	Symbol    string
+	Interval  string
+	Candle    stream.Candle
+	CloseTime int64
+	Closed    bool
+}
+
+// Handler receives every kline update, in order, from a single goroutine.
+type Handler func(k Kline)
+
+// BackfillFunc fetches the candles of a stream missed since the open time
+// of the last one seen, 0 when none was seen yet.
+type BackfillFunc func(symbol, interval string, since int64)
+
+// StreamName is the Binance name of the kline stream of a symbol.
+func StreamName(symbol, interval string) string {
+	return fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
+}
+
+type streamState struct {
+	symbol, interval string
+	// open time of the last candle seen and whether its close was seen
+	lastOpen int64
+	closed   bool
+}
+
+type Client struct {
+	URL            string
+	Dialer         *websocket.Dialer
+	ReconnectAfter time.Duration
+	ReadTimeout    time.Duration
+	*logging.Logger
+
+	handler  Handler
+	backfill BackfillFunc
+
+	mu      sync.Mutex
+	streams map[string]*streamState
+	conn    *websocket.Conn
+	nextID  int
+
+	writeMu sync.Mutex
+}
+
+// New returns a client for the combined stream endpoint url, e.g.
+// wss://stream.binance.com:9443/stream.
+func New(url string, logger *logging.Logger, handler Handler, backfill BackfillFunc) *Client {
+	return &Client{
+		URL:            url,
+		Dialer:         websocket.DefaultDialer,
+		ReconnectAfter: defaultReconnectAfter,
+		ReadTimeout:    defaultReadTimeout,
+		Logger:         logger,
+		handler:        handler,
+		backfill:       backfill,
+		streams:        make(map[string]*streamState),
+	}
+}
+
+// Subscribe adds the kline stream of every symbol in every interval. It is
+// sent right away when connected and replayed after each reconnect.
+func (c *Client) Subscribe(symbols, intervals []string) error {
+	var names []string
+	c.mu.Lock()
+	for _, symbol := range symbols {
+		for _, interval := range intervals {
+			name := StreamName(symbol, interval)
+			if _, ok := c.streams[name]; ok {
+				continue
+			}
+			c.streams[name] = &streamState{symbol: strings.ToUpper(symbol), interval: interval}
+			names = append(names, name)
+		}
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return c.send(conn, "SUBSCRIBE", names)
+}
+
+// Unsubscribe drops the kline streams of the symbols in the intervals.
+func (c *Client) Unsubscribe(symbols, intervals []string) error {
+	var names []string
+	c.mu.Lock()
+	for _, symbol := range symbols {
+		for _, interval := range intervals {
+			name := StreamName(symbol, interval)
+			if _, ok := c.streams[name]; ok {
+				delete(c.streams, name)
+				names = append(names, name)
+			}
+		}
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return c.send(conn, "UNSUBSCRIBE", names)
+}
+
+// Streams lists the names of the subscribed streams.
+func (c *Client) Streams() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res := make([]string, 0, len(c.streams))
+	for name := range c.streams {
+		res = append(res, name)
+	}
+	return res
+}
+
+type request struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int      `json:"id"`
+}
+
+func (c *Client) send(conn *websocket.Conn, method string, names []string) error {
+	for len(names) > 0 {
+		n := len(names)
+		if n > subscribeChunk {
+			n = subscribeChunk
+		}
+		c.mu.Lock()
+		c.nextID++
+		req := request{Method: method, Params: names[:n], ID: c.nextID}
+		c.mu.Unlock()
+		c.writeMu.Lock()
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err := conn.WriteJSON(req)
+		c.writeMu.Unlock()
+		if err != nil {
+			return err
+		}
+		names = names[n:]
+		if len(names) > 0 {
+			time.Sleep(subscribeDelay)
+		}
+	}
+	return nil
+}
+
+// Run connects and reads until ctx is done, reconnecting with a growing
+// delay whenever the connection fails and every ReconnectAfter.
+func (c *Client) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := c.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Since(started) > maxBackoff {
+			backoff = time.Second
+		}
+		c.WithError(err).Debugf("websocket session ended, reconnecting in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// session runs one connection from dial to the first read error.
+func (c *Client) session(ctx context.Context) error {
+	conn, _, err := c.Dialer.DialContext(ctx, c.URL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Closing the connection is the only way to interrupt a blocked read.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(c.ReconnectAfter):
+			c.Debug("forcing the periodic websocket reconnect")
+		case <-done:
+			return
+		}
+		conn.Close()
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(c.ReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(c.ReadTimeout))
+		c.writeMu.Lock()
+		defer c.writeMu.Unlock()
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(writeTimeout))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+
+	c.mu.Lock()
+	c.conn = conn
+	names := make([]string, 0, len(c.streams))
+	var missed []streamState
+	for name, s := range c.streams {
+		names = append(names, name)
+		if s.lastOpen != 0 {
+			missed = append(missed, *s)
+		}
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(conn, "SUBSCRIBE", names); err != nil {
+		return err
+	}
+	// Candles may have closed while disconnected. The backfill brings the
+	// stream up to date, so the next update starts it afresh.
+	for _, s := range missed {
+		c.backfill(s.symbol, s.interval, s.lastOpen)
+		c.mu.Lock()
+		if cur, ok := c.streams[StreamName(s.symbol, s.interval)]; ok && cur.lastOpen == s.lastOpen {
+			cur.lastOpen = 0
+		}
+		c.mu.Unlock()
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		conn.SetReadDeadline(time.Now().Add(c.ReadTimeout))
+		if err := c.dispatch(msg); err != nil {
+			c.WithError(err).Debug("failed handling websocket message")
+		}
+	}
+}
+
+type message struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	// set on the replies to SUBSCRIBE and UNSUBSCRIBE
+	ID    *int `json:"id"`
+	Error *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+// encoding/json matches keys case-insensitively, so the Binance fields
+// differing only in case from the ones used must be declared to be skipped.
+type klineEvent struct {
+	Event     string `json:"e"`
+	EventTime int64  `json:"E"`
+	Kline     struct {
+		OpenTime  int64       `json:"t"`
+		CloseTime int64       `json:"T"`
+		Symbol    string      `json:"s"`
+		Interval  string      `json:"i"`
+		Open      json.Number `json:"o"`
+		Close     json.Number `json:"c"`
+		High      json.Number `json:"h"`
+		Low       json.Number `json:"l"`
+		Volume    json.Number `json:"v"`
+		Closed    bool        `json:"x"`
+
+		LastTradeID         int64       `json:"L"`
+		QuoteVolume         json.Number `json:"q"`
+		Trades              int64       `json:"n"`
+		TakerBuyVolume      json.Number `json:"V"`
+		TakerBuyQuoteVolume json.Number `json:"Q"`
+	} `json:"k"`
+}
+
+func (c *Client) dispatch(raw []byte) error {
+	var msg message
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return err
+	}
+	if msg.Error != nil {
+		return fmt.Errorf("binance error %d: %s", msg.Error.Code, msg.Error.Msg)
+	}
+	if msg.ID != nil || msg.Stream == "" {
+		return nil
+	}
+	var ev klineEvent
+	if err := json.Unmarshal(msg.Data, &ev); err != nil {
+		return err
+	}
+	if ev.Event != "kline" {
+		return nil
+	}
+	k, err := ev.kline()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	s, ok := c.streams[msg.Stream]
+	var gap bool
+	var since int64
+	if ok {
+		// A new candle while the previous one never closed, or one that
+		// skips whole intervals, means updates were lost.
+		if s.lastOpen != 0 && k.Candle.OpenTime > s.lastOpen {
+			gap = !s.closed || k.Candle.OpenTime > s.lastOpen+intervalMillis(s.interval)
+			since = s.lastOpen
+		}
+		if k.Candle.OpenTime >= s.lastOpen {
+			s.lastOpen, s.closed = k.Candle.OpenTime, k.Closed
+		}
+	}
+	c.mu.Unlock()
+	if !ok {
+		// late update of a stream just unsubscribed
+		return nil
+	}
+	if gap {
+		c.backfill(k.Symbol, k.Interval, since)
+	}
+	c.handler(k)
+	return nil
+}
+
+func (ev *klineEvent) kline() (Kline, error) {
+	k := ev.Kline
+	var values [8]float64
+	for i, n := range []json.Number{k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteVolume, k.TakerBuyVolume, k.TakerBuyQuoteVolume} {
+		v, err := strconv.ParseFloat(string(n), 64)
+		if err != nil {
+			return Kline{}, err
+		}
+		values[i] = v
+	}
+	if k.Symbol == "" || k.Interval == "" {
+		return Kline{}, errors.New("kline without symbol or interval")
+	}
+	return Kline{
+		Symbol:   k.Symbol,
+		Interval: k.Interval,
+		Candle: stream.Candle{
+			OpenTime: k.OpenTime,
+			Open:     values[0],
+			High:     values[1],
+			Low:      values[2],
+			Close:    values[3],
+			Volume:   values[4],
+
+			QuoteVolume:         values[5],
+			Trades:              k.Trades,
+			TakerBuyVolume:      values[6],
+			TakerBuyQuoteVolume: values[7],
+		},
+		CloseTime: k.CloseTime,
+		Closed:    k.Closed,
+	}, nil
+}
+
+var intervalUnits = map[byte]int64{
+	'm': int64(time.Minute / time.Millisecond),
+	'h': int64(time.Hour / time.Millisecond),
+	'd': 24 * int64(time.Hour/time.Millisecond),
+	'w': 7 * 24 * int64(time.Hour/time.Millisecond),
+	'M': 31 * 24 * int64(time.Hour/time.Millisecond),
+}
+
+// intervalMillis is the length of a Binance interval such as "15m". Months
+// count as 31 days, the longest they get.
+func intervalMillis(interval string) int64 {
+	if len(interval) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n * intervalUnits[interval[len(interval)-1]]
+}
@@ -0,0 +1,188 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/logging"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const minute = int64(time.Minute / time.Millisecond)
+
+// t0 is the open time of the first candle sent, on a minute.
+const t0 = 1600000020000
+
+type backfillCall struct {
+	symbol, interval string
+	since            int64
+}
+
+// serve starts a combined stream endpoint handing every connection to the
+// test, and a client of it following the BTCUSDT 1m stream.
+func serve(t *testing.T) (<-chan *websocket.Conn, <-chan Kline, <-chan backfillCall) {
+	conns := make(chan *websocket.Conn, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	klines := make(chan Kline, 16)
+	backfills := make(chan backfillCall, 16)
+	c := New("ws"+strings.TrimPrefix(srv.URL, "http"), &logging.Logger{Logger: logrus.New()},
+		func(k Kline) { klines <- k },
+		func(symbol, interval string, since int64) { backfills <- backfillCall{symbol, interval, since} })
+	if err := c.Subscribe([]string{"BTCUSDT"}, []string{"1m"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go c.Run(ctx)
+	return conns, klines, backfills
+}
+
+// accept waits for the client to connect and checks its subscription.
+func accept(t *testing.T, conns <-chan *websocket.Conn) *websocket.Conn {
+	t.Helper()
+	var conn *websocket.Conn
+	select {
+	case conn = <-conns:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the client to connect")
+	}
+	var req request
+	if err := conn.ReadJSON(&req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Method != "SUBSCRIBE" || len(req.Params) != 1 || req.Params[0] != "btcusdt@kline_1m" {
+		t.Fatalf("got %+v", req)
+	}
+	if err := conn.WriteJSON(map[string]interface{}{"result": nil, "id": req.ID}); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func sendKline(t *testing.T, conn *websocket.Conn, open int64, closed bool) {
+	t.Helper()
+	msg := fmt.Sprintf(`{"stream":"btcusdt@kline_1m","data":{"e":"kline","E":%d,"s":"BTCUSDT","k":{`+
+		`"t":%d,"T":%d,"s":"BTCUSDT","i":"1m","o":"1.5","c":"2.5","h":"3","l":"1","v":"10","x":%t,`+
+		`"L":7,"q":"20","n":5,"V":"4","Q":"8","B":"0"}}}`, open+1000, open, open+minute-1, closed)
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func nextKline(t *testing.T, klines <-chan Kline) Kline {
+	t.Helper()
+	select {
+	case k := <-klines:
+		return k
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a kline")
+	}
+	return Kline{}
+}
+
+func nextBackfill(t *testing.T, backfills <-chan backfillCall) backfillCall {
+	t.Helper()
+	select {
+	case b := <-backfills:
+		return b
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a backfill")
+	}
+	return backfillCall{}
+}
+
+func TestDecode(t *testing.T) {
+	conns, klines, _ := serve(t)
+	conn := accept(t, conns)
+	sendKline(t, conn, t0, true)
+	k := nextKline(t, klines)
+	c := k.Candle
+	if k.Symbol != "BTCUSDT" || k.Interval != "1m" || !k.Closed || k.CloseTime != t0+minute-1 || c.OpenTime != t0 {
+		t.Errorf("got %+v", k)
+	}
+	got := [...]float64{c.Open, c.High, c.Low, c.Close, c.Volume, c.QuoteVolume, c.TakerBuyVolume, c.TakerBuyQuoteVolume}
+	if want := [...]float64{1.5, 3, 1, 2.5, 10, 20, 4, 8}; got != want || c.Trades != 5 {
+		t.Errorf("got %v and %d trades, want %v and 5", got, c.Trades, want)
+	}
+	// the updates of streams not subscribed to are dropped
+	msg := fmt.Sprintf(`{"stream":"ethusdt@kline_1m","data":{"e":"kline","k":{"t":%d,"s":"ETHUSDT","i":"1m",`+
+		`"o":"1","c":"1","h":"1","l":"1","v":"1","q":"1","V":"1","Q":"1"}}}`, t0)
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+	sendKline(t, conn, t0+minute, false)
+	if k := nextKline(t, klines); k.Symbol != "BTCUSDT" || k.Candle.OpenTime != t0+minute {
+		t.Errorf("got %+v after an ETHUSDT update", k)
+	}
+}
+
+func TestGap(t *testing.T) {
+	conns, klines, backfills := serve(t)
+	conn := accept(t, conns)
+	sendKline(t, conn, t0, false)
+	nextKline(t, klines)
+	// the next candle while the first one never closed
+	sendKline(t, conn, t0+minute, true)
+	if b := nextBackfill(t, backfills); b != (backfillCall{"BTCUSDT", "1m", t0}) {
+		t.Errorf("unclosed candle: got %+v", b)
+	}
+	nextKline(t, klines)
+	// a candle skipping a minute
+	sendKline(t, conn, t0+3*minute, false)
+	if b := nextBackfill(t, backfills); b != (backfillCall{"BTCUSDT", "1m", t0 + minute}) {
+		t.Errorf("skipped candle: got %+v", b)
+	}
+	nextKline(t, klines)
+	// the next update of a closed candle is no gap
+	sendKline(t, conn, t0+3*minute, true)
+	nextKline(t, klines)
+	sendKline(t, conn, t0+4*minute, false)
+	nextKline(t, klines)
+	select {
+	case b := <-backfills:
+		t.Errorf("backfill without a gap: %+v", b)
+	default:
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	conns, klines, backfills := serve(t)
+	conn := accept(t, conns)
+	sendKline(t, conn, t0, true)
+	nextKline(t, klines)
+	conn.Close()
+
+	// the client subscribes again and backfills from the last candle seen
+	conn = accept(t, conns)
+	defer conn.Close()
+	if b := nextBackfill(t, backfills); b != (backfillCall{"BTCUSDT", "1m", t0}) {
+		t.Errorf("got %+v", b)
+	}
+	// the stream starts afresh after the backfill
+	sendKline(t, conn, t0+5*minute, false)
+	if k := nextKline(t, klines); k.Candle.OpenTime != t0+5*minute {
+		t.Errorf("got %+v", k)
+	}
+	select {
+	case b := <-backfills:
+		t.Errorf("backfill after the reconnect one: %+v", b)
+	default:
+	}
+}
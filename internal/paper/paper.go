@@ -0,0 +1,328 @@
+// Package paper trades the live signals on a simulated account. Buy signals
+// open a long position on the symbol, or close its short one; sell signals
+// close the long one and open a short one when allowed. Orders fill at
+// once at the last price, with the costs and sizing of the backtests.
+package paper
+
+import (
+	"sync"
+
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/risk"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+// orders and trades the account remembers, the oldest dropped first
+const (
+	maxOrders = 1000
+	maxTrades = 1000
+)
+
+// Position is a position open on the account.
+type Position struct {
+	ID     int           `json:"id"`
+	Symbol string        `json:"symbol"`
+	Side   backtest.Side `json:"side"`
+	Qty    float64       `json:"qty"`
+	Entry  float64       `json:"entry"`
+	Opened int64         `json:"opened"`
+	Fees   float64       `json:"fees"`
+	// rule whose signal opened it
+	Rule string `json:"rule"`
+}
+
+// Order is an order filled on the account.
+type Order struct {
+	ID       int           `json:"id"`
+	Position int           `json:"position"`
+	Symbol   string        `json:"symbol"`
+	Side     backtest.Side `json:"side"`
+	Qty      float64       `json:"qty"`
+	Price    float64       `json:"price"`
+	Fee      float64       `json:"fee"`
+	Time     int64         `json:"time"`
+	Rule     string        `json:"rule"`
+}
+
+// Trade is a position once closed.
+type Trade struct {
+	Position int           `json:"position"`
+	Symbol   string        `json:"symbol"`
+	Side     backtest.Side `json:"side"`
+	Qty      float64       `json:"qty"`
+	Entry    float64       `json:"entry"`
+	Exit     float64       `json:"exit"`
+	Opened   int64         `json:"opened"`
+	Closed   int64         `json:"closed"`
+	Fees     float64       `json:"fees"`
+	// profit after fees
+	PnL  float64 `json:"pnl"`
+	Rule string  `json:"rule"`
+}
+
+// Account is the state of the simulated account, kept across restarts.
+type Account struct {
+	// cash the account started with
+	Start     float64     `json:"start"`
+	Cash      float64     `json:"cash"`
+	Positions []*Position `json:"positions"`
+	Orders    []Order     `json:"orders"`
+	Trades    []Trade     `json:"trades"`
+	LastID    int         `json:"last_id"`
+}
+
+// copy returns a copy of a sharing nothing with it.
+func (a Account) copy() Account {
+	res := a
+	res.Positions = make([]*Position, len(a.Positions))
+	for i, p := range a.Positions {
+		p := *p
+		res.Positions[i] = &p
+	}
+	res.Orders = append([]Order(nil), a.Orders...)
+	res.Trades = append([]Trade(nil), a.Trades...)
+	return res
+}
+
+// PriceFunc returns the last price of a symbol.
+type PriceFunc func(symbol string) (float64, bool)
+
+// Trader executes the signals on the account.
+type Trader struct {
+	cfg backtest.Config
+	// whether sell signals open short positions
+	short  bool
+	prices PriceFunc
+	// sizes and limits the new positions when set
+	Gate *risk.Gate
+	*logging.Logger
+
+	mu      sync.Mutex
+	account Account
+	changed bool
+}
+
+func New(cfg backtest.Config, short bool, prices PriceFunc, logger *logging.Logger) *Trader {
+	return &Trader{
+		cfg:     cfg,
+		short:   short,
+		prices:  prices,
+		Logger:  logger,
+		account: Account{Start: cfg.Cash, Cash: cfg.Cash},
+	}
+}
+
+// Restore brings back an account saved before, unless it is empty.
+func (t *Trader) Restore(a Account) {
+	if a.Start == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.account = a.copy()
+	t.trim()
+}
+
+// trim drops the oldest orders and trades past the ones remembered.
+func (t *Trader) trim() {
+	if n := len(t.account.Orders); n > maxOrders {
+		t.account.Orders = append([]Order(nil), t.account.Orders[n-maxOrders:]...)
+	}
+	if n := len(t.account.Trades); n > maxTrades {
+		t.account.Trades = append([]Trade(nil), t.account.Trades[n-maxTrades:]...)
+	}
+}
+
+// Snapshot returns the account and whether it changed since the last
+// snapshot.
+func (t *Trader) Snapshot() (Account, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	changed := t.changed
+	t.changed = false
+	return t.account.copy(), changed
+}
+
+// Account returns the account as it stands.
+func (t *Trader) Account() Account {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.account.copy()
+}
+
+// Equity is the cash and the open positions valued at the last prices, or
+// at their entry price when unknown.
+func (t *Trader) Equity() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.equity()
+}
+
+func (t *Trader) equity() float64 {
+	res := t.account.Cash
+	for _, p := range t.account.Positions {
+		price, ok := t.prices(p.Symbol)
+		if !ok {
+			price = p.Entry
+		}
+		if p.Side == backtest.Buy {
+			res += p.Qty * price
+		} else {
+			res -= p.Qty * price
+		}
+	}
+	return res
+}
+
+// portfolio is the account as the risk gate sees it.
+func (t *Trader) portfolio(equity float64) risk.Portfolio {
+	res := risk.Portfolio{Equity: equity}
+	for _, p := range t.account.Positions {
+		price, ok := t.prices(p.Symbol)
+		if !ok {
+			price = p.Entry
+		}
+		res.Positions = append(res.Positions, risk.Exposure{Symbol: p.Symbol, Value: p.Qty * price, Short: p.Side == backtest.Sell})
+	}
+	return res
+}
+
+// Handle trades a signal at price, the last one of its symbol.
+func (t *Trader) Handle(s rules.Signal, price float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch s.Direction {
+	case rules.Buy:
+		t.closeAll(s, backtest.Sell, price)
+		t.open(s, backtest.Buy, price)
+	case rules.Sell:
+		t.closeAll(s, backtest.Buy, price)
+		if t.short {
+			t.open(s, backtest.Sell, price)
+		}
+	}
+}
+
+func (t *Trader) id() int {
+	t.account.LastID++
+	return t.account.LastID
+}
+
+// fill records an order filling at the market and returns its price and
+// fee.
+func (t *Trader) fill(s rules.Signal, pos *Position, side backtest.Side, qty, price float64) (float64, float64) {
+	p := t.cfg.MarketPrice(side, price)
+	fee := qty * p * t.cfg.TakerFee
+	if side == backtest.Buy {
+		t.account.Cash -= qty*p + fee
+	} else {
+		t.account.Cash += qty*p - fee
+	}
+	t.account.Orders = append(t.account.Orders, Order{
+		ID:       t.id(),
+		Position: pos.ID,
+		Symbol:   pos.Symbol,
+		Side:     side,
+		Qty:      qty,
+		Price:    p,
+		Fee:      fee,
+		Time:     s.Time,
+		Rule:     s.Rule,
+	})
+	t.trim()
+	t.changed = true
+	return p, fee
+}
+
+// open opens a position on the symbol of s unless one of side is open.
+// Longs are cut down to the cash left.
+func (t *Trader) open(s rules.Signal, side backtest.Side, price float64) {
+	for _, p := range t.account.Positions {
+		if p.Symbol == s.Symbol && p.Side == side {
+			return
+		}
+	}
+	equity := t.equity()
+	var qty float64
+	if t.cfg.Sizer != nil && equity > 0 {
+		qty = t.cfg.Sizer.Size(equity, price)
+	}
+	if t.Gate != nil {
+		qty = t.Gate.Check(t.portfolio(equity), s, price, qty*price)
+	}
+	if qty <= 0 {
+		return
+	}
+	if side == backtest.Buy {
+		if most := t.account.Cash / (t.cfg.MarketPrice(side, price) * (1 + t.cfg.TakerFee)); qty > most {
+			qty = most
+		}
+	}
+	if !(qty > 0) {
+		t.Debugf("paper: no cash left to open %s %s", side, s.Symbol)
+		return
+	}
+	pos := &Position{ID: t.id(), Symbol: s.Symbol, Side: side, Qty: qty, Opened: s.Time, Rule: s.Rule}
+	pos.Entry, pos.Fees = t.fill(s, pos, side, qty, price)
+	t.account.Positions = append(t.account.Positions, pos)
+	t.Debugf("paper: opened %s %v %s at %v on %s", side, qty, s.Symbol, pos.Entry, s.Rule)
+}
+
+// closeAll closes the positions of side on the symbol of s.
+func (t *Trader) closeAll(s rules.Signal, side backtest.Side, price float64) {
+	open := t.account.Positions[:0]
+	for _, pos := range t.account.Positions {
+		if pos.Symbol != s.Symbol || pos.Side != side {
+			open = append(open, pos)
+			continue
+		}
+		exit, fee := t.fill(s, pos, opposite(side), pos.Qty, price)
+		pnl := (exit - pos.Entry) * pos.Qty
+		if side == backtest.Sell {
+			pnl = -pnl
+		}
+		fees := pos.Fees + fee
+		t.account.Trades = append(t.account.Trades, Trade{
+			Position: pos.ID,
+			Symbol:   pos.Symbol,
+			Side:     pos.Side,
+			Qty:      pos.Qty,
+			Entry:    pos.Entry,
+			Exit:     exit,
+			Opened:   pos.Opened,
+			Closed:   s.Time,
+			Fees:     fees,
+			PnL:      pnl - fees,
+			Rule:     pos.Rule,
+		})
+		t.trim()
+		t.Debugf("paper: closed %s %s at %v on %s, pnl %.2f", pos.Side, s.Symbol, exit, s.Rule, pnl-fees)
+	}
+	t.account.Positions = open
+}
+
+func opposite(side backtest.Side) backtest.Side {
+	if side == backtest.Buy {
+		return backtest.Sell
+	}
+	return backtest.Buy
+}
+
+// FromConfig makes the trader of the paper section of the config, trading
+// with the costs of the backtest section.
+func FromConfig(prices PriceFunc, logger *logging.Logger) (*Trader, error) {
+	cfg, err := backtest.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Cash = viper.GetFloat64("paper.cash")
+	if size := viper.GetString("paper.size"); size != "" {
+		if cfg.Sizer, err = backtest.ParseSizer(size); err != nil {
+			return nil, err
+		}
+	}
+	return New(cfg, viper.GetBool("paper.short"), prices, logger), nil
+}
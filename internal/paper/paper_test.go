@@ -0,0 +1,197 @@
+package paper
+
+import (
+	"math"
+	"testing"
+
+	"cryptoapi/internal/backtest"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/risk"
+	"cryptoapi/internal/rules"
+
+	"github.com/sirupsen/logrus"
+)
+
+var logger = &logging.Logger{Logger: logrus.New()}
+
+// trader trades 100 of the quote asset a position out of 1000, paying a
+// taker fee of 0.1%, at the prices of the map.
+func trader(short bool, prices map[string]float64) *Trader {
+	cfg := backtest.Config{Cash: 1000, TakerFee: 0.001, Sizer: backtest.Notional(100)}
+	return New(cfg, short, func(symbol string) (float64, bool) {
+		p, ok := prices[symbol]
+		return p, ok
+	}, logger)
+}
+
+func signal(symbol string, d rules.Direction, time int64) rules.Signal {
+	return rules.Signal{Rule: "rule", Symbol: symbol, Direction: d, Time: time}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestOpenClose(t *testing.T) {
+	prices := map[string]float64{"BTCUSDT": 100}
+	tr := trader(false, prices)
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	// a second buy does not add to the long
+	tr.Handle(signal("BTCUSDT", rules.Buy, 2), 100)
+	a := tr.Account()
+	if len(a.Positions) != 1 || len(a.Orders) != 1 {
+		t.Fatalf("%d positions, %d orders", len(a.Positions), len(a.Orders))
+	}
+	if p := a.Positions[0]; p.Side != backtest.Buy || p.Qty != 1 || p.Entry != 100 || !near(p.Fees, 0.1) || p.Opened != 1 {
+		t.Errorf("opened %+v", p)
+	}
+	if !near(a.Cash, 899.9) {
+		t.Errorf("cash %v, want 899.9", a.Cash)
+	}
+	prices["BTCUSDT"] = 110
+	if !near(tr.Equity(), 1009.9) {
+		t.Errorf("equity %v, want 1009.9", tr.Equity())
+	}
+
+	// without shorts a sell only closes the long
+	tr.Handle(signal("BTCUSDT", rules.Sell, 3), 110)
+	a = tr.Account()
+	if len(a.Positions) != 0 || len(a.Trades) != 1 || len(a.Orders) != 2 {
+		t.Fatalf("%d positions, %d trades, %d orders", len(a.Positions), len(a.Trades), len(a.Orders))
+	}
+	if trade := a.Trades[0]; trade.Exit != 110 || !near(trade.Fees, 0.21) || !near(trade.PnL, 9.79) || trade.Closed != 3 {
+		t.Errorf("closed %+v", trade)
+	}
+	if !near(a.Cash, 1009.79) {
+		t.Errorf("cash %v, want 1009.79", a.Cash)
+	}
+}
+
+func TestFlip(t *testing.T) {
+	tr := trader(true, map[string]float64{})
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	tr.Handle(signal("BTCUSDT", rules.Sell, 2), 125)
+	a := tr.Account()
+	if len(a.Positions) != 1 || len(a.Trades) != 1 {
+		t.Fatalf("%d positions, %d trades", len(a.Positions), len(a.Trades))
+	}
+	if p := a.Positions[0]; p.Side != backtest.Sell || !near(p.Qty, 0.8) || p.Entry != 125 {
+		t.Errorf("flipped to %+v", p)
+	}
+	// the short gains as the price falls
+	tr.Handle(signal("BTCUSDT", rules.Buy, 3), 100)
+	a = tr.Account()
+	if trade := a.Trades[1]; trade.Side != backtest.Sell || !near(trade.PnL, 0.8*25-0.8*(125+100)*0.001) {
+		t.Errorf("closed %+v", trade)
+	}
+	if len(a.Positions) != 1 || a.Positions[0].Side != backtest.Buy || a.Positions[0].Qty != 1 {
+		t.Errorf("positions %+v", a.Positions)
+	}
+}
+
+func TestCashCap(t *testing.T) {
+	tr := trader(false, map[string]float64{})
+	tr.cfg.Sizer = backtest.Notional(5000)
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	a := tr.Account()
+	// the long spends all the cash, its fee included
+	if len(a.Positions) != 1 || !near(a.Positions[0].Qty, 1000/100.1) || !near(a.Cash, 0) {
+		t.Errorf("positions %+v, cash %v", a.Positions, a.Cash)
+	}
+	// shorts are not cut
+	tr = trader(true, map[string]float64{})
+	tr.cfg.Sizer = backtest.Notional(5000)
+	tr.Handle(signal("BTCUSDT", rules.Sell, 1), 100)
+	if a := tr.Account(); len(a.Positions) != 1 || a.Positions[0].Qty != 50 {
+		t.Errorf("positions %+v", a.Positions)
+	}
+	// nor are positions opened with nothing left
+	tr = trader(false, map[string]float64{})
+	tr.account.Cash = 0
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	if a := tr.Account(); len(a.Positions) != 0 || len(a.Orders) != 0 {
+		t.Errorf("opened %+v with no cash", a.Positions)
+	}
+}
+
+func TestGate(t *testing.T) {
+	prices := map[string]float64{"BTCUSDT": 100, "ETHUSDT": 10}
+	tr := trader(false, prices)
+	tr.Gate = risk.New("paper", risk.Limits{MaxPositions: 1}, risk.Sizing{Mode: risk.Fixed, Notional: 50}, nil, logger)
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	tr.Handle(signal("ETHUSDT", rules.Buy, 2), 10)
+	a := tr.Account()
+	// the gate sizes the position and turns down the one above its limit
+	if len(a.Positions) != 1 || a.Positions[0].Symbol != "BTCUSDT" || a.Positions[0].Qty != 0.5 {
+		t.Errorf("positions %+v", a.Positions)
+	}
+	if r := tr.Gate.Rejections(); len(r) != 1 || r[0].Symbol != "ETHUSDT" || r[0].Account != "paper" {
+		t.Errorf("rejections %+v", r)
+	}
+	// closing is never held up
+	tr.Handle(signal("BTCUSDT", rules.Sell, 3), 100)
+	if a := tr.Account(); len(a.Positions) != 0 || len(a.Trades) != 1 {
+		t.Errorf("%d positions, %d trades", len(a.Positions), len(a.Trades))
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	tr := trader(false, map[string]float64{})
+	if _, changed := tr.Snapshot(); changed {
+		t.Error("changed before trading")
+	}
+	tr.Handle(signal("BTCUSDT", rules.Buy, 1), 100)
+	a, changed := tr.Snapshot()
+	if !changed || len(a.Positions) != 1 {
+		t.Fatalf("snapshot %+v, changed %v", a, changed)
+	}
+	if _, changed := tr.Snapshot(); changed {
+		t.Error("changed again without trading")
+	}
+	// the snapshot shares nothing with the trader
+	a.Positions[0].Qty = 5
+	if got := tr.Account().Positions[0].Qty; got != 1 {
+		t.Errorf("the snapshot changed the position to %v", got)
+	}
+
+	restored := trader(false, map[string]float64{})
+	restored.Restore(Account{})
+	if got := restored.Account(); got.Cash != 1000 || got.Start != 1000 {
+		t.Errorf("an empty account replaced %+v", got)
+	}
+	a.Positions[0].Qty = 1
+	restored.Restore(a)
+	// the restored long is closed by a sell and its ids carry on
+	restored.Handle(signal("BTCUSDT", rules.Sell, 2), 110)
+	got := restored.Account()
+	if len(got.Positions) != 0 || len(got.Trades) != 1 || got.Trades[0].Position != a.Positions[0].ID {
+		t.Errorf("account %+v", got)
+	}
+	if got.Orders[1].ID != a.LastID+1 {
+		t.Errorf("order id %d after %d", got.Orders[1].ID, a.LastID)
+	}
+}
+
+func TestHistoryCap(t *testing.T) {
+	tr := trader(false, map[string]float64{})
+	for i := 0; i < maxTrades+10; i++ {
+		tr.Handle(signal("BTCUSDT", rules.Buy, int64(2*i)), 100)
+		tr.Handle(signal("BTCUSDT", rules.Sell, int64(2*i+1)), 100)
+	}
+	a := tr.Account()
+	if len(a.Orders) != maxOrders || len(a.Trades) != maxTrades {
+		t.Fatalf("%d orders, %d trades", len(a.Orders), len(a.Trades))
+	}
+	// the last ones are kept
+	if a.Orders[maxOrders-1].ID != a.LastID || a.Trades[maxTrades-1].Closed != int64(2*(maxTrades+10)-1) {
+		t.Errorf("last order %d of %d, last trade closed at %d", a.Orders[maxOrders-1].ID, a.LastID, a.Trades[maxTrades-1].Closed)
+	}
+
+	// so are the ones of an account saved before the cap
+	a.Orders = append(a.Orders, a.Orders...)
+	restored := trader(false, map[string]float64{})
+	restored.Restore(a)
+	if got := restored.Account(); len(got.Orders) != maxOrders {
+		t.Errorf("restored %d orders", len(got.Orders))
+	}
+}
@@ -0,0 +1,393 @@
+// Package risk is the gate signals pass before becoming orders: it sizes the
+// new positions and turns down, or cuts down, those breaking the limits of
+// the account. Once the losses of a day reach the daily limit, a kill switch
+// stops any position from opening until the next day (UTC) or a reset.
+// Closing positions is never held up.
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/rules"
+
+	"github.com/spf13/viper"
+)
+
+const maxRejections = 500
+
+type Limits struct {
+	// largest value of the positions of a symbol, in the quote asset
+	MaxPosition float64 `json:"max_position,omitempty"`
+	// largest value of all the positions
+	MaxExposure  float64 `json:"max_exposure,omitempty"`
+	MaxPositions int     `json:"max_positions,omitempty"`
+	// loss of a day tripping the kill switch, as a fraction of the equity
+	// the day started with
+	DailyLoss float64 `json:"daily_loss,omitempty"`
+	// largest correlation of the returns of a new position with those of
+	// an open one, over Window candles of the interval of the gate
+	MaxCorrelation float64 `json:"max_correlation,omitempty"`
+	Window         int     `json:"window,omitempty"`
+}
+
+// Exposure is an open position.
+type Exposure struct {
+	Symbol string
+	// in the quote asset
+	Value float64
+	Short bool
+}
+
+// Portfolio is the account a new position would join.
+type Portfolio struct {
+	Equity    float64
+	Positions []Exposure
+}
+
+// Rejection is a signal turned down, or a position cut down.
+type Rejection struct {
+	Time      int64           `json:"time"`
+	Account   string          `json:"account"`
+	Rule      string          `json:"rule"`
+	Symbol    string          `json:"symbol"`
+	Direction rules.Direction `json:"direction"`
+	Reason    string          `json:"reason"`
+	// set when the position opened anyway, smaller
+	Cut bool `json:"cut,omitempty"`
+}
+
+// State is what the gate keeps across restarts.
+type State struct {
+	// day of the daily loss and the equity it started with
+	Day        string      `json:"day"`
+	Start      float64     `json:"start"`
+	Killed     string      `json:"killed,omitempty"`
+	Rejections []Rejection `json:"rejections"`
+}
+
+// CandlesFunc returns the recent candles of a symbol.
+type CandlesFunc func(symbol string) (rules.Source, error)
+
+// Gate is the risk gate of one account.
+type Gate struct {
+	// name of the account, paper or live, in the rejections
+	Account string
+	Limits
+	Sizing
+	*logging.Logger
+
+	candles  CandlesFunc
+	handlers []func(Rejection)
+
+	mu      sync.Mutex
+	state   State
+	changed bool
+}
+
+func New(account string, limits Limits, sizing Sizing, candles CandlesFunc, logger *logging.Logger) *Gate {
+	return &Gate{
+		Account: account,
+		Limits:  limits,
+		Sizing:  sizing,
+		Logger:  logger,
+		candles: candles,
+	}
+}
+
+// FromConfig makes the gate of an account from the risk section of the
+// config.
+func FromConfig(account string, candles CandlesFunc, logger *logging.Logger) (*Gate, error) {
+	limits := Limits{
+		MaxPosition:    viper.GetFloat64("risk.max-position"),
+		MaxExposure:    viper.GetFloat64("risk.max-exposure"),
+		MaxPositions:   viper.GetInt("risk.max-positions"),
+		DailyLoss:      viper.GetFloat64("risk.daily-loss"),
+		MaxCorrelation: viper.GetFloat64("risk.max-correlation"),
+		Window:         viper.GetInt("risk.correlation-window"),
+	}
+	sizing := Sizing{
+		Mode:        Mode(viper.GetString("risk.sizing.mode")),
+		Notional:    viper.GetFloat64("risk.sizing.notional"),
+		Percent:     viper.GetFloat64("risk.sizing.percent"),
+		Risk:        viper.GetFloat64("risk.sizing.risk"),
+		ATRPeriod:   viper.GetInt("risk.sizing.atr-period"),
+		ATRMultiple: viper.GetFloat64("risk.sizing.atr-multiple"),
+		WinRate:     viper.GetFloat64("risk.sizing.win-rate"),
+		Payoff:      viper.GetFloat64("risk.sizing.payoff"),
+		Fraction:    viper.GetFloat64("risk.sizing.fraction"),
+	}
+	if err := sizing.Check(); err != nil {
+		return nil, err
+	}
+	return New(account, limits, sizing, candles, logger), nil
+}
+
+// HandleRejections registers fn to be told of every rejection.
+func (g *Gate) HandleRejections(fn func(Rejection)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.handlers = append(g.handlers, fn)
+}
+
+// Check sizes a position s would open at price, returning its quantity, or
+// zero when the gate turns it down. Without a sizing mode the position is
+// worth value, the size of the account.
+func (g *Gate) Check(p Portfolio, s rules.Signal, price, value float64) float64 {
+	now := time.Now().UTC()
+	g.Update(p.Equity, now)
+	reject := func(cut bool, format string, args ...interface{}) {
+		g.reject(Rejection{
+			Time:      now.UnixNano() / int64(time.Millisecond),
+			Account:   g.Account,
+			Rule:      s.Rule,
+			Symbol:    s.Symbol,
+			Direction: s.Direction,
+			Reason:    fmt.Sprintf(format, args...),
+			Cut:       cut,
+		})
+	}
+	g.mu.Lock()
+	killed := g.state.Killed
+	g.mu.Unlock()
+	if killed != "" {
+		reject(false, "kill switch: %s", killed)
+		return 0
+	}
+	if !(price > 0) || p.Equity <= 0 {
+		reject(false, "no price or no equity")
+		return 0
+	}
+	if g.MaxPositions > 0 && len(p.Positions) >= g.MaxPositions {
+		reject(false, "%d positions open, the most allowed", len(p.Positions))
+		return 0
+	}
+	if g.Sizing.Mode != "" {
+		var why string
+		if value, why = g.value(s.Symbol, p.Equity, price); why != "" {
+			reject(false, "%s", why)
+			return 0
+		}
+	}
+	if !(value > 0) {
+		reject(false, "sized to nothing")
+		return 0
+	}
+	if err := g.correlated(p, s); err != "" {
+		reject(false, "%s", err)
+		return 0
+	}
+
+	var symbol, total float64
+	for _, e := range p.Positions {
+		total += math.Abs(e.Value)
+		if e.Symbol == s.Symbol {
+			symbol += math.Abs(e.Value)
+		}
+	}
+	if g.MaxPosition > 0 && symbol+value > g.MaxPosition {
+		if value = g.MaxPosition - symbol; value <= 0 {
+			reject(false, "%s position of %.2f at its limit of %.2f", s.Symbol, symbol, g.MaxPosition)
+			return 0
+		}
+		reject(true, "cut to %.2f by the %s position limit of %.2f", value, s.Symbol, g.MaxPosition)
+	}
+	if g.MaxExposure > 0 && total+value > g.MaxExposure {
+		if value = g.MaxExposure - total; value <= 0 {
+			reject(false, "exposure of %.2f at its limit of %.2f", total, g.MaxExposure)
+			return 0
+		}
+		reject(true, "cut to %.2f by the exposure limit of %.2f", value, g.MaxExposure)
+	}
+	return value / price
+}
+
+// Update follows the equity of the account, starting a day at the first
+// update past midnight UTC and tripping the kill switch once the day lost
+// DailyLoss of its starting equity.
+func (g *Gate) Update(equity float64, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	day := now.UTC().Format("2006-01-02")
+	if g.state.Day != day {
+		g.state.Day, g.state.Start, g.state.Killed = day, equity, ""
+		g.changed = true
+	}
+	if g.DailyLoss <= 0 || g.state.Killed != "" || g.state.Start <= 0 {
+		return
+	}
+	if loss := (g.state.Start - equity) / g.state.Start; loss >= g.DailyLoss {
+		g.state.Killed = fmt.Sprintf("lost %.2f%% of %.2f on %s", loss*100, g.state.Start, day)
+		g.changed = true
+		g.Debugf("risk: %s kill switch tripped, %s", g.Account, g.state.Killed)
+	}
+}
+
+// Kill trips the kill switch until Reset or the next day.
+func (g *Gate) Kill(reason string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.state.Killed = reason
+	g.changed = true
+}
+
+// Reset releases the kill switch, the day starting over from equity.
+func (g *Gate) Reset(equity float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.state.Killed, g.state.Start = "", equity
+	g.changed = true
+}
+
+// Killed returns why the kill switch is tripped, empty when it is not.
+func (g *Gate) Killed() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state.Killed
+}
+
+func (g *Gate) reject(r Rejection) {
+	if r.Cut {
+		g.Debugf("risk: %s %s %s on %s cut: %s", g.Account, r.Direction, r.Symbol, r.Rule, r.Reason)
+	} else {
+		g.Debugf("risk: %s %s %s on %s rejected: %s", g.Account, r.Direction, r.Symbol, r.Rule, r.Reason)
+	}
+	g.mu.Lock()
+	g.state.Rejections = append(g.state.Rejections, r)
+	if len(g.state.Rejections) > maxRejections {
+		g.state.Rejections = append([]Rejection(nil), g.state.Rejections[len(g.state.Rejections)-maxRejections:]...)
+	}
+	g.changed = true
+	handlers := g.handlers
+	g.mu.Unlock()
+	for _, fn := range handlers {
+		fn(r)
+	}
+}
+
+// Rejections returns the last rejections, oldest first.
+func (g *Gate) Rejections() []Rejection {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Rejection(nil), g.state.Rejections...)
+}
+
+// Snapshot returns the state and whether it changed since the last
+// snapshot.
+func (g *Gate) Snapshot() (State, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	changed := g.changed
+	g.changed = false
+	res := g.state
+	res.Rejections = append([]Rejection(nil), g.state.Rejections...)
+	return res, changed
+}
+
+// Restore brings back a state saved before.
+func (g *Gate) Restore(s State) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.state = s
+	g.state.Rejections = append([]Rejection(nil), s.Rejections...)
+}
+
+// correlated tells why s is too correlated with an open position, empty
+// when it is not. Longs and shorts on correlated symbols hedge each other,
+// so only positions adding to the same bet count.
+func (g *Gate) correlated(p Portfolio, s rules.Signal) string {
+	if g.MaxCorrelation <= 0 || len(p.Positions) == 0 {
+		return ""
+	}
+	returns, err := g.returns(s.Symbol)
+	if err != nil {
+		return fmt.Sprintf("no returns to correlate: %v", err)
+	}
+	short := s.Direction == rules.Sell
+	for _, e := range p.Positions {
+		if e.Symbol == s.Symbol {
+			continue
+		}
+		other, err := g.returns(e.Symbol)
+		if err != nil {
+			return fmt.Sprintf("no returns to correlate: %v", err)
+		}
+		c := correlation(returns, other)
+		if e.Short != short {
+			c = -c
+		}
+		if c > g.MaxCorrelation {
+			return fmt.Sprintf("correlation of %.2f with %s above %.2f", c, e.Symbol, g.MaxCorrelation)
+		}
+	}
+	return ""
+}
+
+// returns are the returns of the last Window candles of symbol.
+func (g *Gate) returns(symbol string) ([]float64, error) {
+	src, err := g.source(symbol)
+	if err != nil {
+		return nil, err
+	}
+	closes := src.Series(indicator.Close)
+	window := g.Window
+	if window < 2 {
+		window = 2
+	}
+	if len(closes) <= window {
+		return nil, fmt.Errorf("not enough %s candles", symbol)
+	}
+	closes = closes[len(closes)-window-1:]
+	res := make([]float64, window)
+	for i := range res {
+		res[i] = closes[i+1]/closes[i] - 1
+	}
+	return res, nil
+}
+
+// correlation is the Pearson correlation of the last values a and b share,
+// zero when either does not vary.
+func correlation(a, b []float64) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	a, b = a[len(a)-n:], b[len(b)-n:]
+	var ma, mb float64
+	for i := 0; i < n; i++ {
+		ma += a[i]
+		mb += b[i]
+	}
+	ma /= float64(n)
+	mb /= float64(n)
+	var cov, va, vb float64
+	for i := 0; i < n; i++ {
+		cov += (a[i] - ma) * (b[i] - mb)
+		va += (a[i] - ma) * (a[i] - ma)
+		vb += (b[i] - mb) * (b[i] - mb)
+	}
+	if va == 0 || vb == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(va*vb)
+}
+
+func (g *Gate) source(symbol string) (rules.Source, error) {
+	if g.candles == nil {
+		return nil, errors.New("no candles")
+	}
+	return g.candles(symbol)
+}
+
+func (g *Gate) eval(src rules.Source, expr string) ([]float64, error) {
+	e, err := rules.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return e.Eval(src)
+}
@@ -0,0 +1,236 @@
+package risk
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/logging"
+	"cryptoapi/internal/rules"
+
+	"github.com/sirupsen/logrus"
+)
+
+var logger = &logging.Logger{Logger: logrus.New()}
+
+// closes is a source of candles a unit high and low around the closes.
+type closes []float64
+
+func (c closes) Series(in indicator.Input) []float64 {
+	res := make([]float64, len(c))
+	for i, v := range c {
+		switch in {
+		case indicator.High:
+			res[i] = v + 1
+		case indicator.Low:
+			res[i] = v - 1
+		default:
+			res[i] = v
+		}
+	}
+	return res
+}
+
+func (c closes) CloseTimes() []int64 {
+	res := make([]int64, len(c))
+	for i := range res {
+		res[i] = int64(i)
+	}
+	return res
+}
+
+func (c closes) Frame(string) (rules.Source, error) {
+	return nil, errors.New("no other intervals")
+}
+
+// market serves the candles of the map.
+func market(candles map[string]closes) CandlesFunc {
+	return func(symbol string) (rules.Source, error) {
+		c, ok := candles[symbol]
+		if !ok {
+			return nil, errors.New("no candles")
+		}
+		return c, nil
+	}
+}
+
+func buy(symbol string) rules.Signal {
+	return rules.Signal{Rule: "rule", Symbol: symbol, Direction: rules.Buy}
+}
+
+func TestLimits(t *testing.T) {
+	btc := Exposure{Symbol: "BTCUSDT", Value: 100}
+	eth := Exposure{Symbol: "ETHUSDT", Value: 100, Short: true}
+	for _, tt := range []struct {
+		name      string
+		limits    Limits
+		positions []Exposure
+		price     float64
+		// quantity at a price of 10 for a value of 100, and whether the
+		// position was cut rather than turned down
+		want float64
+		cut  bool
+	}{
+		{"none", Limits{}, []Exposure{btc, eth}, 10, 10, false},
+		{"symbol cut", Limits{MaxPosition: 150}, []Exposure{btc, eth}, 10, 5, true},
+		{"symbol full", Limits{MaxPosition: 100}, []Exposure{btc}, 10, 0, false},
+		{"other symbol", Limits{MaxPosition: 100}, []Exposure{eth}, 10, 10, false},
+		// shorts add to the exposure too
+		{"exposure cut", Limits{MaxExposure: 260}, []Exposure{btc, eth}, 10, 6, true},
+		{"exposure full", Limits{MaxExposure: 200}, []Exposure{btc, eth}, 10, 0, false},
+		{"both cut", Limits{MaxPosition: 180, MaxExposure: 250}, []Exposure{btc, eth}, 10, 5, true},
+		{"max positions", Limits{MaxPositions: 2}, []Exposure{btc, eth}, 10, 0, false},
+		{"under max positions", Limits{MaxPositions: 2}, []Exposure{eth}, 10, 10, false},
+		{"no price", Limits{}, nil, 0, 0, false},
+	} {
+		g := New("paper", tt.limits, Sizing{}, nil, logger)
+		got := g.Check(Portfolio{Equity: 1000, Positions: tt.positions}, buy("BTCUSDT"), tt.price, 100)
+		if !(math.Abs(got-tt.want) < 1e-9) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		r := g.Rejections()
+		switch {
+		case tt.want == 10 && len(r) != 0:
+			t.Errorf("%s: rejections %+v", tt.name, r)
+		case tt.want != 10 && (len(r) == 0 || r[len(r)-1].Cut != tt.cut):
+			t.Errorf("%s: rejections %+v, want the last cut %v", tt.name, r, tt.cut)
+		}
+	}
+}
+
+func TestKillSwitch(t *testing.T) {
+	g := New("live", Limits{DailyLoss: 0.1}, Sizing{}, nil, logger)
+	day := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	g.Update(1000, day)
+	g.Update(901, day.Add(time.Hour))
+	if g.Killed() != "" {
+		t.Fatalf("killed after losing less than 10%%: %s", g.Killed())
+	}
+	g.Update(900, day.Add(2*time.Hour))
+	if !strings.Contains(g.Killed(), "lost 10.00% of 1000.00 on 2021-03-01") {
+		t.Fatalf("killed %q", g.Killed())
+	}
+	// a recovery the same day does not release it
+	g.Update(1000, day.Add(3*time.Hour))
+	if g.Killed() == "" {
+		t.Error("released the same day")
+	}
+	// the next day starts over from the equity it is first updated with
+	g.Update(900, day.Add(14*time.Hour))
+	if s, _ := g.Snapshot(); s.Killed != "" || s.Day != "2021-03-02" || s.Start != 900 {
+		t.Errorf("next day state %+v", s)
+	}
+	g.Update(811, day.Add(15*time.Hour))
+	if g.Killed() != "" {
+		t.Error("killed on the loss of the day before")
+	}
+	g.Update(810, day.Add(16*time.Hour))
+	if g.Killed() == "" {
+		t.Error("not killed on the second day")
+	}
+	g.Reset(810)
+	if g.Killed() != "" {
+		t.Error("still killed after a reset")
+	}
+
+	// the gate turns everything down while tripped
+	now := time.Now()
+	g.Update(1000, now)
+	g.Kill("by hand")
+	if got := g.Check(Portfolio{Equity: 1000}, buy("BTCUSDT"), 10, 100); got != 0 {
+		t.Errorf("got %v while killed", got)
+	}
+	if r := g.Rejections(); len(r) != 1 || r[0].Reason != "kill switch: by hand" {
+		t.Errorf("rejections %+v", r)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	// ETH moves with BTC, SOL against it
+	candles := map[string]closes{
+		"BTCUSDT": {100, 101, 99, 102, 104, 103},
+		"ETHUSDT": {10, 10.1, 9.9, 10.2, 10.4, 10.3},
+		"SOLUSDT": {50, 49.5, 50.5, 49, 48, 48.5},
+	}
+	for _, tt := range []struct {
+		name     string
+		position Exposure
+		side     rules.Direction
+		ok       bool
+	}{
+		{"long on long", Exposure{Symbol: "ETHUSDT", Value: 100}, rules.Buy, false},
+		{"short on short", Exposure{Symbol: "ETHUSDT", Value: 100, Short: true}, rules.Sell, false},
+		// opposite sides on correlated symbols hedge each other
+		{"long on short", Exposure{Symbol: "ETHUSDT", Value: 100, Short: true}, rules.Buy, true},
+		{"short on long", Exposure{Symbol: "ETHUSDT", Value: 100}, rules.Sell, true},
+		// the same side on symbols moving apart does too
+		{"long on long against", Exposure{Symbol: "SOLUSDT", Value: 100}, rules.Buy, true},
+		{"long on short against", Exposure{Symbol: "SOLUSDT", Value: 100, Short: true}, rules.Buy, false},
+		{"same symbol", Exposure{Symbol: "BTCUSDT", Value: 100}, rules.Buy, true},
+		{"no candles", Exposure{Symbol: "XRPUSDT", Value: 100}, rules.Buy, false},
+	} {
+		g := New("paper", Limits{MaxCorrelation: 0.8, Window: 5}, Sizing{}, market(candles), logger)
+		s := buy("BTCUSDT")
+		s.Direction = tt.side
+		got := g.Check(Portfolio{Equity: 1000, Positions: []Exposure{tt.position}}, s, 10, 100)
+		if (got > 0) != tt.ok {
+			t.Errorf("%s: got %v, want allowed %v (%+v)", tt.name, got, tt.ok, g.Rejections())
+		}
+	}
+	if c := correlation([]float64{1, 2, 3}, []float64{5, 5, 5}); c != 0 {
+		t.Errorf("correlation %v with a flat series", c)
+	}
+}
+
+func TestSizing(t *testing.T) {
+	candles := market(map[string]closes{"BTCUSDT": {10, 10, 10, 10, 10, 10}})
+	for _, tt := range []struct {
+		sizing Sizing
+		// quantity at 10 with an equity of 1000
+		want float64
+	}{
+		{Sizing{Mode: Fixed, Notional: 200}, 20},
+		{Sizing{Mode: Percent, Percent: 0.1}, 10},
+		// losing 1% of the equity at a stop two ATRs of 2 away
+		{Sizing{Mode: ATR, Risk: 0.01, ATRPeriod: 3, ATRMultiple: 2}, 2.5},
+		// half the Kelly fraction of 0.6 - 0.4/2
+		{Sizing{Mode: Kelly, WinRate: 0.6, Payoff: 2, Fraction: 0.5}, 20},
+		{Sizing{Mode: Kelly, WinRate: 0.3, Payoff: 1, Fraction: 1}, 0},
+		{Sizing{Mode: Kelly, WinRate: 0.5, Payoff: 1, Fraction: 1}, 0},
+		// not enough candles for the ATR
+		{Sizing{Mode: ATR, Risk: 0.01, ATRPeriod: 10, ATRMultiple: 2}, 0},
+	} {
+		if err := tt.sizing.Check(); err != nil {
+			t.Fatal(err)
+		}
+		g := New("paper", Limits{}, tt.sizing, candles, logger)
+		got := g.Check(Portfolio{Equity: 1000}, buy("BTCUSDT"), 10, 5000)
+		if !(math.Abs(got-tt.want) < 1e-9) {
+			t.Errorf("%+v: got %v, want %v", tt.sizing, got, tt.want)
+		}
+		if tt.want == 0 && len(g.Rejections()) != 1 {
+			t.Errorf("%+v: rejections %+v", tt.sizing, g.Rejections())
+		}
+	}
+	g := New("paper", Limits{}, Sizing{Mode: Kelly, WinRate: 0.3, Payoff: 1, Fraction: 1}, candles, logger)
+	g.Check(Portfolio{Equity: 1000}, buy("BTCUSDT"), 10, 5000)
+	if r := g.Rejections(); len(r) != 1 || !strings.HasPrefix(r[0].Reason, "no edge") {
+		t.Errorf("rejections %+v", r)
+	}
+
+	for _, s := range []Sizing{
+		{Mode: Fixed},
+		{Mode: Percent, Percent: -1},
+		{Mode: ATR, Risk: 0.01, ATRMultiple: 2},
+		{Mode: Kelly, WinRate: 1.5, Payoff: 2, Fraction: 1},
+		{Mode: Kelly, WinRate: 0.5, Fraction: 1},
+		{Mode: "martingale"},
+	} {
+		if err := s.Check(); err == nil {
+			t.Errorf("%+v: no error", s)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+)
+
+// Mode is how the size of a new position is decided.
+type Mode string
+
+const (
+	// Notional of the quote asset
+	Fixed Mode = "notional"
+	// Percent of the equity
+	Percent Mode = "percent"
+	// as much as loses Risk of the equity at a stop ATRMultiple ATRs away
+	ATR Mode = "atr"
+	// Fraction of the Kelly criterion of WinRate and Payoff, of the equity
+	Kelly Mode = "kelly"
+)
+
+// Sizing decides the size of the new positions, left to the account when
+// Mode is empty.
+type Sizing struct {
+	Mode     Mode    `json:"mode"`
+	Notional float64 `json:"notional,omitempty"`
+	// fractions of the equity, e.g. 0.1 for 10%
+	Percent float64 `json:"percent,omitempty"`
+	Risk    float64 `json:"risk,omitempty"`
+	// ATR of ATRPeriod candles of the interval of the gate
+	ATRPeriod   int     `json:"atr_period,omitempty"`
+	ATRMultiple float64 `json:"atr_multiple,omitempty"`
+	// part of the trades won and average win over average loss
+	WinRate  float64 `json:"win_rate,omitempty"`
+	Payoff   float64 `json:"payoff,omitempty"`
+	Fraction float64 `json:"fraction,omitempty"`
+}
+
+func (s Sizing) Check() error {
+	switch s.Mode {
+	case "":
+	case Fixed:
+		if s.Notional <= 0 {
+			return fmt.Errorf("sizing %s needs a positive notional", s.Mode)
+		}
+	case Percent:
+		if s.Percent <= 0 {
+			return fmt.Errorf("sizing %s needs a positive percent", s.Mode)
+		}
+	case ATR:
+		if s.Risk <= 0 || s.ATRPeriod < 1 || s.ATRMultiple <= 0 {
+			return fmt.Errorf("sizing %s needs a positive risk, atr period and multiple", s.Mode)
+		}
+	case Kelly:
+		if s.WinRate <= 0 || s.WinRate > 1 || s.Payoff <= 0 || s.Fraction <= 0 {
+			return fmt.Errorf("sizing %s needs a win rate, a payoff and a fraction", s.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown sizing mode %q", s.Mode)
+	}
+	return nil
+}
+
+// Kelly is the fraction of the equity the Kelly criterion stakes, negative
+// without an edge.
+func (s Sizing) Kelly() float64 {
+	return s.WinRate - (1-s.WinRate)/s.Payoff
+}
+
+// value returns the value in the quote asset of a new position of symbol at
+// price, and why there is none.
+func (g *Gate) value(symbol string, equity, price float64) (float64, string) {
+	s := g.Sizing
+	switch s.Mode {
+	case Fixed:
+		return s.Notional, ""
+	case Percent:
+		return equity * s.Percent, ""
+	case ATR:
+		atr, err := g.atr(symbol)
+		if err != nil {
+			return 0, fmt.Sprintf("no atr: %v", err)
+		}
+		qty := equity * s.Risk / (s.ATRMultiple * atr)
+		return qty * price, ""
+	case Kelly:
+		f := s.Kelly()
+		if f <= 0 {
+			return 0, fmt.Sprintf("no edge, kelly fraction %.4f", f)
+		}
+		return equity * f * s.Fraction, ""
+	}
+	return 0, fmt.Sprintf("unknown sizing mode %q", s.Mode)
+}
+
+func (g *Gate) atr(symbol string) (float64, error) {
+	src, err := g.source(symbol)
+	if err != nil {
+		return 0, err
+	}
+	v, err := g.eval(src, fmt.Sprintf("atr(%d)", g.Sizing.ATRPeriod))
+	if err != nil {
+		return 0, err
+	}
+	if n := len(v); n > 0 && !math.IsNaN(v[n-1]) && v[n-1] > 0 {
+		return v[n-1], nil
+	}
+	return 0, fmt.Errorf("not enough %s candles", symbol)
+}
@@ -0,0 +1,759 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"cryptoapi/internal/aggregate"
+	"cryptoapi/internal/bars"
+	"cryptoapi/internal/indicator"
+)
+
+// Expressions are evaluated over whole series at once: every node yields a
+// value per candle, conditions 1 or 0, and NaN where not known yet, e.g.
+// before an indicator's lookback.
+//
+//	expr    = and { "OR" and }
+//	and     = not { "AND" not }
+//	not     = "NOT" not | cond
+//	cond    = sum [ op sum ] [ "within" number [ "bars" ] ]
+//	op      = ">" | "<" | ">=" | "<=" | "==" | "!=" | "above" | "below" |
+//	          "crosses_above" | "crosses_below" | "crosses" |
+//	          "crossover" | "crossunder"
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary [ "[" number "]" ] [ "@" interval ]
+//	primary = number | "(" expr ")" | column | indicator
+//	indicator = [ library "." ] name [ "(" [ arg { "," arg } ] ")" ] [ "." output ]
+//	arg     = number | column | option "=" number
+//
+// Positional numbers set the indicator options in order, columns bind its
+// real inputs in order, and [n] reads the value n candles back.
+//
+// A term followed by @interval, e.g. rsi(14)@1d or close[1]@4h, is computed
+// on the candles of that interval, virtual ones included, and each candle of
+// the rule sees the value of the last candle of that interval closed by its
+// own close, so a higher timeframe never leaks a candle still forming.
+
+type node interface {
+	eval(e *env) ([]float64, error)
+}
+
+// Source is the candles a rule is evaluated on.
+type Source interface {
+	indicator.Source
+	// CloseTimes are the close times of the candles in milliseconds.
+	CloseTimes() []int64
+	// Frame returns the candles of the same symbol at another interval.
+	Frame(interval string) (Source, error)
+}
+
+// env is what an expression is evaluated against.
+type env struct {
+	src Source
+	n   int
+	// indicator outputs already computed, by call
+	cache map[string][][]float64
+	// environments of the other intervals used
+	frames map[string]*env
+}
+
+func newEnv(src Source) *env {
+	return &env{
+		src:    src,
+		n:      len(src.CloseTimes()),
+		cache:  make(map[string][][]float64),
+		frames: make(map[string]*env),
+	}
+}
+
+// frame evaluates x on the candles of another interval, aligned on the
+// candles of the rule.
+type frame struct {
+	x        node
+	interval string
+}
+
+func (f frame) eval(e *env) ([]float64, error) {
+	sub, ok := e.frames[f.interval]
+	if !ok {
+		src, err := e.src.Frame(f.interval)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.interval, err)
+		}
+		sub = newEnv(src)
+		e.frames[f.interval] = sub
+	}
+	x, err := f.x.eval(sub)
+	if err != nil {
+		return nil, err
+	}
+	times, subTimes := e.src.CloseTimes(), sub.src.CloseTimes()
+	res := make([]float64, e.n)
+	j := -1
+	for i := range res {
+		for j+1 < len(subTimes) && subTimes[j+1] <= times[i] {
+			j++
+		}
+		if j < 0 {
+			res[i] = math.NaN()
+		} else {
+			res[i] = x[j]
+		}
+	}
+	return res, nil
+}
+
+type number float64
+
+func (v number) eval(e *env) ([]float64, error) {
+	res := make([]float64, e.n)
+	for i := range res {
+		res[i] = float64(v)
+	}
+	return res, nil
+}
+
+type column indicator.Input
+
+func (c column) eval(e *env) ([]float64, error) {
+	s := e.src.Series(indicator.Input(c))
+	if s == nil {
+		return nil, fmt.Errorf("no %s series", c)
+	}
+	return s, nil
+}
+
+var columns = map[string]indicator.Input{
+	"open":                   indicator.Open,
+	"high":                   indicator.High,
+	"low":                    indicator.Low,
+	"close":                  indicator.Close,
+	"volume":                 indicator.Volume,
+	"quote_volume":           indicator.QuoteVolume,
+	"trades":                 indicator.Trades,
+	"taker_buy_volume":       indicator.TakerBuyVolume,
+	"taker_buy_quote_volume": indicator.TakerBuyQuoteVolume,
+}
+
+type call struct {
+	def     *indicator.Definition
+	options map[string]float64
+	reals   []indicator.Input
+	output  int
+	key     string
+}
+
+func (c *call) eval(e *env) ([]float64, error) {
+	out, ok := e.cache[c.key]
+	if !ok {
+		var err error
+		out, err = c.def.Invoke(e.src, c.options, c.reals...)
+		if err != nil {
+			return nil, err
+		}
+		lookback, err := c.def.Lookback(c.options)
+		if err != nil {
+			return nil, err
+		}
+		// the padding before the lookback is not a value
+		for _, o := range out {
+			for i := 0; i < lookback && i < len(o); i++ {
+				o[i] = math.NaN()
+			}
+		}
+		e.cache[c.key] = out
+	}
+	return out[c.output], nil
+}
+
+type shift struct {
+	x    node
+	bars int
+}
+
+func (s shift) eval(e *env) ([]float64, error) {
+	x, err := s.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(x))
+	for i := range res {
+		if i < s.bars {
+			res[i] = math.NaN()
+		} else {
+			res[i] = x[i-s.bars]
+		}
+	}
+	return res, nil
+}
+
+type binary struct {
+	op   string
+	a, b node
+}
+
+func truth(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func (n binary) eval(e *env) ([]float64, error) {
+	a, err := n.a.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	b, err := n.b.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(a))
+	for i := range res {
+		x, y := a[i], b[i]
+		switch n.op {
+		case "and":
+			switch {
+			case x == 0 || y == 0:
+				res[i] = 0
+			case math.IsNaN(x) || math.IsNaN(y):
+				res[i] = math.NaN()
+			default:
+				res[i] = 1
+			}
+			continue
+		case "or":
+			switch {
+			case x == 1 || y == 1:
+				res[i] = 1
+			case math.IsNaN(x) || math.IsNaN(y):
+				res[i] = math.NaN()
+			default:
+				res[i] = 0
+			}
+			continue
+		}
+		if math.IsNaN(x) || math.IsNaN(y) {
+			res[i] = math.NaN()
+			continue
+		}
+		switch n.op {
+		case "+":
+			res[i] = x + y
+		case "-":
+			res[i] = x - y
+		case "*":
+			res[i] = x * y
+		case "/":
+			res[i] = x / y
+		case ">", "above":
+			res[i] = truth(x > y)
+		case "<", "below":
+			res[i] = truth(x < y)
+		case ">=":
+			res[i] = truth(x >= y)
+		case "<=":
+			res[i] = truth(x <= y)
+		case "==":
+			res[i] = truth(x == y)
+		case "!=":
+			res[i] = truth(x != y)
+		case "crosses_above", "crosses_below", "crosses":
+			if i == 0 || math.IsNaN(a[i-1]) || math.IsNaN(b[i-1]) {
+				res[i] = math.NaN()
+				continue
+			}
+			up := a[i-1] <= b[i-1] && x > y
+			down := a[i-1] >= b[i-1] && x < y
+			switch n.op {
+			case "crosses_above":
+				res[i] = truth(up)
+			case "crosses_below":
+				res[i] = truth(down)
+			default:
+				res[i] = truth(up || down)
+			}
+		}
+	}
+	return res, nil
+}
+
+type not struct {
+	x node
+}
+
+func (n not) eval(e *env) ([]float64, error) {
+	x, err := n.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(x))
+	for i, v := range x {
+		switch {
+		case math.IsNaN(v):
+			res[i] = v
+		default:
+			res[i] = truth(v == 0)
+		}
+	}
+	return res, nil
+}
+
+type neg struct {
+	x node
+}
+
+func (n neg) eval(e *env) ([]float64, error) {
+	x, err := n.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(x))
+	for i, v := range x {
+		res[i] = -v
+	}
+	return res, nil
+}
+
+// within is true when its condition held on any of the last bars candles.
+type within struct {
+	x    node
+	bars int
+}
+
+func (w within) eval(e *env) ([]float64, error) {
+	x, err := w.x.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(x))
+	for i := range res {
+		res[i] = 0
+		for j := i; j > i-w.bars && j >= 0; j-- {
+			if x[j] == 1 {
+				res[i] = 1
+				break
+			}
+			if math.IsNaN(x[j]) {
+				res[i] = math.NaN()
+			}
+		}
+	}
+	return res, nil
+}
+
+type token struct {
+	text string
+	num  bool
+	pos  int
+}
+
+func lex(s string) ([]token, error) {
+	var res []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(s) && unicode.IsDigit(rune(s[i+1]))):
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.' || s[j] == 'e' || s[j] == 'E' ||
+				((s[j] == '-' || s[j] == '+') && (s[j-1] == 'e' || s[j-1] == 'E'))) {
+				j++
+			}
+			res = append(res, token{text: s[i:j], num: true, pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			res = append(res, token{text: s[i:j], pos: i})
+			i = j
+		case strings.ContainsRune("<>=!", c) && i+1 < len(s) && s[i+1] == '=':
+			res = append(res, token{text: s[i : i+2], pos: i})
+			i += 2
+		case c == '@':
+			// the interval, e.g. 4h or ha:1h, is one token
+			res = append(res, token{text: "@", pos: i})
+			j := i + 1
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("()[],=+-*/<>!@", rune(s[j])) {
+				j++
+			}
+			res = append(res, token{text: s[i+1 : j], pos: i + 1})
+			i = j
+		case strings.ContainsRune("()[],.=+-*/<>", c):
+			res = append(res, token{text: s[i : i+1], pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", c, i)
+		}
+	}
+	return res, nil
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+var errEnd = errors.New("unexpected end of expression")
+
+// Expr is a compiled expression.
+type Expr struct {
+	text string
+	root node
+}
+
+// Compile parses an expression, checking its indicators and options.
+func Compile(s string) (*Expr, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.i < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q at %d", p.toks[p.i].text, p.toks[p.i].pos)
+	}
+	return &Expr{text: s, root: n}, nil
+}
+
+func (x *Expr) String() string {
+	return x.text
+}
+
+// Eval computes the expression on every candle of src.
+func (x *Expr) Eval(src Source) ([]float64, error) {
+	return x.root.eval(newEnv(src))
+}
+
+func (p *parser) peek() string {
+	if p.i < len(p.toks) {
+		return strings.ToLower(p.toks[p.i].text)
+	}
+	return ""
+}
+
+func (p *parser) accept(texts ...string) (string, bool) {
+	t := p.peek()
+	for _, text := range texts {
+		if t == text && t != "" {
+			p.i++
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(text string) error {
+	if _, ok := p.accept(text); ok {
+		return nil
+	}
+	if p.i == len(p.toks) {
+		return errEnd
+	}
+	return fmt.Errorf("expected %q at %d, got %q", text, p.toks[p.i].pos, p.toks[p.i].text)
+}
+
+func (p *parser) number() (float64, error) {
+	if p.i == len(p.toks) {
+		return 0, errEnd
+	}
+	t := p.toks[p.i]
+	if !t.num {
+		return 0, fmt.Errorf("expected a number at %d, got %q", t.pos, t.text)
+	}
+	v, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q at %d", t.text, t.pos)
+	}
+	p.i++
+	return v, nil
+}
+
+func (p *parser) count() (int, error) {
+	v, err := p.number()
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v != math.Trunc(v) {
+		return 0, fmt.Errorf("%v is not a count of candles", v)
+	}
+	return int(v), nil
+}
+
+func (p *parser) expr() (node, error) {
+	n, err := p.and()
+	for err == nil {
+		if _, ok := p.accept("or"); !ok {
+			break
+		}
+		var b node
+		if b, err = p.and(); err == nil {
+			n = binary{"or", n, b}
+		}
+	}
+	return n, err
+}
+
+func (p *parser) and() (node, error) {
+	n, err := p.not()
+	for err == nil {
+		if _, ok := p.accept("and"); !ok {
+			break
+		}
+		var b node
+		if b, err = p.not(); err == nil {
+			n = binary{"and", n, b}
+		}
+	}
+	return n, err
+}
+
+func (p *parser) not() (node, error) {
+	if _, ok := p.accept("not"); ok {
+		n, err := p.not()
+		return not{n}, err
+	}
+	return p.cond()
+}
+
+var synonyms = map[string]string{
+	"crossover":  "crosses_above",
+	"crossunder": "crosses_below",
+}
+
+func (p *parser) cond() (node, error) {
+	n, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := p.accept(">", "<", ">=", "<=", "==", "!=", "above", "below",
+		"crosses_above", "crosses_below", "crosses", "crossover", "crossunder"); ok {
+		b, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := synonyms[op]; ok {
+			op = s
+		}
+		n = binary{op, n, b}
+	}
+	if _, ok := p.accept("within"); ok {
+		bars, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		if bars < 1 {
+			return nil, errors.New("within needs at least 1 candle")
+		}
+		p.accept("bars")
+		n = within{n, bars}
+	}
+	return n, nil
+}
+
+func (p *parser) sum() (node, error) {
+	n, err := p.product()
+	for err == nil {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			break
+		}
+		var b node
+		if b, err = p.product(); err == nil {
+			n = binary{op, n, b}
+		}
+	}
+	return n, err
+}
+
+func (p *parser) product() (node, error) {
+	n, err := p.unary()
+	for err == nil {
+		op, ok := p.accept("*", "/")
+		if !ok {
+			break
+		}
+		var b node
+		if b, err = p.unary(); err == nil {
+			n = binary{op, n, b}
+		}
+	}
+	return n, err
+}
+
+func (p *parser) unary() (node, error) {
+	if _, ok := p.accept("-"); ok {
+		n, err := p.unary()
+		return neg{n}, err
+	}
+	n, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("["); ok {
+		bars, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		n = shift{n, bars}
+	}
+	if _, ok := p.accept("@"); ok {
+		t := p.toks[p.i]
+		p.i++
+		if err := checkInterval(t.text); err != nil {
+			return nil, fmt.Errorf("%v at %d", err, t.pos)
+		}
+		n = frame{n, t.text}
+	}
+	return n, nil
+}
+
+func checkInterval(interval string) error {
+	if strings.Contains(interval, ":") {
+		_, err := bars.Parse(interval)
+		return err
+	}
+	_, err := aggregate.ParseInterval(interval)
+	return err
+}
+
+func (p *parser) primary() (node, error) {
+	if p.i == len(p.toks) {
+		return nil, errEnd
+	}
+	t := p.toks[p.i]
+	switch {
+	case t.num:
+		v, err := p.number()
+		return number(v), err
+	case t.text == "(":
+		p.i++
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	}
+	if !isIdent(t.text) {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	p.i++
+	name := strings.ToLower(t.text)
+	if in, ok := columns[name]; ok {
+		return column(in), nil
+	}
+	return p.call(name, t.pos)
+}
+
+func isIdent(s string) bool {
+	r := rune(s[0])
+	return unicode.IsLetter(r) || r == '_'
+}
+
+var libraries = map[string]bool{"talib": true, "tulip": true, "flow": true}
+
+func (p *parser) call(name string, pos int) (node, error) {
+	if libraries[name] {
+		if err := p.expect("."); err != nil {
+			return nil, err
+		}
+		if p.i == len(p.toks) {
+			return nil, errEnd
+		}
+		name += "." + strings.ToLower(p.toks[p.i].text)
+		p.i++
+	}
+	def, err := indicator.Find(name)
+	if err != nil {
+		return nil, fmt.Errorf("%v at %d", err, pos)
+	}
+	c := &call{def: def, options: make(map[string]float64)}
+	positional := 0
+	if _, ok := p.accept("("); ok {
+		for args := 0; p.peek() != ")"; args++ {
+			if args > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.arg(c, &positional); err != nil {
+				return nil, err
+			}
+		}
+		p.i++
+	}
+	if _, err := def.Resolve(c.options); err != nil {
+		return nil, err
+	}
+	if p.peek() == "." {
+		p.i++
+		if p.i == len(p.toks) {
+			return nil, errEnd
+		}
+		output := strings.ToLower(p.toks[p.i].text)
+		p.i++
+		c.output = -1
+		for i, o := range def.Outputs {
+			if strings.ToLower(o) == output {
+				c.output = i
+			}
+		}
+		if c.output < 0 {
+			return nil, fmt.Errorf("%s has no output %q", def.ID(), output)
+		}
+	}
+	c.key = fmt.Sprintf("%s%v%v", def.ID(), c.options, c.reals)
+	return c, nil
+}
+
+func (p *parser) arg(c *call, positional *int) error {
+	if p.i == len(p.toks) {
+		return errEnd
+	}
+	t := p.toks[p.i]
+	if t.num {
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		if *positional >= len(c.def.Options) {
+			return fmt.Errorf("%s takes %d options", c.def.ID(), len(c.def.Options))
+		}
+		c.options[c.def.Options[*positional].Name] = v
+		*positional++
+		return nil
+	}
+	if !isIdent(t.text) {
+		return fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	p.i++
+	name := strings.ToLower(t.text)
+	if _, ok := p.accept("="); ok {
+		v, err := p.number()
+		if err != nil {
+			return err
+		}
+		c.options[name] = v
+		return nil
+	}
+	in, ok := columns[name]
+	if !ok {
+		return fmt.Errorf("unknown column %q at %d", t.text, t.pos)
+	}
+	c.reals = append(c.reals, in)
+	return nil
+}
@@ -0,0 +1,294 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"cryptoapi/internal/indicator"
+)
+
+const minute = int64(60000)
+
+// candles is a source of one minute candles, high a unit above the close
+// and low a unit below, its other intervals coming from frames.
+type candles struct {
+	close  []float64
+	times  []int64
+	frames map[string]candles
+}
+
+func closes(prices ...float64) candles {
+	c := candles{close: prices}
+	for i := range prices {
+		c.times = append(c.times, int64(i+1)*minute-1)
+	}
+	return c
+}
+
+func (c candles) Series(in indicator.Input) []float64 {
+	res := make([]float64, len(c.close))
+	for i, v := range c.close {
+		switch in {
+		case indicator.Close, indicator.Open, indicator.Real:
+			res[i] = v
+		case indicator.High:
+			res[i] = v + 1
+		case indicator.Low:
+			res[i] = v - 1
+		case indicator.Volume:
+			res[i] = 1
+		default:
+			return nil
+		}
+	}
+	return res
+}
+
+func (c candles) CloseTimes() []int64 {
+	return c.times
+}
+
+func (c candles) Frame(interval string) (Source, error) {
+	f, ok := c.frames[interval]
+	if !ok {
+		return nil, fmt.Errorf("no %s candles", interval)
+	}
+	return f, nil
+}
+
+// same tells whether two series are equal, NaN matching NaN.
+func same(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+var nan = math.NaN()
+
+// evalTests compiles and evaluates each expression on src.
+func evalTests(t *testing.T, src Source, tests []struct {
+	expr string
+	want []float64
+}) {
+	t.Helper()
+	for _, tt := range tests {
+		x, err := Compile(tt.expr)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		got, err := x.Eval(src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+		} else if !same(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	evalTests(t, closes(1, 2, 3), []struct {
+		expr string
+		want []float64
+	}{
+		{"1 + 2 * 3", []float64{7, 7, 7}},
+		{"(1 + 2) * 3", []float64{9, 9, 9}},
+		{"10 - 2 - 3", []float64{5, 5, 5}},
+		{"8 / 2 / 2", []float64{2, 2, 2}},
+		{"-close * 2", []float64{-2, -4, -6}},
+		{"2 - -close", []float64{3, 4, 5}},
+		{"close + 1 > 2 * close - 1", []float64{1, 0, 0}},
+		{"close above 1", []float64{0, 1, 1}},
+		{"close below 2", []float64{1, 0, 0}},
+		{"close >= 2", []float64{0, 1, 1}},
+		{"close <= 2", []float64{1, 1, 0}},
+		{"close != 2", []float64{1, 0, 1}},
+		// AND binds tighter than OR, NOT tighter than AND
+		{"close > 1 and close < 3 or close == 1", []float64{1, 1, 0}},
+		{"close == 1 or close > 1 and close < 3", []float64{1, 1, 0}},
+		{"(close == 1 or close > 1) and close < 3", []float64{1, 1, 0}},
+		{"not close > 1 and close < 3", []float64{1, 0, 0}},
+		{"NOT (close > 1 AND close < 3)", []float64{1, 0, 1}},
+		{"not not close == 2", []float64{0, 1, 0}},
+	})
+}
+
+func TestCrosses(t *testing.T) {
+	evalTests(t, closes(1, 3, 3, 1, 2, 3), []struct {
+		expr string
+		want []float64
+	}{
+		// touching the level is not crossing it, leaving it after is
+		{"close crosses_above 2", []float64{nan, 1, 0, 0, 0, 1}},
+		{"close crossover 2", []float64{nan, 1, 0, 0, 0, 1}},
+		{"close crosses_below 2", []float64{nan, 0, 0, 1, 0, 0}},
+		{"close crossunder 2", []float64{nan, 0, 0, 1, 0, 0}},
+		{"close crosses 2", []float64{nan, 1, 0, 1, 0, 1}},
+		{"2 crosses_above close", []float64{nan, 0, 0, 1, 0, 0}},
+		// a cross needs the candle before known
+		{"close[1] crosses_above 2", []float64{nan, nan, 1, 0, 0, 0}},
+	})
+}
+
+func TestWithin(t *testing.T) {
+	evalTests(t, closes(3, 1, 1, 3, 1), []struct {
+		expr string
+		want []float64
+	}{
+		{"close > 2 within 1", []float64{1, 0, 0, 1, 0}},
+		{"close > 2 within 2 bars", []float64{1, 1, 0, 1, 1}},
+		{"close > 2 within 3", []float64{1, 1, 1, 1, 1}},
+		{"close crosses_above 2 within 2", []float64{nan, nan, 0, 1, 1}},
+		// true once known, unknown while only unknown or false candles are seen
+		{"close[1] > 2 within 2", []float64{nan, 1, 1, 0, 1}},
+		{"close[2] > 2 within 2", []float64{nan, nan, 1, 1, 0}},
+	})
+}
+
+func TestShift(t *testing.T) {
+	evalTests(t, closes(1, 2, 3, 4), []struct {
+		expr string
+		want []float64
+	}{
+		{"close[0]", []float64{1, 2, 3, 4}},
+		{"close[1]", []float64{nan, 1, 2, 3}},
+		{"close[3]", []float64{nan, nan, nan, 1}},
+		{"close[4]", []float64{nan, nan, nan, nan}},
+		{"close - close[1]", []float64{nan, 1, 1, 1}},
+		{"(close + 1)[2]", []float64{nan, nan, 2, 3}},
+		{"-close[1]", []float64{nan, -1, -2, -3}},
+	})
+}
+
+func TestOptions(t *testing.T) {
+	sma := []float64{nan, nan, 2, 3, 4, 5}
+	evalTests(t, closes(1, 2, 3, 4, 5, 6), []struct {
+		expr string
+		want []float64
+	}{
+		{"sma(3)", sma},
+		{"SMA(3)", sma},
+		{"talib.sma(3)", sma},
+		{"sma(timeperiod=3)", sma},
+		{"sma(close, 3)", sma},
+		{"sma(3, high)", []float64{nan, nan, 3, 4, 5, 6}},
+		{"sma(high, timeperiod=3) - sma(low, timeperiod=3)", []float64{nan, nan, 2, 2, 2, 2}},
+		// the lookback before an indicator's first value is unknown
+		{"sma(3) > 0", []float64{nan, nan, 1, 1, 1, 1}},
+		{"bbands(3, nbdevup=0).upperband", sma},
+		{"bbands(3, 0, nbdevdn=0).lowerband", sma},
+		{"bbands(timeperiod=3).middleband", sma},
+		{"max(2)", []float64{nan, 2, 3, 4, 5, 6}},
+	})
+
+	for _, tt := range []struct {
+		expr, want string
+	}{
+		{"sma(3, 4)", "takes 1 options"},
+		{"sma(nope=3)", "nope"},
+		{"sma(1)", "timeperiod"},
+		{"sma(3).nope", `no output "nope"`},
+		{"sma(closes)", `unknown column "closes" at 4`},
+		{"sma(timeperiod=)", `expected a number at 15, got ")"`},
+		{"talib.", "unexpected end"},
+	} {
+		if _, err := Compile(tt.expr); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error about %s", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestNaN(t *testing.T) {
+	evalTests(t, closes(1, 2), []struct {
+		expr string
+		want []float64
+	}{
+		{"close[1] + 1", []float64{nan, 2}},
+		{"close[1] > 0", []float64{nan, 1}},
+		{"not close[1] > 0", []float64{nan, 0}},
+		{"-close[1]", []float64{nan, -1}},
+		// AND is false once either side is, OR true once either side is
+		{"close[1] > 0 and close > 5", []float64{0, 0}},
+		{"close[1] > 0 and close > 0", []float64{nan, 1}},
+		{"close[1] > 0 or close > 0", []float64{1, 1}},
+		{"close[1] > 0 or close > 5", []float64{nan, 1}},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		expr, want string
+	}{
+		{"", "unexpected end of expression"},
+		{"close >", "unexpected end of expression"},
+		{"(close > 1", "unexpected end of expression"},
+		{"close > 1 )", `unexpected ")" at 10`},
+		{"close $ 1", `unexpected '$' at 6`},
+		{"close > * 2", `unexpected "*" at 8`},
+		{"close[1 > 2", `expected "]" at 8, got ">"`},
+		{"close[1.5]", "1.5 is not a count of candles"},
+		{"close[x]", `expected a number at 6, got "x"`},
+		{"close > 1 within 0", "within needs at least 1 candle"},
+		{"close > 1 within -1", `expected a number at 17, got "-"`},
+		{"1 + nope(3)", `unknown indicator "nope" at 4`},
+		{"rsi(14)@5x", `bad interval "5x" at 8`},
+		{"close@renko:1m", "renko bars need a size"},
+		{"close 1", `unexpected "1" at 6`},
+	} {
+		if _, err := Compile(tt.expr); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: got %v, want an error containing %s", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestFrame(t *testing.T) {
+	const hour = 60 * minute
+	src := closes(make([]float64, 150)...)
+	src.frames = map[string]candles{"1h": {close: []float64{10, 20}, times: []int64{hour - 1, 2*hour - 1}}}
+	for _, tt := range []struct {
+		expr string
+		// value on the 1m candles closing the first hour, opening the
+		// second, closing it and past it
+		want [4]float64
+	}{
+		{"close@1h", [4]float64{10, 10, 20, 20}},
+		{"close[1]@1h", [4]float64{nan, nan, 10, 10}},
+		{"close@1h > 15", [4]float64{0, 0, 1, 1}},
+	} {
+		x, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := x.Eval(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// nothing is known before the first 1h candle has closed
+		for i := 0; i < 59; i++ {
+			if !math.IsNaN(got[i]) {
+				t.Fatalf("%s: candle %d sees %v before the hour closed", tt.expr, i, got[i])
+			}
+		}
+		// the 1m candle closing with the hour sees it, the one before does not
+		if g := []float64{got[59], got[60], got[119], got[149]}; !same(g, tt.want[:]) {
+			t.Errorf("%s: got %v, want %v", tt.expr, g, tt.want)
+		}
+		for i := 60; i < 119; i++ {
+			if !same(got[i:i+1], got[60:61]) {
+				t.Errorf("%s: candle %d sees %v while the second hour forms", tt.expr, i, got[i])
+			}
+		}
+	}
+	x, _ := Compile("close@4h")
+	if _, err := x.Eval(src); err == nil || !strings.Contains(err.Error(), "4h") {
+		t.Errorf("got %v, want an error about the missing 4h candles", err)
+	}
+}
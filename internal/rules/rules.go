@@ -0,0 +1,218 @@
+// Package rules evaluates the signals declared in the signals section of the
+// config, conditions over the indicator registry such as
+//
+//	rsi(14) crosses_below 30 AND close > ema(200)
+//
+// on every closed candle of the symbols and interval they watch.
+//
+// By default a rule fires on the candle its condition turns true, then waits
+// for it to turn false again; level rules fire on every candle it holds. A
+// rule can also wait for a re-arm condition and a cooldown before firing
+// again.
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/indicator"
+	"cryptoapi/internal/logging"
+
+	"github.com/spf13/viper"
+)
+
+type Direction string
+
+const (
+	Buy     Direction = "buy"
+	Sell    Direction = "sell"
+	Neutral Direction = "neutral"
+)
+
+// Rule is a signal declared in the config.
+type Rule struct {
+	Name      string    `mapstructure:"name" json:"name"`
+	When      string    `mapstructure:"when" json:"when"`
+	Interval  string    `mapstructure:"interval" json:"interval"`
+	Direction Direction `mapstructure:"direction" json:"direction"`
+	// every followed symbol when empty
+	Symbols []string `mapstructure:"symbols" json:"symbols,omitempty"`
+	// edge when empty
+	Mode Mode `mapstructure:"mode" json:"mode"`
+	// least time between two signals, in candle time
+	Cooldown time.Duration `mapstructure:"cooldown" json:"cooldown,omitempty"`
+	// condition re-arming the rule once it fired, see rearmed
+	Rearm string `mapstructure:"rearm" json:"rearm,omitempty"`
+
+	expr  *Expr
+	rearm *Expr
+}
+
+// Compile checks the rule and parses its condition.
+func (r *Rule) Compile() error {
+	if r.Name == "" || r.When == "" || r.Interval == "" {
+		return fmt.Errorf("rule %q needs a name, a condition and an interval", r.Name)
+	}
+	switch r.Direction {
+	case Buy, Sell, Neutral:
+	case "":
+		r.Direction = Neutral
+	default:
+		return fmt.Errorf("rule %s: unknown direction %q", r.Name, r.Direction)
+	}
+	switch r.Mode {
+	case Edge, Level:
+	case "":
+		r.Mode = Edge
+	default:
+		return fmt.Errorf("rule %s: unknown mode %q", r.Name, r.Mode)
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("rule %s: negative cooldown", r.Name)
+	}
+	for i, s := range r.Symbols {
+		r.Symbols[i] = strings.ToUpper(s)
+	}
+	expr, err := Compile(r.When)
+	if err != nil {
+		return fmt.Errorf("rule %s: %w", r.Name, err)
+	}
+	r.expr = expr
+	if r.Rearm != "" {
+		if r.rearm, err = Compile(r.Rearm); err != nil {
+			return fmt.Errorf("rule %s: rearm: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// Watches tells whether the rule applies to a symbol and interval.
+func (r *Rule) Watches(symbol, interval string) bool {
+	if r.Interval != interval {
+		return false
+	}
+	if len(r.Symbols) == 0 {
+		return true
+	}
+	for _, s := range r.Symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// FromConfig reads and compiles the rules of the signals section.
+func FromConfig() ([]*Rule, error) {
+	var res []*Rule
+	if err := viper.UnmarshalKey("signals", &res); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, r := range res {
+		if err := r.Compile(); err != nil {
+			return nil, err
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("rule %s declared twice", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return res, nil
+}
+
+// Signal is a rule firing on a closed candle.
+type Signal struct {
+	Rule      string    `json:"rule"`
+	Symbol    string    `json:"symbol"`
+	Interval  string    `json:"interval"`
+	Direction Direction `json:"direction"`
+	// close of the candle
+	Price float64 `json:"price"`
+	// close time of the candle in milliseconds
+	Time int64 `json:"time"`
+}
+
+type Engine struct {
+	rules []*Rule
+	*logging.Logger
+
+	mu      sync.Mutex
+	states  map[string]*State
+	history []Signal
+	// whether states or history changed since the last snapshot
+	changed bool
+}
+
+func New(rules []*Rule, logger *logging.Logger) *Engine {
+	return &Engine{rules: rules, Logger: logger, states: make(map[string]*State)}
+}
+
+func (e *Engine) Rules() []*Rule {
+	return e.rules
+}
+
+// Evaluate runs the rules watching symbol and interval on the last candle of
+// src, which must have closed, and returns the signals firing. A candle is
+// only evaluated once per rule, however often it comes. A rule failing to
+// evaluate is logged and skipped.
+func (e *Engine) Evaluate(symbol, interval string, src Source) []Signal {
+	var res []Signal
+	closes, times := src.Series(indicator.Close), src.CloseTimes()
+	n := len(times)
+	if n == 0 {
+		return nil
+	}
+	for _, r := range e.rules {
+		if !r.Watches(symbol, interval) {
+			continue
+		}
+		key := StateKey(r.Name, symbol, interval)
+		e.mu.Lock()
+		s, ok := e.states[key]
+		e.mu.Unlock()
+		if ok && times[n-1] <= s.Time {
+			continue
+		}
+		v, err := r.expr.Eval(src)
+		if err != nil {
+			e.WithError(err).Debugf("failed evaluating rule %s on %s_%s", r.Name, symbol, interval)
+			continue
+		}
+		cond, prev := v[n-1] == 1, n > 1 && v[n-2] == 1
+		rearm, err := r.rearmed(cond, src)
+		if err != nil {
+			e.WithError(err).Debugf("failed evaluating rearm of rule %s on %s_%s", r.Name, symbol, interval)
+			continue
+		}
+
+		e.mu.Lock()
+		if s, ok = e.states[key]; !ok {
+			s = &State{Phase: Armed}
+			e.states[key] = s
+		}
+		fired := r.step(s, times[n-1], cond, prev, rearm)
+		// the time evaluated moves on even when the phase stays, so that a
+		// restart does not evaluate the candle again
+		e.changed = true
+		if fired {
+			signal := Signal{
+				Rule:      r.Name,
+				Symbol:    symbol,
+				Interval:  interval,
+				Direction: r.Direction,
+				Price:     closes[n-1],
+				Time:      times[n-1],
+			}
+			res = append(res, signal)
+			e.history = append(e.history, signal)
+			if len(e.history) > maxHistory {
+				e.history = e.history[len(e.history)-maxHistory:]
+			}
+		}
+		e.mu.Unlock()
+	}
+	return res
+}
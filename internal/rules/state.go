@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"strings"
+	"time"
+)
+
+// Mode tells which candles a rule fires on.
+type Mode string
+
+const (
+	// Edge fires on the candle the condition turns true.
+	Edge Mode = "edge"
+	// Level fires on every candle the condition holds.
+	Level Mode = "level"
+)
+
+// Phase is where a rule stands on a symbol and interval: armed until it
+// fires, triggered until it re-arms, then cooling down until its cooldown
+// has passed.
+type Phase string
+
+const (
+	Armed     Phase = "armed"
+	Triggered Phase = "triggered"
+	Cooling   Phase = "cooling"
+)
+
+// maxHistory is how many signals the engine remembers.
+const maxHistory = 1000
+
+// State is the phase of a rule on a symbol and interval.
+type State struct {
+	Phase Phase `json:"phase"`
+	// close time of the last candle evaluated
+	Time int64 `json:"time"`
+	// close time of the candle the rule last fired on
+	Fired int64 `json:"fired,omitempty"`
+}
+
+// Snapshot is what the engine remembers across restarts.
+type Snapshot struct {
+	// by rule, symbol and interval, see StateKey
+	States  map[string]State `json:"states"`
+	History []Signal         `json:"history"`
+}
+
+// StateKey is the key of the state of a rule on a symbol and interval.
+func StateKey(rule, symbol, interval string) string {
+	return rule + "/" + symbol + "/" + interval
+}
+
+// step moves s on to the candle closed at t, given the values of the
+// condition on it and on the candle before and whether the re-arm condition
+// holds, and tells whether the rule fires.
+func (r *Rule) step(s *State, t int64, cond, prev, rearm bool) bool {
+	if s.Phase == Triggered && rearm {
+		s.Phase = Cooling
+	}
+	if s.Phase == Cooling && t-s.Fired >= int64(r.Cooldown/time.Millisecond) {
+		s.Phase = Armed
+	}
+	s.Time = t
+	if s.Phase != Armed || !cond || r.Mode == Edge && prev {
+		return false
+	}
+	s.Phase, s.Fired = Triggered, t
+	return true
+}
+
+// rearmed tells whether the rule re-arms on the last candle: when its re-arm
+// condition holds if it has one, else when the condition no longer holds in
+// edge mode and right away in level mode.
+func (r *Rule) rearmed(cond bool, src Source) (bool, error) {
+	if r.rearm != nil {
+		v, err := r.rearm.Eval(src)
+		if err != nil {
+			return false, err
+		}
+		return v[len(v)-1] == 1, nil
+	}
+	return r.Mode == Level || !cond, nil
+}
+
+// Snapshot returns the state of the engine and whether it changed since the
+// last snapshot.
+func (e *Engine) Snapshot() (Snapshot, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	res := Snapshot{
+		States:  make(map[string]State, len(e.states)),
+		History: append([]Signal(nil), e.history...),
+	}
+	for k, s := range e.states {
+		res.States[k] = *s
+	}
+	changed := e.changed
+	e.changed = false
+	return res, changed
+}
+
+// Restore brings back a snapshot, dropping the states of rules no longer
+// declared.
+func (e *Engine) Restore(s Snapshot) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make(map[string]bool, len(e.rules))
+	for _, r := range e.rules {
+		names[r.Name] = true
+	}
+	e.states = make(map[string]*State, len(s.States))
+	for k, state := range s.States {
+		if i := strings.Index(k, "/"); i > 0 && names[k[:i]] {
+			state := state
+			e.states[k] = &state
+		}
+	}
+	e.history = append([]Signal(nil), s.History...)
+	if len(e.history) > maxHistory {
+		e.history = e.history[len(e.history)-maxHistory:]
+	}
+}
+
+// States returns the state of every rule on every symbol and interval, by
+// StateKey, leaving the snapshot to save untouched.
+func (e *Engine) States() map[string]State {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	res := make(map[string]State, len(e.states))
+	for k, s := range e.states {
+		res[k] = *s
+	}
+	return res
+}
+
+// History returns the last signals fired, oldest first.
+func (e *Engine) History() []Signal {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Signal(nil), e.history...)
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"cryptoapi/internal/logging"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestStep(t *testing.T) {
+	type step struct {
+		cond, prev, rearm bool
+		fires             bool
+		phase             Phase
+	}
+	for _, tt := range []struct {
+		name     string
+		mode     Mode
+		cooldown time.Duration
+		// on candles closing a minute apart
+		steps []step
+	}{
+		{"edge", Edge, 0, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{cond: true, prev: true, phase: Triggered},
+			{rearm: true, phase: Armed},
+			{cond: true, fires: true, phase: Triggered},
+		}},
+		{"edge already true", Edge, 0, []step{
+			{cond: true, prev: true, phase: Armed},
+			{phase: Armed},
+			{cond: true, fires: true, phase: Triggered},
+		}},
+		{"level", Level, 0, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{cond: true, prev: true, rearm: true, fires: true, phase: Triggered},
+			{prev: true, rearm: true, phase: Armed},
+			{cond: true, fires: true, phase: Triggered},
+		}},
+		{"not rearmed", Level, 0, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{cond: true, prev: true, phase: Triggered},
+			{phase: Triggered},
+			{cond: true, rearm: true, fires: true, phase: Triggered},
+		}},
+		// the cooldown is over on the candle closing that long after the one
+		// the rule fired on
+		{"level cooldown", Level, 2 * time.Minute, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{cond: true, prev: true, rearm: true, phase: Cooling},
+			{cond: true, prev: true, rearm: true, fires: true, phase: Triggered},
+		}},
+		{"edge cooldown", Edge, 2 * time.Minute, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{rearm: true, phase: Cooling},
+			{cond: true, fires: true, phase: Triggered},
+		}},
+		// an edge coming during the cooldown is lost
+		{"edge in cooldown", Edge, 3 * time.Minute, []step{
+			{cond: true, fires: true, phase: Triggered},
+			{rearm: true, phase: Cooling},
+			{cond: true, phase: Cooling},
+			{cond: true, prev: true, phase: Armed},
+		}},
+	} {
+		r := &Rule{Name: tt.name, Mode: tt.mode, Cooldown: tt.cooldown}
+		s := &State{Phase: Armed}
+		var fired int64
+		for i, st := range tt.steps {
+			now := int64(i+1) * minute
+			got := r.step(s, now, st.cond, st.prev, st.rearm)
+			if got {
+				fired = now
+			}
+			if got != st.fires || s.Phase != st.phase {
+				t.Errorf("%s: step %d fired %v and is %s, want %v and %s", tt.name, i, got, s.Phase, st.fires, st.phase)
+			}
+			if s.Time != now || s.Fired != fired {
+				t.Errorf("%s: step %d at %d, fired at %d, want %d and %d", tt.name, i, s.Time, s.Fired, now, fired)
+			}
+		}
+	}
+}
+
+func engine(t *testing.T, rules ...*Rule) *Engine {
+	t.Helper()
+	for _, r := range rules {
+		if err := r.Compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return New(rules, &logging.Logger{Logger: logrus.New()})
+}
+
+func TestSnapshot(t *testing.T) {
+	e := engine(t, &Rule{Name: "up", When: "close > 1", Interval: "1m"})
+	if got := e.Evaluate("BTCUSDT", "1m", closes(1, 2)); len(got) != 1 || got[0].Time != 2*minute-1 || got[0].Price != 2 {
+		t.Fatalf("fired %+v", got)
+	}
+	snap, changed := e.Snapshot()
+	key := StateKey("up", "BTCUSDT", "1m")
+	if !changed || snap.States[key] != (State{Phase: Triggered, Time: 2*minute - 1, Fired: 2*minute - 1}) || len(snap.History) != 1 {
+		t.Errorf("snapshot %+v, changed %v", snap, changed)
+	}
+	if _, changed := e.Snapshot(); changed {
+		t.Error("changed again without an evaluation")
+	}
+	// a candle seen already is not evaluated again
+	e.Evaluate("BTCUSDT", "1m", closes(1, 2))
+	if _, changed := e.Snapshot(); changed {
+		t.Error("changed on a candle seen already")
+	}
+	// nor does a new candle leaving the phase as it was go unsaved
+	if got := e.Evaluate("BTCUSDT", "1m", closes(1, 2, 2)); len(got) != 0 {
+		t.Errorf("fired %+v while triggered", got)
+	}
+	snap, changed = e.Snapshot()
+	if !changed || snap.States[key].Time != 3*minute-1 || snap.States[key].Phase != Triggered {
+		t.Errorf("state %+v, changed %v", snap.States[key], changed)
+	}
+
+	// the states of rules no longer declared are dropped, the history only
+	// keeps its last signals
+	snap.States[StateKey("gone", "BTCUSDT", "1m")] = State{Phase: Cooling}
+	snap.History = nil
+	for i := 0; i < maxHistory+5; i++ {
+		snap.History = append(snap.History, Signal{Rule: fmt.Sprint(i)})
+	}
+	restored := engine(t, &Rule{Name: "up", When: "close > 1", Interval: "1m"})
+	restored.Restore(snap)
+	states := restored.States()
+	if len(states) != 1 || states[key] != snap.States[key] {
+		t.Errorf("restored %+v", states)
+	}
+	if h := restored.History(); len(h) != maxHistory || h[0].Rule != "5" {
+		t.Errorf("restored %d signals from %s", len(h), h[0].Rule)
+	}
+	// the restored state carries on where it stood
+	if got := restored.Evaluate("BTCUSDT", "1m", closes(1, 2, 2)); len(got) != 0 {
+		t.Errorf("fired %+v again after a restore", got)
+	}
+	restored.Evaluate("BTCUSDT", "1m", closes(1, 2, 2, 1))
+	if got := restored.Evaluate("BTCUSDT", "1m", closes(1, 2, 2, 1, 2)); len(got) != 1 {
+		t.Errorf("fired %+v, want the condition turning true again", got)
+	}
+}
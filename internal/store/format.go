@@ -0,0 +1,169 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+// A partition file is the magic, the row count and then every column in
+// turn, all flate compressed. Open times are delta encoded varints, close
+// times varints relative to their open time, trade counts varints, and
+// prices and volumes raw little-endian float64s. Version 1 files stop after
+// the volume column.
+var (
+	magic   = [4]byte{'K', 'L', 'N', '2'}
+	magicV1 = [4]byte{'K', 'L', 'N', '1'}
+)
+
+var errCorrupt = errors.New("corrupt partition file")
+
+func encode(b *Batch) ([]byte, error) {
+	raw := new(bytes.Buffer)
+	raw.Write(magic[:])
+	var tmp [binary.MaxVarintLen64]byte
+	putUvarint := func(v uint64) {
+		raw.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+	}
+	putVarint := func(v int64) {
+		raw.Write(tmp[:binary.PutVarint(tmp[:], v)])
+	}
+	n := b.Len()
+	putUvarint(uint64(n))
+	var prev int64
+	for _, t := range b.OpenTime {
+		putVarint(t - prev)
+		prev = t
+	}
+	for i, t := range b.CloseTime {
+		putVarint(t - b.OpenTime[i])
+	}
+	for _, v := range b.NumberOfTrades {
+		putUvarint(uint64(v))
+	}
+	var f [8]byte
+	for _, col := range b.floats() {
+		for _, v := range col {
+			binary.LittleEndian.PutUint64(f[:], math.Float64bits(v))
+			raw.Write(f[:])
+		}
+	}
+
+	out := new(bytes.Buffer)
+	w, err := flate.NewWriter(out, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func decode(data []byte) (*Batch, error) {
+	r := bufio.NewReader(flate.NewReader(bytes.NewReader(data)))
+	var m [4]byte
+	if _, err := io.ReadFull(r, m[:]); err != nil || (m != magic && m != magicV1) {
+		return nil, errCorrupt
+	}
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > 1<<26 {
+		return nil, errCorrupt
+	}
+	b := newBatch(int(n))
+	var prev int64
+	for i := range b.OpenTime {
+		d, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, errCorrupt
+		}
+		prev += d
+		b.OpenTime[i] = prev
+	}
+	for i := range b.CloseTime {
+		d, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, errCorrupt
+		}
+		b.CloseTime[i] = b.OpenTime[i] + d
+	}
+	floats := b.floats()
+	if m == magicV1 {
+		floats = floats[:5]
+		b.QuoteAssetVolume = nil
+		b.TakerBuyBaseAssetVolume = nil
+		b.TakerBuyQuoteAssetVolume = nil
+	} else {
+		for i := range b.NumberOfTrades {
+			v, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, errCorrupt
+			}
+			b.NumberOfTrades[i] = int64(v)
+		}
+	}
+	var f [8]byte
+	for _, col := range floats {
+		for i := range col {
+			if _, err := io.ReadFull(r, f[:]); err != nil {
+				return nil, errCorrupt
+			}
+			col[i] = math.Float64frombits(binary.LittleEndian.Uint64(f[:]))
+		}
+	}
+	b.Fill()
+	return b, nil
+}
+
+func (b *Batch) floats() [][]float64 {
+	return [][]float64{b.Open, b.High, b.Low, b.Close, b.Volume,
+		b.QuoteAssetVolume, b.TakerBuyBaseAssetVolume, b.TakerBuyQuoteAssetVolume}
+}
+
+func readPartition(name string) (*Batch, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return b, nil
+}
+
+// writePartition replaces the file only once the new content is synced, so
+// a crash leaves either the old or the new partition.
+func writePartition(name string, b *Batch) error {
+	data, err := encode(b)
+	if err != nil {
+		return err
+	}
+	tmp := name + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,361 @@
+// Package store keeps klines on disk partitioned by symbol, interval and
+// month, so a range of candles can be read without loading a whole history
+// and new candles only rewrite the partition they fall in.
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Batch is a run of candles in columns, sorted by open time. Times are in
+// milliseconds.
+type Batch struct {
+	OpenTime                 []int64
+	Open                     []float64
+	High                     []float64
+	Low                      []float64
+	Close                    []float64
+	Volume                   []float64
+	CloseTime                []int64
+	QuoteAssetVolume         []float64
+	NumberOfTrades           []int64
+	TakerBuyBaseAssetVolume  []float64
+	TakerBuyQuoteAssetVolume []float64
+}
+
+func newBatch(n int) *Batch {
+	return &Batch{
+		OpenTime:                 make([]int64, n),
+		Open:                     make([]float64, n),
+		High:                     make([]float64, n),
+		Low:                      make([]float64, n),
+		Close:                    make([]float64, n),
+		Volume:                   make([]float64, n),
+		CloseTime:                make([]int64, n),
+		QuoteAssetVolume:         make([]float64, n),
+		NumberOfTrades:           make([]int64, n),
+		TakerBuyBaseAssetVolume:  make([]float64, n),
+		TakerBuyQuoteAssetVolume: make([]float64, n),
+	}
+}
+
+// Fill pads the quote volume, trade count and taker buy columns that data
+// recorded before they were kept lacks: NaN volumes and zero trades.
+func (b *Batch) Fill() {
+	n := b.Len()
+	floats := func(col []float64) []float64 {
+		for len(col) < n {
+			col = append(col, math.NaN())
+		}
+		return col
+	}
+	b.QuoteAssetVolume = floats(b.QuoteAssetVolume)
+	b.TakerBuyBaseAssetVolume = floats(b.TakerBuyBaseAssetVolume)
+	b.TakerBuyQuoteAssetVolume = floats(b.TakerBuyQuoteAssetVolume)
+	for len(b.NumberOfTrades) < n {
+		b.NumberOfTrades = append(b.NumberOfTrades, 0)
+	}
+}
+
+func (b *Batch) Len() int {
+	return len(b.OpenTime)
+}
+
+// Append adds row i of src.
+func (b *Batch) Append(src *Batch, i int) {
+	b.OpenTime = append(b.OpenTime, src.OpenTime[i])
+	b.Open = append(b.Open, src.Open[i])
+	b.High = append(b.High, src.High[i])
+	b.Low = append(b.Low, src.Low[i])
+	b.Close = append(b.Close, src.Close[i])
+	b.Volume = append(b.Volume, src.Volume[i])
+	b.CloseTime = append(b.CloseTime, src.CloseTime[i])
+	b.QuoteAssetVolume = append(b.QuoteAssetVolume, src.QuoteAssetVolume[i])
+	b.NumberOfTrades = append(b.NumberOfTrades, src.NumberOfTrades[i])
+	b.TakerBuyBaseAssetVolume = append(b.TakerBuyBaseAssetVolume, src.TakerBuyBaseAssetVolume[i])
+	b.TakerBuyQuoteAssetVolume = append(b.TakerBuyQuoteAssetVolume, src.TakerBuyQuoteAssetVolume[i])
+}
+
+// Slice returns the rows with an open time within [from, to].
+func (b *Batch) Slice(from, to int64) *Batch {
+	i := sort.Search(b.Len(), func(i int) bool { return b.OpenTime[i] >= from })
+	j := sort.Search(b.Len(), func(i int) bool { return b.OpenTime[i] > to })
+	return &Batch{
+		OpenTime:                 b.OpenTime[i:j],
+		Open:                     b.Open[i:j],
+		High:                     b.High[i:j],
+		Low:                      b.Low[i:j],
+		Close:                    b.Close[i:j],
+		Volume:                   b.Volume[i:j],
+		CloseTime:                b.CloseTime[i:j],
+		QuoteAssetVolume:         b.QuoteAssetVolume[i:j],
+		NumberOfTrades:           b.NumberOfTrades[i:j],
+		TakerBuyBaseAssetVolume:  b.TakerBuyBaseAssetVolume[i:j],
+		TakerBuyQuoteAssetVolume: b.TakerBuyQuoteAssetVolume[i:j],
+	}
+}
+
+// Merge merges two filled batches sorted by open time, b winning over a for
+// the candles both have.
+func Merge(a, b *Batch) *Batch {
+	res := &Batch{}
+	i, j := 0, 0
+	for i < a.Len() || j < b.Len() {
+		switch {
+		case j == b.Len() || (i < a.Len() && a.OpenTime[i] < b.OpenTime[j]):
+			res.Append(a, i)
+			i++
+		case i < a.Len() && a.OpenTime[i] == b.OpenTime[j]:
+			i++
+		default:
+			res.Append(b, j)
+			j++
+		}
+	}
+	return res
+}
+
+// sortBatch orders a batch by open time, keeping the last of duplicates.
+func sortBatch(b *Batch) *Batch {
+	idx := make([]int, b.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return b.OpenTime[idx[i]] < b.OpenTime[idx[j]]
+	})
+	res := &Batch{}
+	for k, i := range idx {
+		if k+1 < len(idx) && b.OpenTime[idx[k+1]] == b.OpenTime[i] {
+			continue
+		}
+		res.Append(b, i)
+	}
+	return res
+}
+
+const monthLayout = "2006-01"
+
+func month(t int64) string {
+	return time.Unix(0, t*int64(time.Millisecond)).UTC().Format(monthLayout)
+}
+
+func monthStart(m string) int64 {
+	t, _ := time.Parse(monthLayout, m)
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func monthEnd(m string) int64 {
+	t, _ := time.Parse(monthLayout, m)
+	return t.AddDate(0, 1, 0).UnixNano()/int64(time.Millisecond) - 1
+}
+
+type Store struct {
+	root string
+
+	mu    sync.Mutex
+	locks map[string]*sync.RWMutex
+}
+
+// New returns the store rooted at the directory root, which is created on
+// the first write.
+func New(root string) *Store {
+	return &Store{root: root, locks: make(map[string]*sync.RWMutex)}
+}
+
+func (s *Store) dir(symbol, interval string) string {
+	return filepath.Join(s.root, symbol, dirName(interval))
+}
+
+// dirName is the directory of an interval, months being kept as mo since 1M
+// and 1m would share a directory on case-insensitive filesystems.
+func dirName(interval string) string {
+	if strings.HasSuffix(interval, "M") {
+		return strings.TrimSuffix(interval, "M") + "mo"
+	}
+	return interval
+}
+
+func intervalName(dir string) string {
+	if strings.HasSuffix(dir, "mo") {
+		return strings.TrimSuffix(dir, "mo") + "M"
+	}
+	return dir
+}
+
+// lock returns the lock of a series; writers of a series are serialized and
+// readers never see a partition half replaced.
+func (s *Store) lock(symbol, interval string) *sync.RWMutex {
+	key := symbol + "/" + interval
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = new(sync.RWMutex)
+		s.locks[key] = l
+	}
+	return l
+}
+
+// months lists the partitions of a series in order.
+func (s *Store) months(symbol, interval string) ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir(symbol, interval))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, e := range entries {
+		if name := e.Name(); strings.HasSuffix(name, ".kl") {
+			res = append(res, strings.TrimSuffix(name, ".kl"))
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (s *Store) partition(symbol, interval, m string) string {
+	return filepath.Join(s.dir(symbol, interval), m+".kl")
+}
+
+// Write stores the candles of b, replacing those with the same open time.
+// Only the partitions of the months b covers are rewritten.
+func (s *Store) Write(symbol, interval string, b *Batch) error {
+	if b.Len() == 0 {
+		return nil
+	}
+	l := s.lock(symbol, interval)
+	l.Lock()
+	defer l.Unlock()
+	if err := os.MkdirAll(s.dir(symbol, interval), 0755); err != nil {
+		return err
+	}
+	filled := *b
+	filled.Fill()
+	b = sortBatch(&filled)
+	for start := 0; start < b.Len(); {
+		m := month(b.OpenTime[start])
+		end := start
+		for end < b.Len() && month(b.OpenTime[end]) == m {
+			end++
+		}
+		rows := b.Slice(b.OpenTime[start], b.OpenTime[end-1])
+		name := s.partition(symbol, interval, m)
+		old, err := readPartition(name)
+		switch {
+		case err == nil:
+			rows = Merge(old, rows)
+		case !os.IsNotExist(err):
+			return err
+		}
+		if err := writePartition(name, rows); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// Scan calls fn with the candles opened within [from, to], one partition at
+// a time, so a long range never sits in memory at once. Scanning stops at
+// the first error fn returns.
+func (s *Store) Scan(symbol, interval string, from, to int64, fn func(b *Batch) error) error {
+	l := s.lock(symbol, interval)
+	l.RLock()
+	defer l.RUnlock()
+	months, err := s.months(symbol, interval)
+	if err != nil {
+		return err
+	}
+	for _, m := range months {
+		if monthEnd(m) < from || monthStart(m) > to {
+			continue
+		}
+		b, err := readPartition(s.partition(symbol, interval, m))
+		if err != nil {
+			return err
+		}
+		if b = b.Slice(from, to); b.Len() == 0 {
+			continue
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read returns the candles opened within [from, to] in a single batch.
+func (s *Store) Read(symbol, interval string, from, to int64) (*Batch, error) {
+	res := &Batch{}
+	err := s.Scan(symbol, interval, from, to, func(b *Batch) error {
+		for i := 0; i < b.Len(); i++ {
+			res.Append(b, i)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Bounds returns the open times of the first and last stored candles of a
+// series, ok being false when it has none.
+func (s *Store) Bounds(symbol, interval string) (first, last int64, ok bool, err error) {
+	l := s.lock(symbol, interval)
+	l.RLock()
+	defer l.RUnlock()
+	months, err := s.months(symbol, interval)
+	if err != nil || len(months) == 0 {
+		return 0, 0, false, err
+	}
+	b, err := readPartition(s.partition(symbol, interval, months[0]))
+	if err != nil {
+		return 0, 0, false, err
+	}
+	e := b
+	if len(months) > 1 {
+		if e, err = readPartition(s.partition(symbol, interval, months[len(months)-1])); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if b.Len() == 0 || e.Len() == 0 {
+		return 0, 0, false, fmt.Errorf("empty partition in %s", s.dir(symbol, interval))
+	}
+	return b.OpenTime[0], e.OpenTime[e.Len()-1], true, nil
+}
+
+// Series lists the stored symbols with their intervals.
+func (s *Store) Series() (map[string][]string, error) {
+	res := make(map[string][]string)
+	symbols, err := ioutil.ReadDir(s.root)
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		if !symbol.IsDir() {
+			continue
+		}
+		intervals, err := ioutil.ReadDir(filepath.Join(s.root, symbol.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, interval := range intervals {
+			if interval.IsDir() {
+				res[symbol.Name()] = append(res[symbol.Name()], intervalName(interval.Name()))
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const hour = int64(time.Hour / time.Millisecond)
+
+// candles returns a filled batch of hourly candles opened at the given
+// times, their prices telling them apart.
+func candles(price float64, times ...int64) *Batch {
+	b := &Batch{}
+	for i, t := range times {
+		p := price + float64(i)
+		b.OpenTime = append(b.OpenTime, t)
+		b.Open = append(b.Open, p)
+		b.High = append(b.High, p+1)
+		b.Low = append(b.Low, p-1)
+		b.Close = append(b.Close, p+0.5)
+		b.Volume = append(b.Volume, 10*p)
+		b.CloseTime = append(b.CloseTime, t+hour-1)
+		b.QuoteAssetVolume = append(b.QuoteAssetVolume, 100*p)
+		b.NumberOfTrades = append(b.NumberOfTrades, int64(i)*1000)
+		b.TakerBuyBaseAssetVolume = append(b.TakerBuyBaseAssetVolume, 5*p)
+		b.TakerBuyQuoteAssetVolume = append(b.TakerBuyQuoteAssetVolume, 50*p)
+	}
+	return b
+}
+
+func utc(year int, month time.Month, day, hour int) int64 {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+}
+
+func TestEncode(t *testing.T) {
+	// open times far apart and out of step, close times before their open
+	b := candles(0.00001234, 1500000000000, 1500000000001, 1600000000000)
+	b.CloseTime[1] = b.OpenTime[1] - 5
+	b.NumberOfTrades[2] = 1 << 40
+	data, err := encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, magic[:]) {
+		t.Error("the magic is not compressed")
+	}
+	got, err := decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, b) {
+		t.Errorf("got %+v, want %+v", got, b)
+	}
+
+	if _, err := decode(data[:len(data)/2]); err != errCorrupt {
+		t.Errorf("truncated: got %v", err)
+	}
+	if _, err := decode([]byte("KLN2")); err != errCorrupt {
+		t.Errorf("uncompressed: got %v", err)
+	}
+}
+
+func TestDecodeV1(t *testing.T) {
+	// two candles of a version 1 file, which stops after the volumes
+	raw := new(bytes.Buffer)
+	raw.Write(magicV1[:])
+	var tmp [binary.MaxVarintLen64]byte
+	raw.Write(tmp[:binary.PutUvarint(tmp[:], 2)])
+	for _, v := range []int64{1000, 60000, 59999, 59999} {
+		raw.Write(tmp[:binary.PutVarint(tmp[:], v)])
+	}
+	for _, v := range []float64{1, 2, 3, 4, 0.5, 1.5, 2, 3, 10, 20} {
+		binary.Write(raw, binary.LittleEndian, v)
+	}
+	data := new(bytes.Buffer)
+	w, _ := flate.NewWriter(data, flate.BestSpeed)
+	w.Write(raw.Bytes())
+	w.Close()
+
+	b, err := decode(data.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Batch{
+		OpenTime:  []int64{1000, 61000},
+		Open:      []float64{1, 2},
+		High:      []float64{3, 4},
+		Low:       []float64{0.5, 1.5},
+		Close:     []float64{2, 3},
+		Volume:    []float64{10, 20},
+		CloseTime: []int64{60999, 120999},
+	}
+	got := *b
+	got.QuoteAssetVolume, got.NumberOfTrades, got.TakerBuyBaseAssetVolume, got.TakerBuyQuoteAssetVolume = nil, nil, nil, nil
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	// the order flow columns it lacks are filled in
+	for _, col := range [][]float64{b.QuoteAssetVolume, b.TakerBuyBaseAssetVolume, b.TakerBuyQuoteAssetVolume} {
+		if len(col) != 2 || !math.IsNaN(col[0]) || !math.IsNaN(col[1]) {
+			t.Errorf("got %v, want NaN volumes", col)
+		}
+	}
+	if !reflect.DeepEqual(b.NumberOfTrades, []int64{0, 0}) {
+		t.Errorf("got %v trades, want none", b.NumberOfTrades)
+	}
+}
+
+func TestWriteScan(t *testing.T) {
+	s := New(t.TempDir())
+	// from the last hours of January to the first of February
+	var times []int64
+	for h := utc(2020, 1, 31, 21); h <= utc(2020, 2, 1, 2); h += hour {
+		times = append(times, h)
+	}
+	b := candles(100, times...)
+	if err := s.Write("BTCUSDT", "1h", b); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2020-01.kl", "2020-02.kl"} {
+		if _, err := os.Stat(filepath.Join(s.root, "BTCUSDT", "1h", name)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	var scanned []int
+	err := s.Scan("BTCUSDT", "1h", 0, math.MaxInt64, func(p *Batch) error {
+		scanned = append(scanned, p.Len())
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(scanned, []int{3, 3}) {
+		t.Errorf("scanned partitions of %v candles, %v, want 3 and 3", scanned, err)
+	}
+	stop := errors.New("stop")
+	scanned = nil
+	err = s.Scan("BTCUSDT", "1h", 0, math.MaxInt64, func(p *Batch) error {
+		scanned = append(scanned, p.Len())
+		return stop
+	})
+	if err != stop || len(scanned) != 1 {
+		t.Errorf("scanning went on after an error: %v, %v", scanned, err)
+	}
+
+	got, err := s.Read("BTCUSDT", "1h", times[2], times[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := b.Slice(times[2], times[3]); !reflect.DeepEqual(got, want) {
+		t.Errorf("read %+v, want %+v", got, want)
+	}
+	first, last, ok, err := s.Bounds("BTCUSDT", "1h")
+	if err != nil || !ok || first != times[0] || last != times[len(times)-1] {
+		t.Errorf("bounds %d to %d, %v, %v", first, last, ok, err)
+	}
+	if _, _, ok, err := s.Bounds("BTCUSDT", "4h"); ok || err != nil {
+		t.Errorf("bounds of nothing: %v, %v", ok, err)
+	}
+}
+
+func TestMonths(t *testing.T) {
+	s := New(t.TempDir())
+	minutes := candles(1, utc(2020, 1, 1, 0))
+	months := candles(2, utc(2020, 1, 1, 0), utc(2020, 2, 1, 0))
+	if err := s.Write("BTCUSDT", "1m", minutes); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write("BTCUSDT", "1M", months); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(s.root, "BTCUSDT", "1mo")); err != nil {
+		t.Error(err)
+	}
+	series, err := s.Series()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := series["BTCUSDT"]; !reflect.DeepEqual(got, []string{"1M", "1m"}) && !reflect.DeepEqual(got, []string{"1m", "1M"}) {
+		t.Errorf("series %v, want 1m and 1M", got)
+	}
+	for interval, want := range map[string]*Batch{"1m": minutes, "1M": months} {
+		got, err := s.Read("BTCUSDT", interval, 0, math.MaxInt64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: read %+v, want %+v", interval, got, want)
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	a := candles(1, 0, hour, 2*hour)
+	b := candles(10, hour, 3*hour)
+	if got := Merge(a, b); !reflect.DeepEqual(got.Open, []float64{1, 10, 3, 11}) {
+		t.Errorf("merged %v, want b winning over a", got.Open)
+	}
+
+	s := New(t.TempDir())
+	// out of order, the later of two candles with the same open time wins
+	unsorted := candles(1, 2*hour, 0, 2*hour, hour)
+	if err := s.Write("BTCUSDT", "1h", unsorted); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write("BTCUSDT", "1h", candles(10, hour)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Read("BTCUSDT", "1h", 0, math.MaxInt64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.OpenTime, []int64{0, hour, 2 * hour}) || !reflect.DeepEqual(got.Open, []float64{2, 10, 3}) {
+		t.Errorf("stored %v opening at %v", got.Open, got.OpenTime)
+	}
+}
+
+func TestAtomicWrite(t *testing.T) {
+	s := New(t.TempDir())
+	old := candles(1, 0, hour)
+	if err := s.Write("BTCUSDT", "1h", old); err != nil {
+		t.Fatal(err)
+	}
+	name := s.partition("BTCUSDT", "1h", month(0))
+	if _, err := os.Stat(name + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// a write failing before the rename leaves the partition as it was
+	if err := os.Mkdir(name+".tmp", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write("BTCUSDT", "1h", candles(10, hour)); err == nil {
+		t.Fatal("no error")
+	}
+	got, err := s.Read("BTCUSDT", "1h", 0, math.MaxInt64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, old) {
+		t.Errorf("read %+v, want %+v", got, old)
+	}
+}
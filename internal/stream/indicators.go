@@ -0,0 +1,379 @@
+package stream
+
+import (
+	"math"
+)
+
+// SMA is the simple moving average of the close.
+type SMA struct {
+	s sma
+}
+
+func NewSMA(period int) *SMA {
+	return &SMA{newSma(period)}
+}
+
+func (i *SMA) Update(c Candle) []float64 {
+	v, _ := i.s.push(c.Close)
+	return []float64{v}
+}
+
+func (i *SMA) Peek(c Candle) []float64 {
+	v, _ := i.s.peek(c.Close)
+	return []float64{v}
+}
+
+func (i *SMA) Ready() bool {
+	return i.s.ready()
+}
+
+// EMA is the exponential moving average of the close.
+type EMA struct {
+	e ema
+}
+
+func NewEMA(period int) *EMA {
+	return &EMA{newEma(period)}
+}
+
+func (i *EMA) Update(c Candle) []float64 {
+	i.e = i.e.next(c.Close)
+	return []float64{i.e.out()}
+}
+
+func (i *EMA) Peek(c Candle) []float64 {
+	return []float64{i.e.next(c.Close).out()}
+}
+
+func (i *EMA) Ready() bool {
+	return i.e.ready()
+}
+
+// RSI is Wilder's relative strength index of the close.
+type RSI struct {
+	state rsi
+}
+
+type rsi struct {
+	prev       float64
+	seen       bool
+	gain, loss wilder
+}
+
+func NewRSI(period int) *RSI {
+	return &RSI{rsi{gain: wilder{period: period}, loss: wilder{period: period}}}
+}
+
+func (s rsi) next(close float64) (rsi, float64) {
+	if !s.seen {
+		s.prev, s.seen = close, true
+		return s, 0
+	}
+	change := close - s.prev
+	s.prev = close
+	s.gain = s.gain.next(math.Max(change, 0))
+	s.loss = s.loss.next(math.Max(-change, 0))
+	if !s.gain.ready() || s.gain.value+s.loss.value == 0 {
+		return s, 0
+	}
+	return s, 100 * s.gain.value / (s.gain.value + s.loss.value)
+}
+
+func (i *RSI) Update(c Candle) []float64 {
+	var v float64
+	i.state, v = i.state.next(c.Close)
+	return []float64{v}
+}
+
+func (i *RSI) Peek(c Candle) []float64 {
+	_, v := i.state.next(c.Close)
+	return []float64{v}
+}
+
+func (i *RSI) Ready() bool {
+	return i.state.gain.ready()
+}
+
+// ATR is the average true range, smoothed the Wilder way.
+type ATR struct {
+	state atr
+}
+
+type atr struct {
+	prevClose float64
+	seen      bool
+	tr        wilder
+}
+
+func NewATR(period int) *ATR {
+	return &ATR{atr{tr: wilder{period: period}}}
+}
+
+func (s atr) next(c Candle) (atr, float64) {
+	if !s.seen {
+		s.prevClose, s.seen = c.Close, true
+		return s, 0
+	}
+	tr := math.Max(c.High-c.Low, math.Max(math.Abs(c.High-s.prevClose), math.Abs(c.Low-s.prevClose)))
+	s.prevClose = c.Close
+	s.tr = s.tr.next(tr)
+	if !s.tr.ready() {
+		return s, 0
+	}
+	return s, s.tr.value
+}
+
+func (i *ATR) Update(c Candle) []float64 {
+	var v float64
+	i.state, v = i.state.next(c)
+	return []float64{v}
+}
+
+func (i *ATR) Peek(c Candle) []float64 {
+	_, v := i.state.next(c)
+	return []float64{v}
+}
+
+func (i *ATR) Ready() bool {
+	return i.state.tr.ready()
+}
+
+// MACD outputs the macd line, its signal and the histogram. As in talib, the
+// fast average starts late so that it is ready on the same candle as the
+// slow one.
+type MACD struct {
+	state macd
+}
+
+type macd struct {
+	fast, slow, signal ema
+}
+
+func NewMACD(fast, slow, signal int) *MACD {
+	if slow < fast {
+		fast, slow = slow, fast
+	}
+	return &MACD{macd{newEma(fast), newEma(slow), newEma(signal)}}
+}
+
+func (s macd) next(close float64) (macd, []float64) {
+	if s.slow.count >= s.slow.period-s.fast.period {
+		s.fast = s.fast.next(close)
+	}
+	s.slow = s.slow.next(close)
+	if !s.slow.ready() {
+		return s, []float64{0, 0, 0}
+	}
+	line := s.fast.value - s.slow.value
+	s.signal = s.signal.next(line)
+	if !s.signal.ready() {
+		return s, []float64{0, 0, 0}
+	}
+	return s, []float64{line, s.signal.value, line - s.signal.value}
+}
+
+func (i *MACD) Update(c Candle) []float64 {
+	var out []float64
+	i.state, out = i.state.next(c.Close)
+	return out
+}
+
+func (i *MACD) Peek(c Candle) []float64 {
+	_, out := i.state.next(c.Close)
+	return out
+}
+
+func (i *MACD) Ready() bool {
+	return i.state.signal.ready()
+}
+
+// Bollinger outputs the upper, middle and lower bands of the close, the
+// middle one being the simple average and the width k population standard
+// deviations.
+type Bollinger struct {
+	w *window
+	k float64
+}
+
+func NewBollinger(period int, k float64) *Bollinger {
+	return &Bollinger{newWindow(period), k}
+}
+
+func (i *Bollinger) Peek(c Candle) []float64 {
+	if i.w.n+1 < len(i.w.buf) {
+		return []float64{0, 0, 0}
+	}
+	old := i.w.evicted()
+	n := float64(len(i.w.buf))
+	mean := (i.w.sum - old + c.Close) / n
+	variance := (i.w.sumSq-old*old+c.Close*c.Close)/n - mean*mean
+	dev := i.k * math.Sqrt(math.Max(variance, 0))
+	return []float64{mean + dev, mean, mean - dev}
+}
+
+func (i *Bollinger) Update(c Candle) []float64 {
+	out := i.Peek(c)
+	i.w.push(c.Close)
+	return out
+}
+
+func (i *Bollinger) Ready() bool {
+	return i.w.full()
+}
+
+// Stochastic outputs the slow %K and %D, both smoothed with simple averages.
+type Stochastic struct {
+	highs, lows *window
+	k, d        sma
+}
+
+func NewStochastic(kPeriod, kSlowing, dPeriod int) *Stochastic {
+	return &Stochastic{
+		highs: newWindow(kPeriod),
+		lows:  newWindow(kPeriod),
+		k:     newSma(kSlowing),
+		d:     newSma(dPeriod),
+	}
+}
+
+func (i *Stochastic) fastK(c Candle) (float64, bool) {
+	if i.highs.n+1 < len(i.highs.buf) {
+		return 0, false
+	}
+	highest, _ := i.highs.extremes(c.High)
+	_, lowest := i.lows.extremes(c.Low)
+	if highest == lowest {
+		return 0, true
+	}
+	return 100 * (c.Close - lowest) / (highest - lowest), true
+}
+
+func (i *Stochastic) Peek(c Candle) []float64 {
+	fast, ok := i.fastK(c)
+	if !ok {
+		return []float64{0, 0}
+	}
+	k, ok := i.k.peek(fast)
+	if !ok {
+		return []float64{0, 0}
+	}
+	d, ok := i.d.peek(k)
+	if !ok {
+		return []float64{0, 0}
+	}
+	return []float64{k, d}
+}
+
+func (i *Stochastic) Update(c Candle) []float64 {
+	fast, ok := i.fastK(c)
+	i.highs.push(c.High)
+	i.lows.push(c.Low)
+	if !ok {
+		return []float64{0, 0}
+	}
+	k, ok := i.k.push(fast)
+	if !ok {
+		return []float64{0, 0}
+	}
+	d, ok := i.d.push(k)
+	if !ok {
+		return []float64{0, 0}
+	}
+	return []float64{k, d}
+}
+
+func (i *Stochastic) Ready() bool {
+	return i.d.ready()
+}
+
+// OBV is the on balance volume, starting from the first candle's volume.
+type OBV struct {
+	state obv
+}
+
+type obv struct {
+	prevClose, value float64
+	seen             bool
+}
+
+func NewOBV() *OBV {
+	return &OBV{}
+}
+
+func (s obv) next(c Candle) obv {
+	switch {
+	case !s.seen:
+		s.value, s.seen = c.Volume, true
+	case c.Close > s.prevClose:
+		s.value += c.Volume
+	case c.Close < s.prevClose:
+		s.value -= c.Volume
+	}
+	s.prevClose = c.Close
+	return s
+}
+
+func (i *OBV) Update(c Candle) []float64 {
+	i.state = i.state.next(c)
+	return []float64{i.state.value}
+}
+
+func (i *OBV) Peek(c Candle) []float64 {
+	return []float64{i.state.next(c).value}
+}
+
+func (i *OBV) Ready() bool {
+	return i.state.seen
+}
+
+// VWAP is the volume weighted average of the typical price. It restarts at
+// every multiple of session, in milliseconds of open time, e.g. 86400000 for
+// the daily VWAP, or never when session is zero.
+type VWAP struct {
+	session int64
+	state   vwap
+}
+
+type vwap struct {
+	start         int64
+	priceVol, vol float64
+	seen          bool
+}
+
+func NewVWAP(session int64) *VWAP {
+	return &VWAP{session: session}
+}
+
+func (i *VWAP) next(c Candle) vwap {
+	s := i.state
+	var start int64
+	if i.session > 0 {
+		start = c.OpenTime - c.OpenTime%i.session
+	}
+	if !s.seen || start != s.start {
+		s = vwap{start: start, seen: true}
+	}
+	s.priceVol += (c.High + c.Low + c.Close) / 3 * c.Volume
+	s.vol += c.Volume
+	return s
+}
+
+func (s vwap) value() float64 {
+	if s.vol == 0 {
+		return 0
+	}
+	return s.priceVol / s.vol
+}
+
+func (i *VWAP) Update(c Candle) []float64 {
+	i.state = i.next(c)
+	return []float64{i.state.value()}
+}
+
+func (i *VWAP) Peek(c Candle) []float64 {
+	return []float64{i.next(c).value()}
+}
+
+func (i *VWAP) Ready() bool {
+	return i.state.seen
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"cryptoapi/internal/talib"
+)
+
+// walk returns n candles of a random walk around 100.
+func walk(n int) []Candle {
+	r := rand.New(rand.NewSource(1))
+	res := make([]Candle, n)
+	price := 100.0
+	for i := range res {
+		c := Candle{OpenTime: int64(i) * 60000, Open: price}
+		price += r.NormFloat64()
+		c.Close = price
+		c.High = math.Max(c.Open, c.Close) + math.Abs(r.NormFloat64())/2
+		c.Low = math.Min(c.Open, c.Close) - math.Abs(r.NormFloat64())/2
+		c.Volume = 100 + 900*r.Float64()
+		res[i] = c
+	}
+	return res
+}
+
+func columns(candles []Candle) (high, low, close, volume []float64) {
+	for _, c := range candles {
+		high = append(high, c.High)
+		low = append(low, c.Low)
+		close = append(close, c.Close)
+		volume = append(volume, c.Volume)
+	}
+	return
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
+
+// TestParity feeds the candles one at a time and checks every output against
+// the talib function on the whole history, and Peek against Update.
+func TestParity(t *testing.T) {
+	candles := walk(500)
+	high, low, close, volume := columns(candles)
+	for _, tt := range []struct {
+		name string
+		ind  Indicator
+		want [][]float64
+	}{
+		{"sma", NewSMA(20), [][]float64{talib.Sma(close, 20)}},
+		{"ema", NewEMA(20), [][]float64{talib.Ema(close, 20)}},
+		{"rsi", NewRSI(14), [][]float64{talib.Rsi(close, 14)}},
+		{"atr", NewATR(14), [][]float64{talib.Atr(high, low, close, 14)}},
+		{"bbands", NewBollinger(20, 2), func() [][]float64 {
+			upper, middle, lower := talib.BBands(close, 20, 2, 2, talib.SMA)
+			return [][]float64{upper, middle, lower}
+		}()},
+		{"stoch", NewStochastic(14, 3, 3), func() [][]float64 {
+			k, d := talib.Stoch(high, low, close, 14, 3, talib.SMA, 3, talib.SMA)
+			return [][]float64{k, d}
+		}()},
+		{"obv", NewOBV(), [][]float64{talib.Obv(close, volume)}},
+	} {
+		for i, c := range candles {
+			peek := tt.ind.Peek(c)
+			if again := tt.ind.Peek(c); !equal(again, peek) {
+				t.Fatalf("%s at %d: peeking twice got %v then %v", tt.name, i, peek, again)
+			}
+			got := tt.ind.Update(c)
+			for j := range got {
+				if !near(peek[j], got[j]) {
+					t.Fatalf("%s at %d: peeked %v, updated to %v", tt.name, i, peek, got)
+				}
+				if want := tt.want[j][i]; !near(got[j], want) {
+					t.Fatalf("%s at %d: output %d is %v, want %v", tt.name, i, j, got[j], want)
+				}
+			}
+		}
+		if !tt.ind.Ready() {
+			t.Errorf("%s not ready after %d candles", tt.name, len(candles))
+		}
+	}
+}
+
+func equal(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMACD(t *testing.T) {
+	_, _, closes, _ := columns(walk(500))
+	for _, p := range [][3]int{{12, 26, 9}, {26, 12, 9}, {5, 35, 5}} {
+		line, signal, hist := talib.Macd(closes, p[0], p[1], p[2])
+		m := NewMACD(p[0], p[1], p[2])
+		for i, c := range closes {
+			got := m.Update(Candle{Close: c})
+			want := []float64{line[i], signal[i], hist[i]}
+			for j := range want {
+				if math.Abs(got[j]-want[j]) > 1e-9 {
+					t.Fatalf("%v at %d: got %v, want %v", p, i, got, want)
+				}
+			}
+		}
+	}
+}
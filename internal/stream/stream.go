@@ -0,0 +1,193 @@
+// Package stream holds incremental versions of the common indicators. They
+// take one candle at a time, so the cost of an update does not grow with the
+// history, and can peek at a still-forming candle without committing it.
+//
+// Values follow the talib conventions: outputs are zero until the indicator
+// has seen its lookback, and a stream seeded with a history returns the same
+// last value as the talib function on that history.
+package stream
+
+import (
+	"math"
+)
+
+// Candle is one bar fed to an indicator. OpenTime is in milliseconds.
+type Candle struct {
+	OpenTime int64
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   float64
+
+	// order flow, NaN volumes and zero trades when unknown
+	QuoteVolume         float64
+	Trades              int64
+	TakerBuyVolume      float64
+	TakerBuyQuoteVolume float64
+}
+
+// Indicator is the common interface of the streaming indicators.
+type Indicator interface {
+	// Update commits a closed candle and returns the outputs after it.
+	Update(c Candle) []float64
+	// Peek returns the outputs as if c closed now, leaving the state as is.
+	// It is meant for the bar still forming and may be called any number of
+	// times between two updates.
+	Peek(c Candle) []float64
+	// Ready reports whether the outputs are past the lookback.
+	Ready() bool
+}
+
+// Seed feeds a history of closed candles and returns the last outputs.
+func Seed(ind Indicator, candles []Candle) []float64 {
+	var out []float64
+	for _, c := range candles {
+		out = ind.Update(c)
+	}
+	return out
+}
+
+// window is a ring buffer of the last len(buf) values with their sum and sum
+// of squares. The sums are recomputed every time the buffer wraps so rounding
+// errors do not pile up over a long stream.
+type window struct {
+	buf        []float64
+	pos, n     int
+	sum, sumSq float64
+}
+
+func newWindow(size int) *window {
+	return &window{buf: make([]float64, size)}
+}
+
+func (w *window) full() bool {
+	return w.n == len(w.buf)
+}
+
+// evicted is the value push would drop, zero while the buffer fills.
+func (w *window) evicted() float64 {
+	if !w.full() {
+		return 0
+	}
+	return w.buf[w.pos]
+}
+
+func (w *window) push(v float64) {
+	old := w.evicted()
+	w.sum += v - old
+	w.sumSq += v*v - old*old
+	w.buf[w.pos] = v
+	w.pos = (w.pos + 1) % len(w.buf)
+	if w.n < len(w.buf) {
+		w.n++
+	}
+	if w.pos == 0 {
+		w.sum, w.sumSq = 0, 0
+		for _, x := range w.buf {
+			w.sum += x
+			w.sumSq += x * x
+		}
+	}
+}
+
+// extremes returns the highest and lowest of the window with v pushed.
+func (w *window) extremes(v float64) (max, min float64) {
+	max, min = v, v
+	for i := 1; i < len(w.buf) && i <= w.n; i++ {
+		x := w.buf[(w.pos-i+len(w.buf))%len(w.buf)]
+		max = math.Max(max, x)
+		min = math.Min(min, x)
+	}
+	return max, min
+}
+
+// sma is the simple moving average of plain values.
+type sma struct {
+	w *window
+}
+
+func newSma(period int) sma {
+	return sma{newWindow(period)}
+}
+
+func (s sma) ready() bool {
+	return s.w.full()
+}
+
+// peek returns the average with v pushed and whether it covers a full period.
+func (s sma) peek(v float64) (float64, bool) {
+	if s.w.n+1 < len(s.w.buf) {
+		return 0, false
+	}
+	return (s.w.sum - s.w.evicted() + v) / float64(len(s.w.buf)), true
+}
+
+func (s sma) push(v float64) (float64, bool) {
+	avg, ok := s.peek(v)
+	s.w.push(v)
+	return avg, ok
+}
+
+// ema is the exponential moving average seeded, like talib, with the simple
+// average of the first period values. It is a value so peeking is a copy.
+type ema struct {
+	period int
+	k      float64
+	count  int
+	value  float64
+}
+
+func newEma(period int) ema {
+	return ema{period: period, k: 2.0 / float64(period+1)}
+}
+
+func (e ema) ready() bool {
+	return e.count >= e.period
+}
+
+func (e ema) next(v float64) ema {
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.value += v
+	case e.count == e.period:
+		e.value = (e.value + v) / float64(e.period)
+	default:
+		e.value += e.k * (v - e.value)
+	}
+	return e
+}
+
+// out is the average once ready and zero before.
+func (e ema) out() float64 {
+	if !e.ready() {
+		return 0
+	}
+	return e.value
+}
+
+// wilder is Wilder's smoothing, seeded with the simple average of the first
+// period values.
+type wilder struct {
+	period int
+	count  int
+	value  float64
+}
+
+func (s wilder) ready() bool {
+	return s.count >= s.period
+}
+
+func (s wilder) next(v float64) wilder {
+	s.count++
+	switch {
+	case s.count < s.period:
+		s.value += v
+	case s.count == s.period:
+		s.value = (s.value + v) / float64(s.period)
+	default:
+		s.value = (s.value*float64(s.period-1) + v) / float64(s.period)
+	}
+	return s
+}
@@ -0,0 +1,166 @@
+package talib
+
+import (
+	"math"
+)
+
+// candleRange selects which part of a candle a setting measures.
+type candleRange int
+
+const (
+	rangeRealBody candleRange = iota
+	rangeHighLow
+	rangeShadows
+)
+
+// candleSetting mirrors TA_CandleSetting: a candle part is "long", "short",
+// "near" and so on when compared with factor times the average of that part
+// over the previous avgPeriod candles. A zero avgPeriod compares against the
+// candle itself.
+type candleSetting struct {
+	rangeType candleRange
+	avgPeriod int
+	factor    float64
+}
+
+// TA_CandleDefaultSettings
+var (
+	bodyLong        = candleSetting{rangeRealBody, 10, 1.0}
+	bodyVeryLong    = candleSetting{rangeRealBody, 10, 3.0}
+	bodyShort       = candleSetting{rangeRealBody, 10, 1.0}
+	bodyDoji        = candleSetting{rangeHighLow, 10, 0.1}
+	shadowLong      = candleSetting{rangeRealBody, 0, 1.0}
+	shadowVeryLong  = candleSetting{rangeRealBody, 0, 2.0}
+	shadowShort     = candleSetting{rangeShadows, 10, 1.0}
+	shadowVeryShort = candleSetting{rangeHighLow, 10, 0.1}
+	near            = candleSetting{rangeHighLow, 5, 0.2}
+	far             = candleSetting{rangeHighLow, 5, 0.6}
+	equal           = candleSetting{rangeHighLow, 5, 0.05}
+)
+
+// candleLookback is the number of candles a pattern spanning extra+1 candles
+// needs before its first output, given the settings it compares against.
+func candleLookback(extra int, settings ...candleSetting) int {
+	period := 0
+	for _, s := range settings {
+		if s.avgPeriod > period {
+			period = s.avgPeriod
+		}
+	}
+	return period + extra
+}
+
+// candles wraps the OHLC columns with the TA_REALBODY, TA_CANDLECOLOR, ...
+// macros of ta_utility.h. Running totals of every range type are kept so a
+// setting's average over any window is a single subtraction.
+type candles struct {
+	open, high, low, close []float64
+	totals                 [3][]float64
+}
+
+func newCandles(inOpen, inHigh, inLow, inClose []float64) *candles {
+	c := &candles{open: inOpen, high: inHigh, low: inLow, close: inClose}
+	n := len(inOpen)
+	for r := range c.totals {
+		c.totals[r] = make([]float64, n+1)
+		for i := 0; i < n; i++ {
+			c.totals[r][i+1] = c.totals[r][i] + c.rangeOf(candleRange(r), i)
+		}
+	}
+	return c
+}
+
+func (c *candles) len() int {
+	return len(c.open)
+}
+
+func (c *candles) realBody(i int) float64 {
+	return math.Abs(c.close[i] - c.open[i])
+}
+
+func (c *candles) upperShadow(i int) float64 {
+	return c.high[i] - c.bodyTop(i)
+}
+
+func (c *candles) lowerShadow(i int) float64 {
+	return c.bodyBottom(i) - c.low[i]
+}
+
+func (c *candles) highLow(i int) float64 {
+	return c.high[i] - c.low[i]
+}
+
+func (c *candles) bodyTop(i int) float64 {
+	return math.Max(c.open[i], c.close[i])
+}
+
+func (c *candles) bodyBottom(i int) float64 {
+	return math.Min(c.open[i], c.close[i])
+}
+
+// color is 1 for a white (rising or flat) candle and -1 for a black one.
+func (c *candles) color(i int) int {
+	if c.close[i] >= c.open[i] {
+		return 1
+	}
+	return -1
+}
+
+func (c *candles) rangeOf(r candleRange, i int) float64 {
+	switch r {
+	case rangeRealBody:
+		return c.realBody(i)
+	case rangeHighLow:
+		return c.highLow(i)
+	case rangeShadows:
+		return c.upperShadow(i) + c.lowerShadow(i)
+	}
+	return 0
+}
+
+// average is TA_CANDLEAVERAGE for candle i: the setting's factor applied to
+// the mean range of the avgPeriod candles before i. Shadows count both the
+// upper and lower shadow, so they are halved to compare with a single one.
+func (c *candles) average(s candleSetting, i int) float64 {
+	var avg float64
+	if s.avgPeriod > 0 {
+		avg = (c.totals[s.rangeType][i] - c.totals[s.rangeType][i-s.avgPeriod]) / float64(s.avgPeriod)
+	} else {
+		avg = c.rangeOf(s.rangeType, i)
+	}
+	if s.rangeType == rangeShadows {
+		avg /= 2.0
+	}
+	return s.factor * avg
+}
+
+// realBodyGapUp reports whether the real body of i2 is entirely above the
+// real body of i1; realBodyGapDown is the opposite.
+func (c *candles) realBodyGapUp(i2, i1 int) bool {
+	return c.bodyBottom(i2) > c.bodyTop(i1)
+}
+
+func (c *candles) realBodyGapDown(i2, i1 int) bool {
+	return c.bodyTop(i2) < c.bodyBottom(i1)
+}
+
+// candleGapUp reports whether the whole range of i2 is above i1;
+// candleGapDown is the opposite.
+func (c *candles) candleGapUp(i2, i1 int) bool {
+	return c.low[i2] > c.high[i1]
+}
+
+func (c *candles) candleGapDown(i2, i1 int) bool {
+	return c.high[i2] < c.low[i1]
+}
+
+// scan runs match on every candle past the lookback and collects the result,
+// leaving the lookback period zeroed like the other indicators.
+func (c *candles) scan(lookback int, match func(i int) int) []int {
+	n := c.len()
+	out := make([]int, n)
+	for i := lookback; i < n; i++ {
+		out[i] = match(i)
+	}
+	return out
+}
@@ -0,0 +1,431 @@
+package talib
+
+import (
+	"math"
+)
+
+const smoothPriceSize = 50
+
+// deg2RadBy360 is 2*pi computed the way TA-Lib does, the phase is sensitive
+// to the last bit when the imaginary part is close to zero.
+var deg2RadBy360 = math.Atan(1) * 8
+
+// hilbert holds the state of one of the four Hilbert transforms Ehlers' cycle
+// measurements run in parallel. Odd and even bars are transformed separately.
+type hilbert struct {
+	odd, even                   [3]float64
+	prevOdd, prevEven           float64
+	prevInputOdd, prevInputEven float64
+}
+
+func (h *hilbert) transform(input float64, idx int, isEven bool, adjustedPrevPeriod float64) float64 {
+	const a, b = 0.0962, 0.5769
+	tmp := a * input
+	var v float64
+	if isEven {
+		v = -h.even[idx]
+		h.even[idx] = tmp
+		v += tmp
+		v -= h.prevEven
+		h.prevEven = b * h.prevInputEven
+		v += h.prevEven
+		h.prevInputEven = input
+	} else {
+		v = -h.odd[idx]
+		h.odd[idx] = tmp
+		v += tmp
+		v -= h.prevOdd
+		h.prevOdd = b * h.prevInputOdd
+		v += h.prevOdd
+		h.prevInputOdd = input
+	}
+	return v * adjustedPrevPeriod
+}
+
+// cycle measures the dominant cycle period of a series one bar at a time,
+// the shared core of MAMA and the HT_* family.
+type cycle struct {
+	in []float64
+
+	trailingWMAIdx   int
+	trailingWMAValue float64
+	periodWMASub     float64
+	periodWMASum     float64
+
+	hilbertIdx               int
+	detrender, q1, jI, jQ    hilbert
+	i1OddPrev2, i1OddPrev3   float64
+	i1EvenPrev2, i1EvenPrev3 float64
+	prevI2, prevQ2, re, im   float64
+
+	period       float64
+	smoothPeriod float64
+
+	// Values of the last step.
+	smoothed float64
+	inPhase  float64
+	quad     float64
+
+	smoothPrice    [smoothPriceSize]float64
+	smoothPriceIdx int
+	dcPhase        float64
+}
+
+// newCycle primes the 4 bar weighted price average and runs it for warmup
+// more bars, returning the cycle and the index of the next bar to step.
+func newCycle(in []float64, warmup int) (*cycle, int) {
+	c := &cycle{in: in}
+	c.periodWMASub = in[0] + in[1] + in[2]
+	c.periodWMASum = in[0] + in[1]*2 + in[2]*3
+	today := 3
+	for i := 0; i < warmup; i++ {
+		c.priceWMA(in[today])
+		today++
+	}
+	return c, today
+}
+
+func (c *cycle) priceWMA(price float64) float64 {
+	c.periodWMASub += price
+	c.periodWMASub -= c.trailingWMAValue
+	c.periodWMASum += price * 4.0
+	c.trailingWMAValue = c.in[c.trailingWMAIdx]
+	c.trailingWMAIdx++
+	smoothed := c.periodWMASum * 0.1
+	c.periodWMASum -= c.periodWMASub
+	return smoothed
+}
+
+// step advances the measurement by the bar at today.
+func (c *cycle) step(today int) {
+	adjustedPrevPeriod := 0.075*c.period + 0.54
+	c.smoothed = c.priceWMA(c.in[today])
+	c.smoothPrice[c.smoothPriceIdx] = c.smoothed
+
+	var q2, i2 float64
+	if today%2 == 0 {
+		detrender := c.detrender.transform(c.smoothed, c.hilbertIdx, true, adjustedPrevPeriod)
+		q1 := c.q1.transform(detrender, c.hilbertIdx, true, adjustedPrevPeriod)
+		c.inPhase, c.quad = c.i1EvenPrev3, q1
+		jI := c.jI.transform(c.i1EvenPrev3, c.hilbertIdx, true, adjustedPrevPeriod)
+		jQ := c.jQ.transform(q1, c.hilbertIdx, true, adjustedPrevPeriod)
+		c.hilbertIdx++
+		if c.hilbertIdx == 3 {
+			c.hilbertIdx = 0
+		}
+		q2 = 0.2*(q1+jI) + 0.8*c.prevQ2
+		i2 = 0.2*(c.i1EvenPrev3-jQ) + 0.8*c.prevI2
+		c.i1OddPrev3 = c.i1OddPrev2
+		c.i1OddPrev2 = detrender
+	} else {
+		detrender := c.detrender.transform(c.smoothed, c.hilbertIdx, false, adjustedPrevPeriod)
+		q1 := c.q1.transform(detrender, c.hilbertIdx, false, adjustedPrevPeriod)
+		c.inPhase, c.quad = c.i1OddPrev3, q1
+		jI := c.jI.transform(c.i1OddPrev3, c.hilbertIdx, false, adjustedPrevPeriod)
+		jQ := c.jQ.transform(q1, c.hilbertIdx, false, adjustedPrevPeriod)
+		q2 = 0.2*(q1+jI) + 0.8*c.prevQ2
+		i2 = 0.2*(c.i1OddPrev3-jQ) + 0.8*c.prevI2
+		c.i1EvenPrev3 = c.i1EvenPrev2
+		c.i1EvenPrev2 = detrender
+	}
+
+	c.re = 0.2*(i2*c.prevI2+q2*c.prevQ2) + 0.8*c.re
+	c.im = 0.2*(i2*c.prevQ2-q2*c.prevI2) + 0.8*c.im
+	c.prevQ2 = q2
+	c.prevI2 = i2
+	prevPeriod := c.period
+	if c.im != 0 && c.re != 0 {
+		c.period = 360.0 / (math.Atan(c.im/c.re) * rad2Deg)
+	}
+	if limit := 1.5 * prevPeriod; c.period > limit {
+		c.period = limit
+	}
+	if limit := 0.67 * prevPeriod; c.period < limit {
+		c.period = limit
+	}
+	if c.period < 6 {
+		c.period = 6
+	} else if c.period > 50 {
+		c.period = 50
+	}
+	c.period = 0.2*c.period + 0.8*prevPeriod
+	c.smoothPeriod = 0.33*c.period + 0.67*c.smoothPeriod
+}
+
+// phase updates the dominant cycle phase from the smoothed prices of the
+// last smoothPeriod bars.
+func (c *cycle) phase() float64 {
+	dcPeriodInt := int(c.smoothPeriod + 0.5)
+	var realPart, imagPart float64
+	idx := c.smoothPriceIdx
+	for i := 0; i < dcPeriodInt; i++ {
+		angle := (float64(i) * deg2RadBy360) / float64(dcPeriodInt)
+		realPart += math.Sin(angle) * c.smoothPrice[idx]
+		imagPart += math.Cos(angle) * c.smoothPrice[idx]
+		if idx == 0 {
+			idx = smoothPriceSize - 1
+		} else {
+			idx--
+		}
+	}
+	if v := math.Abs(imagPart); v > 0 {
+		c.dcPhase = math.Atan(realPart/imagPart) * rad2Deg
+	} else if v <= 0.01 {
+		if realPart < 0 {
+			c.dcPhase -= 90
+		} else if realPart > 0 {
+			c.dcPhase += 90
+		}
+	}
+	c.dcPhase += 90
+	// Compensate the one bar lag of the weighted moving average.
+	c.dcPhase += 360.0 / c.smoothPeriod
+	if imagPart < 0 {
+		c.dcPhase += 180
+	}
+	if c.dcPhase > 315 {
+		c.dcPhase -= 360
+	}
+	return c.dcPhase
+}
+
+// trend returns the average price over the dominant cycle ending at today.
+func (c *cycle) trend(today int) float64 {
+	dcPeriodInt := int(c.smoothPeriod + 0.5)
+	sum := 0.0
+	for i := 0; i < dcPeriodInt; i++ {
+		sum += c.in[today-i]
+	}
+	if dcPeriodInt > 0 {
+		sum /= float64(dcPeriodInt)
+	}
+	return sum
+}
+
+func (c *cycle) advance() {
+	c.smoothPriceIdx++
+	if c.smoothPriceIdx > smoothPriceSize-1 {
+		c.smoothPriceIdx = 0
+	}
+}
+
+// mesa computes Ehlers' MESA adaptive moving average and its following
+// average, starting at index 32.
+func mesa(in []float64, fastLimit, slowLimit float64) (int, []float64, []float64) {
+	const lookback = 32
+	if len(in) <= lookback {
+		return 0, nil, nil
+	}
+	mama := make([]float64, len(in)-lookback)
+	fama := make([]float64, len(in)-lookback)
+	c, today := newCycle(in, 9)
+	var prevPhase, mamaValue, famaValue float64
+	for ; today < len(in); today++ {
+		c.step(today)
+		phase := 0.0
+		if c.inPhase != 0 {
+			phase = math.Atan(c.quad/c.inPhase) * rad2Deg
+		}
+		delta := prevPhase - phase
+		prevPhase = phase
+		if delta < 1 {
+			delta = 1
+		}
+		alpha := fastLimit
+		if delta > 1 {
+			alpha = fastLimit / delta
+			if alpha < slowLimit {
+				alpha = slowLimit
+			}
+		}
+		mamaValue = alpha*in[today] + (1-alpha)*mamaValue
+		alpha *= 0.5
+		famaValue = alpha*mamaValue + (1-alpha)*famaValue
+		if today >= lookback {
+			mama[today-lookback] = mamaValue
+			fama[today-lookback] = famaValue
+		}
+	}
+	return lookback, mama, fama
+}
+
+/*
+ * TA_HT_DCPERIOD - Hilbert Transform - Dominant Cycle Period
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func HtDcPeriod(inReal []float64) (outReal []float64) {
+	const lookback = 32
+	outReal = make([]float64, len(inReal))
+	if len(inReal) <= lookback {
+		return outReal
+	}
+	c, today := newCycle(inReal, 9)
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		if today >= lookback {
+			outReal[today] = c.smoothPeriod
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_HT_DCPHASE - Hilbert Transform - Dominant Cycle Phase
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func HtDcPhase(inReal []float64) (outReal []float64) {
+	const lookback = 63
+	outReal = make([]float64, len(inReal))
+	if len(inReal) <= lookback {
+		return outReal
+	}
+	c, today := newCycle(inReal, 34)
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		phase := c.phase()
+		if today >= lookback {
+			outReal[today] = phase
+		}
+		c.advance()
+	}
+	return outReal
+}
+
+/*
+ * TA_HT_PHASOR - Hilbert Transform - Phasor Components
+ *
+ * Input  = double
+ * Output = double, double
+ *
+ */
+func HtPhasor(inReal []float64) (outInPhase []float64, outQuadrature []float64) {
+	const lookback = 32
+	outInPhase = make([]float64, len(inReal))
+	outQuadrature = make([]float64, len(inReal))
+	if len(inReal) <= lookback {
+		return
+	}
+	c, today := newCycle(inReal, 9)
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		if today >= lookback {
+			outInPhase[today] = c.inPhase
+			outQuadrature[today] = c.quad
+		}
+	}
+	return
+}
+
+/*
+ * TA_HT_SINE - Hilbert Transform - SineWave
+ *
+ * Input  = double
+ * Output = double, double
+ *
+ */
+func HtSine(inReal []float64) (outSine []float64, outLeadSine []float64) {
+	const lookback = 63
+	outSine = make([]float64, len(inReal))
+	outLeadSine = make([]float64, len(inReal))
+	if len(inReal) <= lookback {
+		return
+	}
+	c, today := newCycle(inReal, 34)
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		phase := c.phase()
+		if today >= lookback {
+			outSine[today] = math.Sin(phase * deg2Rad)
+			outLeadSine[today] = math.Sin((phase + 45) * deg2Rad)
+		}
+		c.advance()
+	}
+	return
+}
+
+/*
+ * TA_HT_TRENDLINE - Hilbert Transform - Instantaneous Trendline
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func HtTrendLine(inReal []float64) (outReal []float64) {
+	const lookback = 63
+	outReal = make([]float64, len(inReal))
+	if len(inReal) <= lookback {
+		return outReal
+	}
+	c, today := newCycle(inReal, 34)
+	var iTrend1, iTrend2, iTrend3 float64
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		trend := c.trend(today)
+		trendLine := (4.0*trend + 3.0*iTrend1 + 2.0*iTrend2 + iTrend3) / 10.0
+		iTrend3, iTrend2, iTrend1 = iTrend2, iTrend1, trend
+		if today >= lookback {
+			outReal[today] = trendLine
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_HT_TRENDMODE - Hilbert Transform - Trend vs Cycle Mode
+ *
+ * Input  = double
+ * Output = int
+ *
+ */
+func HtTrendMode(inReal []float64) (outInteger []int) {
+	const lookback = 63
+	outInteger = make([]int, len(inReal))
+	if len(inReal) <= lookback {
+		return outInteger
+	}
+	c, today := newCycle(inReal, 34)
+	var iTrend1, iTrend2, iTrend3 float64
+	var sine, leadSine, prevDCPhase float64
+	daysInTrend := 0
+	for ; today < len(inReal); today++ {
+		c.step(today)
+		prevDCPhase = c.dcPhase
+		phase := c.phase()
+		prevSine, prevLeadSine := sine, leadSine
+		sine = math.Sin(phase * deg2Rad)
+		leadSine = math.Sin((phase + 45) * deg2Rad)
+
+		trend := c.trend(today)
+		trendLine := (4.0*trend + 3.0*iTrend1 + 2.0*iTrend2 + iTrend3) / 10.0
+		iTrend3, iTrend2, iTrend1 = iTrend2, iTrend1, trend
+
+		mode := 1
+		// A sine crossing its lead sine starts a new cycle mode.
+		if (sine > leadSine && prevSine <= prevLeadSine) || (sine < leadSine && prevSine >= prevLeadSine) {
+			daysInTrend = 0
+			mode = 0
+		}
+		daysInTrend++
+		if float64(daysInTrend) < 0.5*c.smoothPeriod {
+			mode = 0
+		}
+		delta := phase - prevDCPhase
+		if c.smoothPeriod != 0 && delta > 0.67*360.0/c.smoothPeriod && delta < 1.5*360.0/c.smoothPeriod {
+			mode = 0
+		}
+		if price := c.smoothPrice[c.smoothPriceIdx]; trendLine != 0 && math.Abs((price-trendLine)/trendLine) >= 0.015 {
+			mode = 1
+		}
+		if today >= lookback {
+			outInteger[today] = mode
+		}
+		c.advance()
+	}
+	return outInteger
+}
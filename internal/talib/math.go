@@ -0,0 +1,410 @@
+package talib
+
+import (
+	"math"
+)
+
+func transform(inReal []float64, fn func(float64) float64) []float64 {
+	out := make([]float64, len(inReal))
+	for i, v := range inReal {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+func operate(inReal0, inReal1 []float64, fn func(a, b float64) float64) []float64 {
+	out := make([]float64, len(inReal0))
+	for i := range inReal0 {
+		out[i] = fn(inReal0[i], inReal1[i])
+	}
+	return out
+}
+
+/*
+ * TA_ACOS - Vector Trigonometric ACos
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Acos(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Acos)
+}
+
+/*
+ * TA_ASIN - Vector Trigonometric ASin
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func ASin(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Asin)
+}
+
+/*
+ * TA_ATAN - Vector Trigonometric ATan
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Atan(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Atan)
+}
+
+/*
+ * TA_CEIL - Vector Ceil
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Ceil(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Ceil)
+}
+
+/*
+ * TA_COS - Vector Trigonometric Cos
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Cos(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Cos)
+}
+
+/*
+ * TA_COSH - Vector Trigonometric Cosh
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Cosh(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Cosh)
+}
+
+/*
+ * TA_EXP - Vector Arithmetic Exp
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Exp(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Exp)
+}
+
+/*
+ * TA_FLOOR - Vector Floor
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Floor(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Floor)
+}
+
+/*
+ * TA_LN - Vector Log Natural
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Ln(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Log)
+}
+
+/*
+ * TA_LOG10 - Vector Log10
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Log10(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Log10)
+}
+
+/*
+ * TA_SIN - Vector Trigonometric Sin
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Sin(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Sin)
+}
+
+/*
+ * TA_SINH - Vector Trigonometric Sinh
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Sinh(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Sinh)
+}
+
+/*
+ * TA_SQRT - Vector Square Root
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Sqrt(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Sqrt)
+}
+
+/*
+ * TA_TAN - Vector Trigonometric Tan
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Tan(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Tan)
+}
+
+/*
+ * TA_TANH - Vector Trigonometric Tanh
+ *
+ * Input  = double
+ * Output = double
+ *
+ */
+func Tanh(inReal []float64) (outReal []float64) {
+	return transform(inReal, math.Tanh)
+}
+
+/*
+ * TA_ADD - Vector Arithmetic Add
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ */
+func Add(inReal0 []float64, inReal1 []float64) (outReal []float64) {
+	return operate(inReal0, inReal1, func(a, b float64) float64 { return a + b })
+}
+
+/*
+ * TA_SUB - Vector Arithmetic Substraction
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ */
+func Sub(inReal0 []float64, inReal1 []float64) (outReal []float64) {
+	return operate(inReal0, inReal1, func(a, b float64) float64 { return a - b })
+}
+
+/*
+ * TA_MULT - Vector Arithmetic Mult
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ */
+func Mult(inReal0 []float64, inReal1 []float64) (outReal []float64) {
+	return operate(inReal0, inReal1, func(a, b float64) float64 { return a * b })
+}
+
+/*
+ * TA_DIV - Vector Arithmetic Div
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ */
+func Div(inReal0 []float64, inReal1 []float64) (outReal []float64) {
+	return operate(inReal0, inReal1, func(a, b float64) float64 { return a / b })
+}
+
+// windowExtreme tracks the index of the highest (or lowest) value of the
+// last period values. A fresh scan keeps the oldest of equal values while
+// an incremental update prefers the newest one, mirroring TA-Lib's indexes.
+func windowExtreme(in []float64, period int, highest bool) []int {
+	out := make([]int, len(in))
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return out
+	}
+	better := func(a, b float64) bool {
+		if highest {
+			return a > b
+		}
+		return a < b
+	}
+	idx := -1
+	var extreme float64
+	for today := lookback; today < len(in); today++ {
+		trailingIdx := today - lookback
+		if idx < trailingIdx {
+			idx, extreme = trailingIdx, in[trailingIdx]
+			for i := trailingIdx + 1; i <= today; i++ {
+				if better(in[i], extreme) {
+					idx, extreme = i, in[i]
+				}
+			}
+		} else if in[today] == extreme || better(in[today], extreme) {
+			idx, extreme = today, in[today]
+		}
+		out[today] = idx
+	}
+	return out
+}
+
+func windowValues(in []float64, period int, highest bool) []float64 {
+	out := make([]float64, len(in))
+	if period < 1 {
+		return out
+	}
+	idx := windowExtreme(in, period, highest)
+	for today := period - 1; today < len(in); today++ {
+		out[today] = in[idx[today]]
+	}
+	return out
+}
+
+/*
+ * TA_MAX - Highest value over a specified period
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Max(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return windowValues(inReal, optInTimePeriod, true)
+}
+
+/*
+ * TA_MAXINDEX - Index of highest value over a specified period
+ *
+ * Input  = double
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MaxIndex(inReal []float64, optInTimePeriod int) (outInteger []int) {
+	return windowExtreme(inReal, optInTimePeriod, true)
+}
+
+/*
+ * TA_MIN - Lowest value over a specified period
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Min(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return windowValues(inReal, optInTimePeriod, false)
+}
+
+/*
+ * TA_MININDEX - Index of lowest value over a specified period
+ *
+ * Input  = double
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MinIndex(inReal []float64, optInTimePeriod int) (outInteger []int) {
+	return windowExtreme(inReal, optInTimePeriod, false)
+}
+
+/*
+ * TA_MINMAX - Lowest and highest values over a specified period
+ *
+ * Input  = double
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MinMax(inReal []float64, optInTimePeriod int) (outMin []float64, outMax []float64) {
+	return windowValues(inReal, optInTimePeriod, false), windowValues(inReal, optInTimePeriod, true)
+}
+
+/*
+ * TA_MINMAXINDEX - Indexes of lowest and highest values over a specified period
+ *
+ * Input  = double
+ * Output = int, int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MinMaxIndex(inReal []float64, optInTimePeriod int) (outMinIdx []int, outMaxIdx []int) {
+	return windowExtreme(inReal, optInTimePeriod, false), windowExtreme(inReal, optInTimePeriod, true)
+}
+
+/*
+ * TA_SUM - Summation
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Sum(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inReal)
+	outReal = make([]float64, n)
+	lookback := optInTimePeriod - 1
+	if optInTimePeriod < 1 || n <= lookback {
+		return outReal
+	}
+	sum := 0.0
+	for i := 0; i < lookback; i++ {
+		sum += inReal[i]
+	}
+	for today := lookback; today < n; today++ {
+		sum += inReal[today]
+		outReal[today] = sum
+		sum -= inReal[today-lookback]
+	}
+	return outReal
+}
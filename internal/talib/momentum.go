@@ -0,0 +1,1151 @@
+package talib
+
+import (
+	"math"
+)
+
+// directionalMovement returns the +DM and -DM of bar today against the bar
+// before it. Only the larger of the two positive moves is kept.
+func directionalMovement(inHigh, inLow []float64, today int) (plusDM, minusDM float64) {
+	diffP := inHigh[today] - inHigh[today-1]
+	diffM := inLow[today-1] - inLow[today]
+	if diffP > 0 && diffP > diffM {
+		plusDM = diffP
+	}
+	if diffM > 0 && diffP < diffM {
+		minusDM = diffM
+	}
+	return
+}
+
+// wilderDM smooths +DM, -DM and the true range with Wilder's method. The
+// first value, at index period, is the running sum of the period-1 initial
+// moves advanced by one smoothing step.
+func wilderDM(inHigh, inLow, inClose []float64, period int) (plusDM, minusDM, tr []float64) {
+	n := len(inHigh)
+	if n <= period {
+		return nil, nil, nil
+	}
+	plusDM = make([]float64, n)
+	minusDM = make([]float64, n)
+	tr = make([]float64, n)
+	var prevPlusDM, prevMinusDM, prevTR float64
+	p := float64(period)
+	for today := 1; today < n; today++ {
+		plus, minus := directionalMovement(inHigh, inLow, today)
+		r := trueRange(inHigh[today], inLow[today], inClose[today-1])
+		if today < period {
+			prevPlusDM += plus
+			prevMinusDM += minus
+			prevTR += r
+		} else {
+			prevPlusDM = prevPlusDM - prevPlusDM/p + plus
+			prevMinusDM = prevMinusDM - prevMinusDM/p + minus
+			prevTR = prevTR - prevTR/p + r
+		}
+		plusDM[today] = prevPlusDM
+		minusDM[today] = prevMinusDM
+		tr[today] = prevTR
+	}
+	return
+}
+
+func dmi(plusDM, minusDM, tr float64) (plusDI, minusDI float64) {
+	return 100.0 * (plusDM / tr), 100.0 * (minusDM / tr)
+}
+
+/*
+ * TA_ADX - Average Directional Movement Index
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Adx(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	lookback := 2*optInTimePeriod - 1
+	if n <= lookback {
+		return outReal
+	}
+	plusDM, minusDM, tr := wilderDM(inHigh, inLow, inClose, optInTimePeriod)
+	p := float64(optInTimePeriod)
+	sumDX := 0.0
+	for today := optInTimePeriod; today <= lookback; today++ {
+		if isZero(tr[today]) {
+			continue
+		}
+		plusDI, minusDI := dmi(plusDM[today], minusDM[today], tr[today])
+		if sum := minusDI + plusDI; !isZero(sum) {
+			sumDX += 100.0 * (math.Abs(minusDI-plusDI) / sum)
+		}
+	}
+	prevADX := sumDX / p
+	outReal[lookback] = prevADX
+	for today := lookback + 1; today < n; today++ {
+		if !isZero(tr[today]) {
+			plusDI, minusDI := dmi(plusDM[today], minusDM[today], tr[today])
+			if sum := minusDI + plusDI; !isZero(sum) {
+				dx := 100.0 * (math.Abs(minusDI-plusDI) / sum)
+				prevADX = (prevADX*(p-1) + dx) / p
+			}
+		}
+		outReal[today] = prevADX
+	}
+	return outReal
+}
+
+/*
+ * TA_ADXR - Average Directional Movement Index Rating
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Adxr(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	lookback := 3*optInTimePeriod - 2
+	if n <= lookback {
+		return outReal
+	}
+	adx := Adx(inHigh, inLow, inClose, optInTimePeriod)
+	for today := lookback; today < n; today++ {
+		outReal[today] = (adx[today] + adx[today-optInTimePeriod+1]) / 2.0
+	}
+	return outReal
+}
+
+/*
+ * TA_DX - Directional Movement Index
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Dx(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if n <= optInTimePeriod {
+		return outReal
+	}
+	plusDM, minusDM, tr := wilderDM(inHigh, inLow, inClose, optInTimePeriod)
+	for today := optInTimePeriod; today < n; today++ {
+		outReal[today] = outReal[today-1]
+		if isZero(tr[today]) {
+			continue
+		}
+		plusDI, minusDI := dmi(plusDM[today], minusDM[today], tr[today])
+		if sum := minusDI + plusDI; !isZero(sum) {
+			outReal[today] = 100.0 * (math.Abs(minusDI-plusDI) / sum)
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_PLUS_DI - Plus Directional Indicator
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func PlusDi(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if optInTimePeriod <= 1 {
+		for today := 1; today < n; today++ {
+			plus, _ := directionalMovement(inHigh, inLow, today)
+			if r := trueRange(inHigh[today], inLow[today], inClose[today-1]); !isZero(r) {
+				outReal[today] = plus / r
+			}
+		}
+		return outReal
+	}
+	if n <= optInTimePeriod {
+		return outReal
+	}
+	plusDM, _, tr := wilderDM(inHigh, inLow, inClose, optInTimePeriod)
+	for today := optInTimePeriod; today < n; today++ {
+		if !isZero(tr[today]) {
+			outReal[today] = 100.0 * (plusDM[today] / tr[today])
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_MINUS_DI - Minus Directional Indicator
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MinusDi(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if optInTimePeriod <= 1 {
+		for today := 1; today < n; today++ {
+			_, minus := directionalMovement(inHigh, inLow, today)
+			if r := trueRange(inHigh[today], inLow[today], inClose[today-1]); !isZero(r) {
+				outReal[today] = minus / r
+			}
+		}
+		return outReal
+	}
+	if n <= optInTimePeriod {
+		return outReal
+	}
+	_, minusDM, tr := wilderDM(inHigh, inLow, inClose, optInTimePeriod)
+	for today := optInTimePeriod; today < n; today++ {
+		if !isZero(tr[today]) {
+			outReal[today] = 100.0 * (minusDM[today] / tr[today])
+		}
+	}
+	return outReal
+}
+
+// smoothedDM applies Wilder's smoothing to one side of the directional
+// movement, starting with the plain sum over the first period-1 moves.
+func smoothedDM(inHigh, inLow []float64, period int, plus bool) []float64 {
+	n := len(inHigh)
+	out := make([]float64, n)
+	pick := func(today int) float64 {
+		p, m := directionalMovement(inHigh, inLow, today)
+		if plus {
+			return p
+		}
+		return m
+	}
+	if period <= 1 {
+		for today := 1; today < n; today++ {
+			out[today] = pick(today)
+		}
+		return out
+	}
+	if n < period {
+		return out
+	}
+	prev := 0.0
+	for today := 1; today < period; today++ {
+		prev += pick(today)
+	}
+	out[period-1] = prev
+	p := float64(period)
+	for today := period; today < n; today++ {
+		prev = prev - prev/p + pick(today)
+		out[today] = prev
+	}
+	return out
+}
+
+/*
+ * TA_PLUS_DM - Plus Directional Movement
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func PlusDm(inHigh []float64, inLow []float64, optInTimePeriod int) (outReal []float64) {
+	return smoothedDM(inHigh, inLow, optInTimePeriod, true)
+}
+
+/*
+ * TA_MINUS_DM - Minus Directional Movement
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MinusDm(inHigh []float64, inLow []float64, optInTimePeriod int) (outReal []float64) {
+	return smoothedDM(inHigh, inLow, optInTimePeriod, false)
+}
+
+// priceOscillator returns the fast and slow averages aligned on the slow
+// one's lookback, swapping the periods if they were given in reverse.
+func priceOscillator(inReal []float64, fastPeriod, slowPeriod, maType int) (int, []float64, []float64) {
+	if slowPeriod < fastPeriod {
+		fastPeriod, slowPeriod = slowPeriod, fastPeriod
+	}
+	lookback := MaLookback(slowPeriod, maType)
+	if len(inReal) <= lookback {
+		return 0, nil, nil
+	}
+	fast := maFrom(inReal, lookback, fastPeriod, maType)
+	_, slow := ma(inReal, slowPeriod, maType)
+	return lookback, fast, slow
+}
+
+/*
+ * TA_APO - Absolute Price Oscillator
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastPeriod:(From 2 to 100000)
+ *    Number of period for the fast MA
+ *
+ * optInSlowPeriod:(From 2 to 100000)
+ *    Number of period for the slow MA
+ *
+ * optInMAType:
+ *    Type of Moving Average
+ *
+ *
+ */
+func Apo(inReal []float64, optInFastPeriod int, optInSlowPeriod int, optInMAType int) (outReal []float64) {
+	beg, fast, slow := priceOscillator(inReal, optInFastPeriod, optInSlowPeriod, optInMAType)
+	out := make([]float64, len(slow))
+	for i := range out {
+		out[i] = fast[i] - slow[i]
+	}
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_PPO - Percentage Price Oscillator
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastPeriod:(From 2 to 100000)
+ *    Number of period for the fast MA
+ *
+ * optInSlowPeriod:(From 2 to 100000)
+ *    Number of period for the slow MA
+ *
+ * optInMAType:
+ *    Type of Moving Average
+ *
+ *
+ */
+func Ppo(inReal []float64, optInFastPeriod int, optInSlowPeriod int, optInMAType int) (outReal []float64) {
+	beg, fast, slow := priceOscillator(inReal, optInFastPeriod, optInSlowPeriod, optInMAType)
+	out := make([]float64, len(slow))
+	for i := range out {
+		if !isZero(slow[i]) {
+			out[i] = ((fast[i] - slow[i]) / slow[i]) * 100.0
+		}
+	}
+	return pad(len(inReal), beg, out)
+}
+
+// aroon returns, for every bar from index period on, how many bars ago the
+// highest high and the lowest low of the last period+1 bars occurred.
+func aroon(inHigh, inLow []float64, period int) (down, up []float64) {
+	n := len(inHigh)
+	down = make([]float64, n)
+	up = make([]float64, n)
+	if period < 1 || n <= period {
+		return
+	}
+	factor := 100.0 / float64(period)
+	lowestIdx, highestIdx := -1, -1
+	var lowest, highest float64
+	for today := period; today < n; today++ {
+		trailingIdx := today - period
+		if lowestIdx < trailingIdx {
+			lowestIdx, lowest = trailingIdx, inLow[trailingIdx]
+			for i := trailingIdx + 1; i <= today; i++ {
+				if inLow[i] <= lowest {
+					lowestIdx, lowest = i, inLow[i]
+				}
+			}
+		} else if inLow[today] <= lowest {
+			lowestIdx, lowest = today, inLow[today]
+		}
+		if highestIdx < trailingIdx {
+			highestIdx, highest = trailingIdx, inHigh[trailingIdx]
+			for i := trailingIdx + 1; i <= today; i++ {
+				if inHigh[i] >= highest {
+					highestIdx, highest = i, inHigh[i]
+				}
+			}
+		} else if inHigh[today] >= highest {
+			highestIdx, highest = today, inHigh[today]
+		}
+		down[today] = factor * float64(period-(today-lowestIdx))
+		up[today] = factor * float64(period-(today-highestIdx))
+	}
+	return
+}
+
+/*
+ * TA_AROON - Aroon
+ *
+ * Input  = High, Low
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func AroOn(inHigh []float64, inLow []float64, optInTimePeriod int) (outAroonDown []float64, outAroonUp []float64) {
+	return aroon(inHigh, inLow, optInTimePeriod)
+}
+
+/*
+ * TA_AROONOSC - Aroon Oscillator
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func AroOnOsc(inHigh []float64, inLow []float64, optInTimePeriod int) (outReal []float64) {
+	down, up := aroon(inHigh, inLow, optInTimePeriod)
+	outReal = make([]float64, len(inHigh))
+	for today := optInTimePeriod; today < len(inHigh); today++ {
+		outReal[today] = up[today] - down[today]
+	}
+	return outReal
+}
+
+/*
+ * TA_BOP - Balance Of Power
+ *
+ * Input  = Open, High, Low, Close
+ * Output = double
+ *
+ */
+func Bop(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outReal []float64) {
+	outReal = make([]float64, len(inOpen))
+	for i := range inOpen {
+		if r := inHigh[i] - inLow[i]; !isZeroOrNeg(r) {
+			outReal[i] = (inClose[i] - inOpen[i]) / r
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_CCI - Commodity Channel Index
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Cci(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	lookback := optInTimePeriod - 1
+	if optInTimePeriod < 1 || n <= lookback {
+		return outReal
+	}
+	p := float64(optInTimePeriod)
+	typical := TypPrice(inHigh, inLow, inClose)
+	sum := 0.0
+	for i := 0; i < lookback; i++ {
+		sum += typical[i]
+	}
+	for today := lookback; today < n; today++ {
+		sum += typical[today]
+		average := sum / p
+		deviation := 0.0
+		for i := today - lookback; i <= today; i++ {
+			deviation += math.Abs(typical[i] - average)
+		}
+		if diff := typical[today] - average; diff != 0 && deviation != 0 {
+			outReal[today] = diff / (0.015 * (deviation / p))
+		}
+		sum -= typical[today-lookback]
+	}
+	return outReal
+}
+
+// wilderGainLoss walks the series keeping Wilder's average gain and loss and
+// calls emit for every bar from index period on.
+func wilderGainLoss(inReal []float64, period int, emit func(today int, gain, loss float64)) {
+	if period < 1 || len(inReal) <= period {
+		return
+	}
+	p := float64(period)
+	var gain, loss float64
+	for today := 1; today <= period; today++ {
+		diff := inReal[today] - inReal[today-1]
+		if diff < 0 {
+			loss -= diff
+		} else {
+			gain += diff
+		}
+	}
+	gain /= p
+	loss /= p
+	emit(period, gain, loss)
+	for today := period + 1; today < len(inReal); today++ {
+		diff := inReal[today] - inReal[today-1]
+		gain *= p - 1
+		loss *= p - 1
+		if diff < 0 {
+			loss -= diff
+		} else {
+			gain += diff
+		}
+		gain /= p
+		loss /= p
+		emit(today, gain, loss)
+	}
+}
+
+/*
+ * TA_RSI - Relative Strength Index
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Rsi(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	outReal = make([]float64, len(inReal))
+	wilderGainLoss(inReal, optInTimePeriod, func(today int, gain, loss float64) {
+		if total := gain + loss; !isZero(total) {
+			outReal[today] = 100.0 * (gain / total)
+		}
+	})
+	return outReal
+}
+
+/*
+ * TA_CMO - Chande Momentum Oscillator
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Cmo(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	outReal = make([]float64, len(inReal))
+	wilderGainLoss(inReal, optInTimePeriod, func(today int, gain, loss float64) {
+		if total := gain + loss; !isZero(total) {
+			outReal[today] = 100.0 * ((gain - loss) / total)
+		}
+	})
+	return outReal
+}
+
+// macd builds the MACD line out of two averages that both start on the slow
+// average's lookback, then smooths it with the signal average.
+func macd(inReal []float64, fast, slow []float64, slowLookback, signalPeriod, signalMAType int) (outMACD, outMACDSignal, outMACDHist []float64) {
+	n := len(inReal)
+	outMACD = make([]float64, n)
+	outMACDSignal = make([]float64, n)
+	outMACDHist = make([]float64, n)
+	if len(slow) == 0 {
+		return
+	}
+	line := make([]float64, len(slow))
+	for i := range line {
+		line[i] = fast[i] - slow[i]
+	}
+	signalBeg, signal := ma(line, signalPeriod, signalMAType)
+	for i, s := range signal {
+		idx := slowLookback + signalBeg + i
+		outMACD[idx] = line[signalBeg+i]
+		outMACDSignal[idx] = s
+		outMACDHist[idx] = line[signalBeg+i] - s
+	}
+	return
+}
+
+// fixedMacd is the classic MACD where a zero period means 12/26 with the
+// traditional 0.15 and 0.075 smoothing factors.
+func fixedMacd(inReal []float64, fastPeriod, slowPeriod, signalPeriod int) ([]float64, []float64, []float64) {
+	if slowPeriod != 0 && fastPeriod != 0 && slowPeriod < fastPeriod {
+		fastPeriod, slowPeriod = slowPeriod, fastPeriod
+	}
+	var fastK, slowK float64
+	if slowPeriod != 0 {
+		slowK = perToK(slowPeriod)
+	} else {
+		slowPeriod, slowK = 26, 0.075
+	}
+	if fastPeriod != 0 {
+		fastK = perToK(fastPeriod)
+	} else {
+		fastPeriod, fastK = 12, 0.15
+	}
+	slowLookback := slowPeriod - 1
+	if len(inReal) <= slowLookback {
+		return macd(inReal, nil, nil, 0, signalPeriod, EMA)
+	}
+	_, fast := ema(inReal[slowLookback-(fastPeriod-1):], fastPeriod, fastK)
+	_, slow := ema(inReal, slowPeriod, slowK)
+	return macd(inReal, fast, slow, slowLookback, signalPeriod, EMA)
+}
+
+/*
+ * TA_MACD - Moving Average Convergence/Divergence
+ *
+ * Input  = double
+ * Output = double, double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastPeriod:(From 2 to 100000)
+ *    Number of period for the fast MA
+ *
+ * optInSlowPeriod:(From 2 to 100000)
+ *    Number of period for the slow MA
+ *
+ * optInSignalPeriod:(From 1 to 100000)
+ *    Smoothing for the signal line (nb of period)
+ *
+ *
+ */
+func Macd(inReal []float64, optInFastPeriod int, optInSlowPeriod int, optInSignalPeriod int) (outMACD []float64, outMACDSignal []float64, outMACDHist []float64) {
+	return fixedMacd(inReal, optInFastPeriod, optInSlowPeriod, optInSignalPeriod)
+}
+
+/*
+ * TA_MACDFIX - Moving Average Convergence/Divergence Fix 12/26
+ *
+ * Input  = double
+ * Output = double, double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInSignalPeriod:(From 1 to 100000)
+ *    Smoothing for the signal line (nb of period)
+ *
+ *
+ */
+func MacdFix(inReal []float64, optInSignalPeriod int) (outMACD []float64, outMACDSignal []float64, outMACDHist []float64) {
+	return fixedMacd(inReal, 0, 0, optInSignalPeriod)
+}
+
+/*
+ * TA_MACDEXT - MACD with controllable MA type
+ *
+ * Input  = double
+ * Output = double, double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastPeriod:(From 2 to 100000)
+ *    Number of period for the fast MA
+ *
+ * optInFastMAType:
+ *    Type of Moving Average for fast MA
+ *
+ * optInSlowPeriod:(From 2 to 100000)
+ *    Number of period for the slow MA
+ *
+ * optInSlowMAType:
+ *    Type of Moving Average for slow MA
+ *
+ * optInSignalPeriod:(From 1 to 100000)
+ *    Smoothing for the signal line (nb of period)
+ *
+ * optInSignalMAType:
+ *    Type of Moving Average for signal line
+ *
+ *
+ */
+func MacdExt(inReal []float64, optInFastPeriod int, optInFastMAType int, optInSlowPeriod int, optInSlowMAType int, optInSignalPeriod int, optInSignalMAType int) (outMACD []float64, outMACDSignal []float64, outMACDHist []float64) {
+	if optInSlowPeriod < optInFastPeriod {
+		optInFastPeriod, optInSlowPeriod = optInSlowPeriod, optInFastPeriod
+		optInFastMAType, optInSlowMAType = optInSlowMAType, optInFastMAType
+	}
+	lookbackLargest := MaLookback(optInFastPeriod, optInFastMAType)
+	if l := MaLookback(optInSlowPeriod, optInSlowMAType); l > lookbackLargest {
+		lookbackLargest = l
+	}
+	if len(inReal) <= lookbackLargest {
+		return macd(inReal, nil, nil, 0, optInSignalPeriod, optInSignalMAType)
+	}
+	fast := maFrom(inReal, lookbackLargest, optInFastPeriod, optInFastMAType)
+	slow := maFrom(inReal, lookbackLargest, optInSlowPeriod, optInSlowMAType)
+	return macd(inReal, fast, slow, lookbackLargest, optInSignalPeriod, optInSignalMAType)
+}
+
+/*
+ * TA_MFI - Money Flow Index
+ *
+ * Input  = High, Low, Close, Volume
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Mfi(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if optInTimePeriod < 1 || n <= optInTimePeriod {
+		return outReal
+	}
+	posFlow := make([]float64, n)
+	negFlow := make([]float64, n)
+	prevValue := (inHigh[0] + inLow[0] + inClose[0]) / 3.0
+	var posSum, negSum float64
+	for today := 1; today < n; today++ {
+		typical := (inHigh[today] + inLow[today] + inClose[today]) / 3.0
+		diff := typical - prevValue
+		prevValue = typical
+		flow := typical * inVolume[today]
+		if diff < 0 {
+			negFlow[today] = flow
+		} else if diff > 0 {
+			posFlow[today] = flow
+		}
+		posSum += posFlow[today]
+		negSum += negFlow[today]
+		if today < optInTimePeriod {
+			continue
+		}
+		if today > optInTimePeriod {
+			posSum -= posFlow[today-optInTimePeriod]
+			negSum -= negFlow[today-optInTimePeriod]
+		}
+		if total := posSum + negSum; total >= 1.0 {
+			outReal[today] = 100.0 * (posSum / total)
+		}
+	}
+	return outReal
+}
+
+// change compares every value with the one optInTimePeriod bars earlier.
+func change(inReal []float64, period int, fn func(value, prev float64) float64) []float64 {
+	out := make([]float64, len(inReal))
+	if period < 0 {
+		return out
+	}
+	for today := period; today < len(inReal); today++ {
+		out[today] = fn(inReal[today], inReal[today-period])
+	}
+	return out
+}
+
+/*
+ * TA_MOM - Momentum
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Mom(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return change(inReal, optInTimePeriod, func(value, prev float64) float64 {
+		return value - prev
+	})
+}
+
+/*
+ * TA_ROC - Rate of change : ((price/prevPrice)-1)*100
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Roc(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return change(inReal, optInTimePeriod, func(value, prev float64) float64 {
+		if prev == 0 {
+			return 0
+		}
+		return ((value / prev) - 1.0) * 100.0
+	})
+}
+
+/*
+ * TA_ROCP - Rate of change Percentage: (price-prevPrice)/prevPrice
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Rocp(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return change(inReal, optInTimePeriod, func(value, prev float64) float64 {
+		if prev == 0 {
+			return 0
+		}
+		return (value - prev) / prev
+	})
+}
+
+/*
+ * TA_ROCR - Rate of change ratio: (price/prevPrice)
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Rocr(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return change(inReal, optInTimePeriod, func(value, prev float64) float64 {
+		if prev == 0 {
+			return 0
+		}
+		return value / prev
+	})
+}
+
+/*
+ * TA_ROCR100 - Rate of change ratio 100 scale: (price/prevPrice)*100
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Rocr100(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return change(inReal, optInTimePeriod, func(value, prev float64) float64 {
+		if prev == 0 {
+			return 0
+		}
+		return (value / prev) * 100.0
+	})
+}
+
+// fastK is the raw stochastic, the position of the close inside the range of
+// the last period bars, returned from index period-1 on.
+func fastK(inHigh, inLow, inClose []float64, period int) []float64 {
+	lookback := period - 1
+	if period < 1 || len(inClose) <= lookback {
+		return nil
+	}
+	highest := windowExtreme(inHigh, period, true)
+	lowest := windowExtreme(inLow, period, false)
+	out := make([]float64, len(inClose)-lookback)
+	for today := lookback; today < len(inClose); today++ {
+		h, l := inHigh[highest[today]], inLow[lowest[today]]
+		if diff := (h - l) / 100.0; diff != 0 {
+			out[today-lookback] = (inClose[today] - l) / diff
+		}
+	}
+	return out
+}
+
+/*
+ * TA_STOCH - Stochastic
+ *
+ * Input  = High, Low, Close
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastK_Period:(From 1 to 100000)
+ *    Time period for building the Fast-K line
+ *
+ * optInSlowK_Period:(From 1 to 100000)
+ *    Smoothing for making the Slow-K line. Usually set to 3
+ *
+ * optInSlowK_MAType:
+ *    Type of Moving Average for Slow-K
+ *
+ * optInSlowD_Period:(From 1 to 100000)
+ *    Smoothing for making the Slow-D line
+ *
+ * optInSlowD_MAType:
+ *    Type of Moving Average for Slow-D
+ *
+ *
+ */
+func Stoch(inHigh []float64, inLow []float64, inClose []float64, optInFastK_Period int, optInSlowK_Period int, optInSlowK_MAType int, optInSlowD_Period int, optInSlowD_MAType int) (outSlowK []float64, outSlowD []float64) {
+	n := len(inHigh)
+	outSlowK = make([]float64, n)
+	outSlowD = make([]float64, n)
+	lookbackK := optInFastK_Period - 1
+	k := fastK(inHigh, inLow, inClose, optInFastK_Period)
+	begK, slowK := ma(k, optInSlowK_Period, optInSlowK_MAType)
+	begD, slowD := ma(slowK, optInSlowD_Period, optInSlowD_MAType)
+	for i, d := range slowD {
+		idx := lookbackK + begK + begD + i
+		outSlowK[idx] = slowK[begD+i]
+		outSlowD[idx] = d
+	}
+	return
+}
+
+/*
+ * TA_STOCHF - Stochastic Fast
+ *
+ * Input  = High, Low, Close
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastK_Period:(From 1 to 100000)
+ *    Time period for building the Fast-K line
+ *
+ * optInFastD_Period:(From 1 to 100000)
+ *    Smoothing for making the Fast-D line. Usually set to 3
+ *
+ * optInFastD_MAType:
+ *    Type of Moving Average for Fast-D
+ *
+ *
+ */
+func Stochf(inHigh []float64, inLow []float64, inClose []float64, optInFastK_Period int, optInFastD_Period int, optInFastD_MAType int) (outFastK []float64, outFastD []float64) {
+	n := len(inHigh)
+	outFastK = make([]float64, n)
+	outFastD = make([]float64, n)
+	lookbackK := optInFastK_Period - 1
+	k := fastK(inHigh, inLow, inClose, optInFastK_Period)
+	begD, d := ma(k, optInFastD_Period, optInFastD_MAType)
+	for i := range d {
+		idx := lookbackK + begD + i
+		outFastK[idx] = k[begD+i]
+		outFastD[idx] = d[i]
+	}
+	return
+}
+
+/*
+ * TA_STOCHRSI - Stochastic Relative Strength Index
+ *
+ * Input  = double
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ * optInFastK_Period:(From 1 to 100000)
+ *    Time period for building the Fast-K line
+ *
+ * optInFastD_Period:(From 1 to 100000)
+ *    Smoothing for making the Fast-D line. Usually set to 3
+ *
+ * optInFastD_MAType:
+ *    Type of Moving Average for Fast-D
+ *
+ *
+ */
+func StochRsi(inReal []float64, optInTimePeriod int, optInFastK_Period int, optInFastD_Period int, optInFastD_MAType int) (outFastK []float64, outFastD []float64) {
+	n := len(inReal)
+	outFastK = make([]float64, n)
+	outFastD = make([]float64, n)
+	if n <= optInTimePeriod {
+		return
+	}
+	rsi := Rsi(inReal, optInTimePeriod)[optInTimePeriod:]
+	k, d := Stochf(rsi, rsi, rsi, optInFastK_Period, optInFastD_Period, optInFastD_MAType)
+	lookback := optInFastK_Period - 1 + MaLookback(optInFastD_Period, optInFastD_MAType)
+	for i := lookback; i < len(rsi); i++ {
+		outFastK[optInTimePeriod+i] = k[i]
+		outFastD[optInTimePeriod+i] = d[i]
+	}
+	return
+}
+
+/*
+ * TA_TRIX - 1-day Rate-Of-Change (ROC) of a Triple Smooth EMA
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Trix(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	k := perToK(optInTimePeriod)
+	beg1, ema1 := ema(inReal, optInTimePeriod, k)
+	beg2, ema2 := ema(ema1, optInTimePeriod, k)
+	beg3, ema3 := ema(ema2, optInTimePeriod, k)
+	if len(ema3) < 2 {
+		return make([]float64, len(inReal))
+	}
+	roc := Roc(ema3, 1)[1:]
+	return pad(len(inReal), beg1+beg2+beg3+1, roc)
+}
+
+/*
+ * TA_ULTOSC - Ultimate Oscillator
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod1:(From 1 to 100000)
+ *    Number of bars for 1st period.
+ *
+ * optInTimePeriod2:(From 1 to 100000)
+ *    Number of bars fro 2nd period
+ *
+ * optInTimePeriod3:(From 1 to 100000)
+ *    Number of bars for 3rd period
+ *
+ *
+ */
+func UltOsc(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod1 int, optInTimePeriod2 int, optInTimePeriod3 int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	periods := []int{optInTimePeriod1, optInTimePeriod2, optInTimePeriod3}
+	for i := 0; i < len(periods); i++ {
+		for j := i + 1; j < len(periods); j++ {
+			if periods[j] < periods[i] {
+				periods[i], periods[j] = periods[j], periods[i]
+			}
+		}
+	}
+	lookback := periods[2]
+	if n <= lookback {
+		return outReal
+	}
+	terms := func(day int) (closeMinusTrueLow, tr float64) {
+		trueLow := math.Min(inLow[day], inClose[day-1])
+		return inClose[day] - trueLow, trueRange(inHigh[day], inLow[day], inClose[day-1])
+	}
+	var a, b [3]float64
+	for k, period := range periods {
+		for i := lookback - period + 1; i < lookback; i++ {
+			ai, bi := terms(i)
+			a[k] += ai
+			b[k] += bi
+		}
+	}
+	weights := [3]float64{4.0, 2.0, 1.0}
+	for today := lookback; today < n; today++ {
+		at, bt := terms(today)
+		output := 0.0
+		for k, period := range periods {
+			a[k] += at
+			b[k] += bt
+			if !isZero(b[k]) {
+				output += weights[k] * (a[k] / b[k])
+			}
+			ai, bi := terms(today - period + 1)
+			a[k] -= ai
+			b[k] -= bi
+		}
+		outReal[today] = 100.0 * (output / 7.0)
+	}
+	return outReal
+}
+
+/*
+ * TA_WILLR - Williams' %R
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Willr(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	lookback := optInTimePeriod - 1
+	if optInTimePeriod < 1 || n <= lookback {
+		return outReal
+	}
+	highest := windowExtreme(inHigh, optInTimePeriod, true)
+	lowest := windowExtreme(inLow, optInTimePeriod, false)
+	for today := lookback; today < n; today++ {
+		h, l := inHigh[highest[today]], inLow[lowest[today]]
+		if diff := (h - l) / -100.0; diff != 0 {
+			outReal[today] = (h - inClose[today]) / diff
+		}
+	}
+	return outReal
+}
@@ -0,0 +1,866 @@
+package talib
+
+import (
+	"math"
+)
+
+/*
+ * TA_MA_Lookback - number of leading values a moving average of the given
+ * period and type consumes before its first output.
+ */
+func MaLookback(period, maType int) int {
+	if period <= 1 {
+		return 0
+	}
+	switch maType {
+	case DEMA:
+		return 2 * (period - 1)
+	case TEMA:
+		return 3 * (period - 1)
+	case KAMA:
+		return period
+	case MAMA:
+		return 32
+	case T3MA:
+		return 6 * (period - 1)
+	default:
+		return period - 1
+	}
+}
+
+// ma dispatches to the moving average selected by maType and returns the
+// index of the first valid value together with the values from there on.
+func ma(in []float64, period, maType int) (int, []float64) {
+	if period == 1 {
+		out := make([]float64, len(in))
+		copy(out, in)
+		return 0, out
+	}
+	switch maType {
+	case EMA:
+		return ema(in, period, perToK(period))
+	case WMA:
+		return wma(in, period)
+	case DEMA:
+		return dema(in, period)
+	case TEMA:
+		return tema(in, period)
+	case TRIMA:
+		return trima(in, period)
+	case KAMA:
+		return kama(in, period)
+	case MAMA:
+		beg, mama, _ := mesa(in, 0.5, 0.05)
+		return beg, mama
+	case T3MA:
+		return t3(in, period, 0.7)
+	default:
+		return sma(in, period)
+	}
+}
+
+// maFrom computes the moving average as if the calculation had been requested
+// from startIdx, which matters for the recursive averages whose seed depends
+// on where the computation begins.
+func maFrom(in []float64, startIdx, period, maType int) []float64 {
+	lookback := MaLookback(period, maType)
+	if startIdx < lookback || startIdx >= len(in) {
+		return nil
+	}
+	_, out := ma(in[startIdx-lookback:], period, maType)
+	return out
+}
+
+func sma(in []float64, period int) (int, []float64) {
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	out := make([]float64, len(in)-lookback)
+	sum := 0.0
+	for i := 0; i < lookback; i++ {
+		sum += in[i]
+	}
+	for i := lookback; i < len(in); i++ {
+		sum += in[i]
+		out[i-lookback] = sum / float64(period)
+		sum -= in[i-lookback]
+	}
+	return lookback, out
+}
+
+// ema is seeded with the simple average of the first period values.
+func ema(in []float64, period int, k float64) (int, []float64) {
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	out := make([]float64, len(in)-lookback)
+	sum := 0.0
+	for i := 0; i < period; i++ {
+		sum += in[i]
+	}
+	prev := sum / float64(period)
+	out[0] = prev
+	for i := period; i < len(in); i++ {
+		prev = (in[i]-prev)*k + prev
+		out[i-lookback] = prev
+	}
+	return lookback, out
+}
+
+func wma(in []float64, period int) (int, []float64) {
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	out := make([]float64, len(in)-lookback)
+	divider := float64(period*(period+1)) / 2.0
+	periodSum, periodSub := 0.0, 0.0
+	for i := 0; i < lookback; i++ {
+		periodSub += in[i]
+		periodSum += in[i] * float64(i+1)
+	}
+	trailing := 0.0
+	for i := lookback; i < len(in); i++ {
+		periodSub += in[i]
+		periodSub -= trailing
+		periodSum += in[i] * float64(period)
+		trailing = in[i-lookback]
+		out[i-lookback] = periodSum / divider
+		periodSum -= periodSub
+	}
+	return lookback, out
+}
+
+func dema(in []float64, period int) (int, []float64) {
+	k := perToK(period)
+	beg1, ema1 := ema(in, period, k)
+	beg2, ema2 := ema(ema1, period, k)
+	if len(ema2) == 0 {
+		return 0, nil
+	}
+	out := make([]float64, len(ema2))
+	for i := range out {
+		out[i] = 2.0*ema1[i+beg2] - ema2[i]
+	}
+	return beg1 + beg2, out
+}
+
+func tema(in []float64, period int) (int, []float64) {
+	k := perToK(period)
+	beg1, ema1 := ema(in, period, k)
+	beg2, ema2 := ema(ema1, period, k)
+	beg3, ema3 := ema(ema2, period, k)
+	if len(ema3) == 0 {
+		return 0, nil
+	}
+	out := make([]float64, len(ema3))
+	for i := range out {
+		out[i] = 3.0*ema1[i+beg2+beg3] - 3.0*ema2[i+beg3] + ema3[i]
+	}
+	return beg1 + beg2 + beg3, out
+}
+
+// trima weights the window triangularly, 1, 2, ..., n, ..., 2, 1.
+func trima(in []float64, period int) (int, []float64) {
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	weights := make([]float64, period)
+	total := 0.0
+	for j := range weights {
+		w := j + 1
+		if period-j < w {
+			w = period - j
+		}
+		weights[j] = float64(w)
+		total += weights[j]
+	}
+	out := make([]float64, len(in)-lookback)
+	for i := lookback; i < len(in); i++ {
+		sum := 0.0
+		for j, w := range weights {
+			sum += in[i-lookback+j] * w
+		}
+		out[i-lookback] = sum / total
+	}
+	return lookback, out
+}
+
+func kama(in []float64, period int) (int, []float64) {
+	const constMax = 2.0 / (30.0 + 1.0)
+	const constDiff = 2.0/(2.0+1.0) - constMax
+	lookback := period
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	out := make([]float64, len(in)-lookback)
+	sumROC1 := 0.0
+	for i := 0; i < period; i++ {
+		sumROC1 += math.Abs(in[i] - in[i+1])
+	}
+	prevKAMA := in[period-1]
+	trailingIdx := 0
+	trailingValue := 0.0
+	for today := period; today < len(in); today++ {
+		periodROC := in[today] - in[trailingIdx]
+		if today > period {
+			sumROC1 -= math.Abs(trailingValue - in[trailingIdx])
+			sumROC1 += math.Abs(in[today] - in[today-1])
+		}
+		trailingValue = in[trailingIdx]
+		trailingIdx++
+		var er float64
+		if sumROC1 <= periodROC || isZero(sumROC1) {
+			er = 1.0
+		} else {
+			er = math.Abs(periodROC / sumROC1)
+		}
+		sc := er*constDiff + constMax
+		sc *= sc
+		prevKAMA = (in[today]-prevKAMA)*sc + prevKAMA
+		out[today-lookback] = prevKAMA
+	}
+	return lookback, out
+}
+
+func t3(in []float64, period int, vFactor float64) (int, []float64) {
+	lookback := 6 * (period - 1)
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	k := perToK(period)
+	oneMinusK := 1.0 - k
+	p := float64(period)
+	today := 0
+
+	sum := 0.0
+	for i := 0; i < period; i++ {
+		sum += in[today]
+		today++
+	}
+	e1 := sum / p
+
+	sum = e1
+	for i := 1; i < period; i++ {
+		e1 = k*in[today] + oneMinusK*e1
+		today++
+		sum += e1
+	}
+	e2 := sum / p
+
+	sum = e2
+	for i := 1; i < period; i++ {
+		e1 = k*in[today] + oneMinusK*e1
+		today++
+		e2 = k*e1 + oneMinusK*e2
+		sum += e2
+	}
+	e3 := sum / p
+
+	sum = e3
+	for i := 1; i < period; i++ {
+		e1 = k*in[today] + oneMinusK*e1
+		today++
+		e2 = k*e1 + oneMinusK*e2
+		e3 = k*e2 + oneMinusK*e3
+		sum += e3
+	}
+	e4 := sum / p
+
+	sum = e4
+	for i := 1; i < period; i++ {
+		e1 = k*in[today] + oneMinusK*e1
+		today++
+		e2 = k*e1 + oneMinusK*e2
+		e3 = k*e2 + oneMinusK*e3
+		e4 = k*e3 + oneMinusK*e4
+		sum += e4
+	}
+	e5 := sum / p
+
+	sum = e5
+	for i := 1; i < period; i++ {
+		e1 = k*in[today] + oneMinusK*e1
+		today++
+		e2 = k*e1 + oneMinusK*e2
+		e3 = k*e2 + oneMinusK*e3
+		e4 = k*e3 + oneMinusK*e4
+		e5 = k*e4 + oneMinusK*e5
+		sum += e5
+	}
+	e6 := sum / p
+
+	v2 := vFactor * vFactor
+	c1 := -v2 * vFactor
+	c2 := 3.0 * (v2 - c1)
+	c3 := -6.0*v2 - 3.0*(vFactor-c1)
+	c4 := 1.0 + 3.0*vFactor - c1 + 3.0*v2
+
+	out := make([]float64, len(in)-lookback)
+	out[0] = c1*e6 + c2*e5 + c3*e4 + c4*e3
+	for ; today < len(in); today++ {
+		e1 = k*in[today] + oneMinusK*e1
+		e2 = k*e1 + oneMinusK*e2
+		e3 = k*e2 + oneMinusK*e3
+		e4 = k*e3 + oneMinusK*e4
+		e5 = k*e4 + oneMinusK*e5
+		e6 = k*e5 + oneMinusK*e6
+		out[today-lookback] = c1*e6 + c2*e5 + c3*e4 + c4*e3
+	}
+	return lookback, out
+}
+
+/*
+ * TA_SMA - Simple Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Sma(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := sma(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_EMA - Exponential Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Ema(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := ema(inReal, optInTimePeriod, perToK(optInTimePeriod))
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_WMA - Weighted Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Wma(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := wma(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_DEMA - Double Exponential Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Dema(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := dema(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_TEMA - Triple Exponential Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Tema(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := tema(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_TRIMA - Triangular Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Trima(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := trima(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_KAMA - Kaufman Adaptive Moving Average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Kama(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	beg, out := kama(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_T3 - Triple Exponential Moving Average (T3)
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ * optInVFactor:(From 0 to 1)
+ *    Volume Factor
+ *
+ *
+ */
+func T3(inReal []float64, optInTimePeriod int, optInVFactor float64) (outReal []float64) {
+	beg, out := t3(inReal, optInTimePeriod, optInVFactor)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_MA - Moving average
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ * optInMAType:
+ *    Type of Moving Average
+ *
+ *
+ */
+func Ma(inReal []float64, optInTimePeriod int, optInMAType int) (outReal []float64) {
+	beg, out := ma(inReal, optInTimePeriod, optInMAType)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_MAMA - MESA Adaptive Moving Average
+ *
+ * Input  = double
+ * Output = double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastLimit:(From 0.01 to 0.99)
+ *    Upper limit use in the adaptive algorithm
+ *
+ * optInSlowLimit:(From 0.01 to 0.99)
+ *    Lower limit use in the adaptive algorithm
+ *
+ *
+ */
+func Mama(inReal []float64, optInFastLimit float64, optInSlowLimit float64) (outMAMA []float64, outFAMA []float64) {
+	beg, mama, fama := mesa(inReal, optInFastLimit, optInSlowLimit)
+	return pad(len(inReal), beg, mama), pad(len(inReal), beg, fama)
+}
+
+/*
+ * TA_MAVP - Moving average with variable period
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInMinPeriod:(From 2 to 100000)
+ *    Value less than minimum will be changed to Minimum period
+ *
+ * optInMaxPeriod:(From 2 to 100000)
+ *    Value higher than maximum will be changed to Maximum period
+ *
+ * optInMAType:
+ *    Type of Moving Average
+ *
+ *
+ */
+func Mavp(inReal []float64, inPeriods []float64, optInMinPeriod int, optInMaxPeriod int, optInMAType int) (outReal []float64) {
+	n := len(inReal)
+	lookback := MaLookback(optInMaxPeriod, optInMAType)
+	if n <= lookback {
+		return make([]float64, n)
+	}
+	outReal = make([]float64, n)
+	cache := make(map[int][]float64)
+	for i := lookback; i < n; i++ {
+		period := int(inPeriods[i])
+		if period < optInMinPeriod {
+			period = optInMinPeriod
+		} else if period > optInMaxPeriod {
+			period = optInMaxPeriod
+		}
+		values, ok := cache[period]
+		if !ok {
+			values = maFrom(inReal, lookback, period, optInMAType)
+			cache[period] = values
+		}
+		outReal[i] = values[i-lookback]
+	}
+	return outReal
+}
+
+/*
+ * TA_BBANDS - Bollinger Bands
+ *
+ * Input  = double
+ * Output = double, double, double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ * optInNbDevUp:(From TA_REAL_MIN to TA_REAL_MAX)
+ *    Deviation multiplier for upper band
+ *
+ * optInNbDevDn:(From TA_REAL_MIN to TA_REAL_MAX)
+ *    Deviation multiplier for lower band
+ *
+ * optInMAType:
+ *    Type of Moving Average
+ *
+ *
+ */
+func BBands(inReal []float64, optInTimePeriod int, optInNbDevUp float64, optInNbDevDn float64, optInMAType int) (outRealUpperBand []float64, outRealMiddleBand []float64, outRealLowerBand []float64) {
+	n := len(inReal)
+	beg, middle := ma(inReal, optInTimePeriod, optInMAType)
+	outRealUpperBand = make([]float64, n)
+	outRealMiddleBand = make([]float64, n)
+	outRealLowerBand = make([]float64, n)
+	if len(middle) == 0 {
+		return
+	}
+	_, deviation := stdDev(inReal, optInTimePeriod)
+	devBeg := optInTimePeriod - 1
+	for i, m := range middle {
+		idx := beg + i
+		var dev float64
+		if optInTimePeriod > 1 && idx >= devBeg {
+			dev = deviation[idx-devBeg]
+		}
+		outRealMiddleBand[idx] = m
+		outRealUpperBand[idx] = m + dev*optInNbDevUp
+		outRealLowerBand[idx] = m - dev*optInNbDevDn
+	}
+	return
+}
+
+/*
+ * TA_MIDPOINT - MidPoint over period
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MidPoint(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inReal)
+	lookback := optInTimePeriod - 1
+	outReal = make([]float64, n)
+	for i := lookback; i < n; i++ {
+		lowest, highest := inReal[i-lookback], inReal[i-lookback]
+		for j := i - lookback + 1; j <= i; j++ {
+			if inReal[j] < lowest {
+				lowest = inReal[j]
+			}
+			if inReal[j] > highest {
+				highest = inReal[j]
+			}
+		}
+		outReal[i] = (highest + lowest) / 2.0
+	}
+	return outReal
+}
+
+/*
+ * TA_MIDPRICE - Midpoint Price over period
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func MidPrice(inHigh []float64, inLow []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inHigh)
+	lookback := optInTimePeriod - 1
+	outReal = make([]float64, n)
+	for i := lookback; i < n; i++ {
+		lowest, highest := inLow[i-lookback], inHigh[i-lookback]
+		for j := i - lookback + 1; j <= i; j++ {
+			if inLow[j] < lowest {
+				lowest = inLow[j]
+			}
+			if inHigh[j] > highest {
+				highest = inHigh[j]
+			}
+		}
+		outReal[i] = (highest + lowest) / 2.0
+	}
+	return outReal
+}
+
+// sarIsLong picks the initial SAR direction from the first bar's directional
+// movement: a positive -DM starts the series short.
+func sarIsLong(inHigh, inLow []float64) bool {
+	diffP := inHigh[1] - inHigh[0]
+	diffM := inLow[0] - inLow[1]
+	return !(diffM > 0 && diffP < diffM)
+}
+
+/*
+ * TA_SAR - Parabolic SAR
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInAcceleration:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor used up to the Maximum value
+ *
+ * optInMaximum:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor Maximum value
+ *
+ *
+ */
+func Sar(inHigh []float64, inLow []float64, optInAcceleration float64, optInMaximum float64) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if n < 2 {
+		return outReal
+	}
+	af := optInAcceleration
+	if af > optInMaximum {
+		af, optInAcceleration = optInMaximum, optInMaximum
+	}
+	isLong := sarIsLong(inHigh, inLow)
+
+	var ep, sar float64
+	if isLong {
+		ep, sar = inHigh[1], inLow[0]
+	} else {
+		ep, sar = inLow[1], inHigh[0]
+	}
+	newLow, newHigh := inLow[1], inHigh[1]
+	for today := 1; today < n; today++ {
+		prevLow, prevHigh := newLow, newHigh
+		newLow, newHigh = inLow[today], inHigh[today]
+		if isLong {
+			if newLow <= sar {
+				isLong = false
+				sar = math.Max(ep, math.Max(prevHigh, newHigh))
+				outReal[today] = sar
+				af = optInAcceleration
+				ep = newLow
+				sar = sar + af*(ep-sar)
+				sar = math.Max(sar, math.Max(prevHigh, newHigh))
+			} else {
+				outReal[today] = sar
+				if newHigh > ep {
+					ep = newHigh
+					af = math.Min(af+optInAcceleration, optInMaximum)
+				}
+				sar = sar + af*(ep-sar)
+				sar = math.Min(sar, math.Min(prevLow, newLow))
+			}
+		} else {
+			if newHigh >= sar {
+				isLong = true
+				sar = math.Min(ep, math.Min(prevLow, newLow))
+				outReal[today] = sar
+				af = optInAcceleration
+				ep = newHigh
+				sar = sar + af*(ep-sar)
+				sar = math.Min(sar, math.Min(prevLow, newLow))
+			} else {
+				outReal[today] = sar
+				if newLow < ep {
+					ep = newLow
+					af = math.Min(af+optInAcceleration, optInMaximum)
+				}
+				sar = sar + af*(ep-sar)
+				sar = math.Max(sar, math.Max(prevHigh, newHigh))
+			}
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_SAREXT - Parabolic SAR - Extended
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInStartValue:(From TA_REAL_MIN to TA_REAL_MAX)
+ *    Start value and direction. 0 for Auto, >0 for Long, <0 for Short
+ *
+ * optInOffsetOnReverse:(From 0 to TA_REAL_MAX)
+ *    Percent offset added/removed to initial stop on short/long reversal
+ *
+ * optInAccelerationInitLong:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor initial value for the Long direction
+ *
+ * optInAccelerationLong:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor for the Long direction
+ *
+ * optInAccelerationMaxLong:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor maximum value for the Long direction
+ *
+ * optInAccelerationInitShort:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor initial value for the Short direction
+ *
+ * optInAccelerationShort:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor for the Short direction
+ *
+ * optInAccelerationMaxShort:(From 0 to TA_REAL_MAX)
+ *    Acceleration Factor maximum value for the Short direction
+ *
+ *
+ */
+func SarExt(inHigh []float64, inLow []float64, optInStartValue float64, optInOffsetOnReverse float64, optInAccelerationInitLong float64, optInAccelerationLong float64, optInAccelerationMaxLong float64, optInAccelerationInitShort float64, optInAccelerationShort float64, optInAccelerationMaxShort float64) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	if n < 2 {
+		return outReal
+	}
+	if optInAccelerationInitLong > optInAccelerationMaxLong {
+		optInAccelerationInitLong = optInAccelerationMaxLong
+	}
+	if optInAccelerationLong > optInAccelerationMaxLong {
+		optInAccelerationLong = optInAccelerationMaxLong
+	}
+	if optInAccelerationInitShort > optInAccelerationMaxShort {
+		optInAccelerationInitShort = optInAccelerationMaxShort
+	}
+	if optInAccelerationShort > optInAccelerationMaxShort {
+		optInAccelerationShort = optInAccelerationMaxShort
+	}
+	afLong := optInAccelerationInitLong
+	afShort := optInAccelerationInitShort
+
+	var isLong bool
+	var ep, sar float64
+	switch {
+	case optInStartValue == 0:
+		isLong = sarIsLong(inHigh, inLow)
+		if isLong {
+			ep, sar = inHigh[1], inLow[0]
+		} else {
+			ep, sar = inLow[1], inHigh[0]
+		}
+	case optInStartValue > 0:
+		isLong = true
+		ep, sar = inHigh[1], optInStartValue
+	default:
+		ep, sar = inLow[1], math.Abs(optInStartValue)
+	}
+	newLow, newHigh := inLow[1], inHigh[1]
+	for today := 1; today < n; today++ {
+		prevLow, prevHigh := newLow, newHigh
+		newLow, newHigh = inLow[today], inHigh[today]
+		if isLong {
+			if newLow <= sar {
+				isLong = false
+				sar = math.Max(ep, math.Max(prevHigh, newHigh))
+				if optInOffsetOnReverse != 0 {
+					sar += sar * optInOffsetOnReverse
+				}
+				outReal[today] = -sar
+				afShort = optInAccelerationInitShort
+				ep = newLow
+				sar = sar + afShort*(ep-sar)
+				sar = math.Max(sar, math.Max(prevHigh, newHigh))
+			} else {
+				outReal[today] = sar
+				if newHigh > ep {
+					ep = newHigh
+					afLong = math.Min(afLong+optInAccelerationLong, optInAccelerationMaxLong)
+				}
+				sar = sar + afLong*(ep-sar)
+				sar = math.Min(sar, math.Min(prevLow, newLow))
+			}
+		} else {
+			if newHigh >= sar {
+				isLong = true
+				sar = math.Min(ep, math.Min(prevLow, newLow))
+				if optInOffsetOnReverse != 0 {
+					sar -= sar * optInOffsetOnReverse
+				}
+				outReal[today] = sar
+				afLong = optInAccelerationInitLong
+				ep = newHigh
+				sar = sar + afLong*(ep-sar)
+				sar = math.Min(sar, math.Min(prevLow, newLow))
+			} else {
+				outReal[today] = -sar
+				if newLow < ep {
+					ep = newLow
+					afShort = math.Min(afShort+optInAccelerationShort, optInAccelerationMaxShort)
+				}
+				sar = sar + afShort*(ep-sar)
+				sar = math.Max(sar, math.Max(prevHigh, newHigh))
+			}
+		}
+	}
+	return outReal
+}
@@ -0,0 +1,1420 @@
+package talib
+
+import (
+	"math"
+)
+
+/*
+ * TA_CDL2CROWS - Two Crows
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl2Crows(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyLong), func(i int) int {
+		if c.color(i-2) == 1 && c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.color(i-1) == -1 && c.realBodyGapUp(i-1, i-2) &&
+			c.color(i) == -1 &&
+			inOpen[i] < inOpen[i-1] && inOpen[i] > inClose[i-1] &&
+			inClose[i] > inOpen[i-2] && inClose[i] < inClose[i-2] {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3BLACKCROWS - Three Black Crows
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3BlackCrows(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(3, shadowVeryShort), func(i int) int {
+		if c.color(i-3) == 1 &&
+			c.color(i-2) == -1 && c.lowerShadow(i-2) < c.average(shadowVeryShort, i-2) &&
+			c.color(i-1) == -1 && c.lowerShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+			c.color(i) == -1 && c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			inOpen[i-1] < inOpen[i-2] && inOpen[i-1] > inClose[i-2] &&
+			inOpen[i] < inOpen[i-1] && inOpen[i] > inClose[i-1] &&
+			inHigh[i-3] > inClose[i-2] &&
+			inClose[i-2] > inClose[i-1] && inClose[i-1] > inClose[i] {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3INSIDE - Three Inside Up/Down
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3InSide(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyShort, bodyLong), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.realBody(i-1) <= c.average(bodyShort, i-1) &&
+			c.bodyTop(i-1) < c.bodyTop(i-2) && c.bodyBottom(i-1) > c.bodyBottom(i-2) &&
+			((c.color(i-2) == 1 && c.color(i) == -1 && inClose[i] < inOpen[i-2]) ||
+				(c.color(i-2) == -1 && c.color(i) == 1 && inClose[i] > inOpen[i-2])) {
+			return -c.color(i-2) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3LINESTRIKE - Three-Line Strike
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3LineStrike(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(3, near), func(i int) int {
+		if c.color(i-3) == c.color(i-2) && c.color(i-2) == c.color(i-1) &&
+			c.color(i) == -c.color(i-1) &&
+			inOpen[i-2] >= c.bodyBottom(i-3)-c.average(near, i-3) &&
+			inOpen[i-2] <= c.bodyTop(i-3)+c.average(near, i-3) &&
+			inOpen[i-1] >= c.bodyBottom(i-2)-c.average(near, i-2) &&
+			inOpen[i-1] <= c.bodyTop(i-2)+c.average(near, i-2) &&
+			((c.color(i-1) == 1 &&
+				inClose[i-1] > inClose[i-2] && inClose[i-2] > inClose[i-3] &&
+				inOpen[i] > inClose[i-1] && inClose[i] < inOpen[i-3]) ||
+				(c.color(i-1) == -1 &&
+					inClose[i-1] < inClose[i-2] && inClose[i-2] < inClose[i-3] &&
+					inOpen[i] < inClose[i-1] && inClose[i] > inOpen[i-3])) {
+			return c.color(i-1) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3OUTSIDE - Three Outside Up/Down
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3OutSide(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(3, func(i int) int {
+		if (c.color(i-1) == 1 && c.color(i-2) == -1 &&
+			inClose[i-1] > inOpen[i-2] && inOpen[i-1] < inClose[i-2] &&
+			inClose[i] > inClose[i-1]) ||
+			(c.color(i-1) == -1 && c.color(i-2) == 1 &&
+				inOpen[i-1] > inClose[i-2] && inClose[i-1] < inOpen[i-2] &&
+				inClose[i] < inClose[i-1]) {
+			return c.color(i-1) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3STARSINSOUTH - Three Stars In The South
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3StarsInSouth(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, shadowVeryShort, shadowLong, bodyLong, bodyShort), func(i int) int {
+		if c.color(i-2) == -1 && c.color(i-1) == -1 && c.color(i) == -1 &&
+			c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.lowerShadow(i-2) > c.average(shadowLong, i-2) &&
+			c.realBody(i-1) < c.realBody(i-2) &&
+			inOpen[i-1] > inClose[i-2] && inOpen[i-1] <= inHigh[i-2] &&
+			inLow[i-1] < inClose[i-2] && inLow[i-1] >= inLow[i-2] &&
+			c.lowerShadow(i-1) > c.average(shadowVeryShort, i-1) &&
+			c.realBody(i) < c.average(bodyShort, i) &&
+			c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			inLow[i] > inLow[i-1] && inHigh[i] < inHigh[i-1] {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDL3WHITESOLDIERS - Three Advancing White Soldiers
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func Cdl3WhiteSoldiers(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, shadowVeryShort, bodyShort, far, near), func(i int) int {
+		if c.color(i-2) == 1 && c.upperShadow(i-2) < c.average(shadowVeryShort, i-2) &&
+			c.color(i-1) == 1 && c.upperShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+			c.color(i) == 1 && c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			inClose[i] > inClose[i-1] && inClose[i-1] > inClose[i-2] &&
+			inOpen[i-1] > inOpen[i-2] && inOpen[i-1] <= inClose[i-2]+c.average(near, i-2) &&
+			inOpen[i] > inOpen[i-1] && inOpen[i] <= inClose[i-1]+c.average(near, i-1) &&
+			c.realBody(i-1) > c.realBody(i-2)-c.average(far, i-2) &&
+			c.realBody(i) > c.realBody(i-1)-c.average(far, i-1) &&
+			c.realBody(i) > c.average(bodyShort, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLABANDONEDBABY - Abandoned Baby
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlAbandOnedBaBy(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyDoji, bodyLong, bodyShort), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.realBody(i-1) <= c.average(bodyDoji, i-1) &&
+			c.realBody(i) > c.average(bodyShort, i) &&
+			((c.color(i-2) == 1 && c.color(i) == -1 &&
+				inClose[i] < inClose[i-2]-c.realBody(i-2)*optInPenetration &&
+				c.candleGapUp(i-1, i-2) && c.candleGapDown(i, i-1)) ||
+				(c.color(i-2) == -1 && c.color(i) == 1 &&
+					inClose[i] > inClose[i-2]+c.realBody(i-2)*optInPenetration &&
+					c.candleGapDown(i-1, i-2) && c.candleGapUp(i, i-1))) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLADVANCEBLOCK - Advance Block
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlAdvanceBlock(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, shadowLong, shadowShort, far, near, bodyLong), func(i int) int {
+		if c.color(i-2) != 1 || c.color(i-1) != 1 || c.color(i) != 1 ||
+			inClose[i] <= inClose[i-1] || inClose[i-1] <= inClose[i-2] ||
+			inOpen[i-1] <= inOpen[i-2] || inOpen[i-1] > inClose[i-2]+c.average(near, i-2) ||
+			inOpen[i] <= inOpen[i-1] || inOpen[i] > inClose[i-1]+c.average(near, i-1) ||
+			c.realBody(i-2) <= c.average(bodyLong, i-2) ||
+			c.upperShadow(i-2) >= c.average(shadowShort, i-2) {
+			return 0
+		}
+		switch {
+		// the 2nd is far smaller and the 3rd does not carry on the advance
+		case c.realBody(i-1) < c.realBody(i-2)-c.average(far, i-2) &&
+			c.realBody(i) < c.realBody(i-1)+c.average(near, i-1):
+		// the 3rd is far smaller than the 2nd
+		case c.realBody(i) < c.realBody(i-1)-c.average(far, i-1):
+		// progressively smaller bodies with some upper shadow
+		case c.realBody(i) < c.realBody(i-1) && c.realBody(i-1) < c.realBody(i-2) &&
+			(c.upperShadow(i) > c.average(shadowShort, i) ||
+				c.upperShadow(i-1) > c.average(shadowShort, i-1)):
+		// a smaller 3rd body with a long upper shadow
+		case c.realBody(i) < c.realBody(i-1) && c.upperShadow(i) > c.average(shadowLong, i):
+		default:
+			return 0
+		}
+		return -100
+	})
+}
+
+/*
+ * TA_CDLBELTHOLD - Belt-hold
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlBeltHold(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyLong, shadowVeryShort), func(i int) int {
+		if c.realBody(i) > c.average(bodyLong, i) &&
+			((c.color(i) == 1 && c.lowerShadow(i) < c.average(shadowVeryShort, i)) ||
+				(c.color(i) == -1 && c.upperShadow(i) < c.average(shadowVeryShort, i))) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLBREAKAWAY - Breakaway
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlBreakaway(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(4, bodyLong), func(i int) int {
+		if c.realBody(i-4) > c.average(bodyLong, i-4) &&
+			c.color(i-4) == c.color(i-3) && c.color(i-3) == c.color(i-1) &&
+			c.color(i-1) == -c.color(i) &&
+			((c.color(i-4) == -1 && c.realBodyGapDown(i-3, i-4) &&
+				inHigh[i-2] < inHigh[i-3] && inLow[i-2] < inLow[i-3] &&
+				inHigh[i-1] < inHigh[i-2] && inLow[i-1] < inLow[i-2] &&
+				inClose[i] > inOpen[i-3] && inClose[i] < inClose[i-4]) ||
+				(c.color(i-4) == 1 && c.realBodyGapUp(i-3, i-4) &&
+					inHigh[i-2] > inHigh[i-3] && inLow[i-2] > inLow[i-3] &&
+					inHigh[i-1] > inHigh[i-2] && inLow[i-1] > inLow[i-2] &&
+					inClose[i] < inOpen[i-3] && inClose[i] > inClose[i-4])) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLCLOSINGMARUBOZU - Closing Marubozu
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlCloSingMarubozu(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyLong, shadowVeryShort), func(i int) int {
+		if c.realBody(i) > c.average(bodyLong, i) &&
+			((c.color(i) == 1 && c.upperShadow(i) < c.average(shadowVeryShort, i)) ||
+				(c.color(i) == -1 && c.lowerShadow(i) < c.average(shadowVeryShort, i))) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLCONCEALBABYSWALL - Concealing Baby Swallow
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlCOncealBaBySwall(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(3, shadowVeryShort), func(i int) int {
+		if c.color(i-3) == -1 && c.color(i-2) == -1 && c.color(i-1) == -1 && c.color(i) == -1 &&
+			c.lowerShadow(i-3) < c.average(shadowVeryShort, i-3) &&
+			c.upperShadow(i-3) < c.average(shadowVeryShort, i-3) &&
+			c.lowerShadow(i-2) < c.average(shadowVeryShort, i-2) &&
+			c.upperShadow(i-2) < c.average(shadowVeryShort, i-2) &&
+			c.realBodyGapDown(i-1, i-2) &&
+			c.upperShadow(i-1) > c.average(shadowVeryShort, i-1) &&
+			inHigh[i-1] > inClose[i-2] &&
+			inHigh[i] > inHigh[i-1] && inLow[i] < inLow[i-1] {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLCOUNTERATTACK - Counterattack
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlCounterattack(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, equal, bodyLong), func(i int) int {
+		if c.color(i-1) == -c.color(i) &&
+			c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.realBody(i) > c.average(bodyLong, i) &&
+			inClose[i] <= inClose[i-1]+c.average(equal, i-1) &&
+			inClose[i] >= inClose[i-1]-c.average(equal, i-1) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLDARKCLOUDCOVER - Dark Cloud Cover
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlDarkCloudCover(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyLong), func(i int) int {
+		if c.color(i-1) == 1 && c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.color(i) == -1 && inOpen[i] > inHigh[i-1] &&
+			inClose[i] > inOpen[i-1] &&
+			inClose[i] < inClose[i-1]-c.realBody(i-1)*optInPenetration {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLDOJI - Doji
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlDoji(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji), func(i int) int {
+		if c.realBody(i) <= c.average(bodyDoji, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLDOJISTAR - Doji Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlDojiStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyDoji, bodyLong), func(i int) int {
+		if c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.realBody(i) <= c.average(bodyDoji, i) &&
+			((c.color(i-1) == 1 && c.realBodyGapUp(i, i-1)) ||
+				(c.color(i-1) == -1 && c.realBodyGapDown(i, i-1))) {
+			return -c.color(i-1) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLDRAGONFLYDOJI - Dragonfly Doji
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlDragOnflyDoji(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji, shadowVeryShort), func(i int) int {
+		if c.realBody(i) <= c.average(bodyDoji, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			c.lowerShadow(i) > c.average(shadowVeryShort, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLENGULFING - Engulfing Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlEngulfing(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(2, func(i int) int {
+		if (c.color(i) == 1 && c.color(i-1) == -1 &&
+			((inClose[i] >= inOpen[i-1] && inOpen[i] < inClose[i-1]) ||
+				(inClose[i] > inOpen[i-1] && inOpen[i] <= inClose[i-1]))) ||
+			(c.color(i) == -1 && c.color(i-1) == 1 &&
+				((inOpen[i] >= inClose[i-1] && inClose[i] < inOpen[i-1]) ||
+					(inOpen[i] > inClose[i-1] && inClose[i] <= inOpen[i-1]))) {
+			// a body sharing an open or close with the prior one only
+			// partially engulfs it
+			if inOpen[i] != inClose[i-1] && inClose[i] != inOpen[i-1] {
+				return c.color(i) * 100
+			}
+			return c.color(i) * 80
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLEVENINGDOJISTAR - Evening Doji Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlEveningDojiStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyDoji, bodyLong, bodyShort), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) && c.color(i-2) == 1 &&
+			c.realBody(i-1) <= c.average(bodyDoji, i-1) && c.realBodyGapUp(i-1, i-2) &&
+			c.realBody(i) > c.average(bodyShort, i) && c.color(i) == -1 &&
+			inClose[i] < inClose[i-2]-c.realBody(i-2)*optInPenetration {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLEVENINGSTAR - Evening Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlEveningStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyShort, bodyLong), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) && c.color(i-2) == 1 &&
+			c.realBody(i-1) <= c.average(bodyShort, i-1) && c.realBodyGapUp(i-1, i-2) &&
+			c.realBody(i) > c.average(bodyShort, i) && c.color(i) == -1 &&
+			inClose[i] < inClose[i-2]-c.realBody(i-2)*optInPenetration {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLGAPSIDESIDEWHITE - Up/Down-gap side-by-side white lines
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlGapSidesideWhite(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, near, equal), func(i int) int {
+		gapUp := c.realBodyGapUp(i-1, i-2) && c.realBodyGapUp(i, i-2)
+		gapDown := c.realBodyGapDown(i-1, i-2) && c.realBodyGapDown(i, i-2)
+		if (gapUp || gapDown) &&
+			c.color(i-1) == 1 && c.color(i) == 1 &&
+			c.realBody(i) >= c.realBody(i-1)-c.average(near, i-1) &&
+			c.realBody(i) <= c.realBody(i-1)+c.average(near, i-1) &&
+			inOpen[i] >= inOpen[i-1]-c.average(equal, i-1) &&
+			inOpen[i] <= inOpen[i-1]+c.average(equal, i-1) {
+			if gapUp {
+				return 100
+			}
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLGRAVESTONEDOJI - Gravestone Doji
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlGravestOneDoji(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji, shadowVeryShort), func(i int) int {
+		if c.realBody(i) <= c.average(bodyDoji, i) &&
+			c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			c.upperShadow(i) > c.average(shadowVeryShort, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLHAMMER - Hammer
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHammer(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyShort, shadowLong, shadowVeryShort, near), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.lowerShadow(i) > c.average(shadowLong, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			c.bodyBottom(i) <= inLow[i-1]+c.average(near, i-1) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLHANGINGMAN - Hanging Man
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHangingMan(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyShort, shadowLong, shadowVeryShort, near), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.lowerShadow(i) > c.average(shadowLong, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			c.bodyBottom(i) >= inHigh[i-1]-c.average(near, i-1) {
+			return -100
+		}
+		return 0
+	})
+}
+
+// harami scores a small second body (as judged by second) inside a long
+// first one: 100 when strictly inside, 80 when it shares an edge.
+func harami(c *candles, second candleSetting) []int {
+	return c.scan(candleLookback(1, second, bodyLong), func(i int) int {
+		if c.realBody(i-1) <= c.average(bodyLong, i-1) ||
+			c.realBody(i) > c.average(second, i) {
+			return 0
+		}
+		if c.bodyTop(i) < c.bodyTop(i-1) && c.bodyBottom(i) > c.bodyBottom(i-1) {
+			return -c.color(i-1) * 100
+		}
+		if c.bodyTop(i) <= c.bodyTop(i-1) && c.bodyBottom(i) >= c.bodyBottom(i-1) {
+			return -c.color(i-1) * 80
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLHARAMI - Harami Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHarami(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	return harami(newCandles(inOpen, inHigh, inLow, inClose), bodyShort)
+}
+
+/*
+ * TA_CDLHARAMICROSS - Harami Cross Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHaramiCross(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	return harami(newCandles(inOpen, inHigh, inLow, inClose), bodyDoji)
+}
+
+/*
+ * TA_CDLHIGHWAVE - High-Wave Candle
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHighWave(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyShort, shadowVeryLong), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.upperShadow(i) > c.average(shadowVeryLong, i) &&
+			c.lowerShadow(i) > c.average(shadowVeryLong, i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+// hikkake tracks an inside bar breakout found by isPattern and, for up to
+// three candles after it, its confirmation: a close beyond the inside bar
+// against the false breakout. Patterns score 100 and confirmations 200. The
+// state is built from first, so candles before the lookback still prime it.
+func hikkake(c *candles, first, lookback int, isPattern func(i int) bool) []int {
+	n := c.len()
+	out := make([]int, n)
+	patternIdx, patternResult := 0, 0
+	for i := first; i < n; i++ {
+		result := 0
+		if isPattern(i) {
+			patternResult = 100
+			if c.high[i] >= c.high[i-1] {
+				patternResult = -100
+			}
+			patternIdx = i
+			result = patternResult
+		} else if i <= patternIdx+3 &&
+			((patternResult > 0 && c.close[i] > c.high[patternIdx-1]) ||
+				(patternResult < 0 && c.close[i] < c.low[patternIdx-1])) {
+			result = patternResult * 2
+			patternIdx = 0
+		}
+		if i >= lookback {
+			out[i] = result
+		}
+	}
+	return out
+}
+
+/*
+ * TA_CDLHIKKAKE - Hikkake Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHikkake(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return hikkake(c, 2, 5, func(i int) bool {
+		return inHigh[i-1] < inHigh[i-2] && inLow[i-1] > inLow[i-2] &&
+			((inHigh[i] < inHigh[i-1] && inLow[i] < inLow[i-1]) ||
+				(inHigh[i] > inHigh[i-1] && inLow[i] > inLow[i-1]))
+	})
+}
+
+/*
+ * TA_CDLHIKKAKEMOD - Modified Hikkake Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHikkakeMod(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	lookback := candleLookback(5, near)
+	if lookback < 6 {
+		lookback = 6
+	}
+	return hikkake(c, lookback-3, lookback, func(i int) bool {
+		return inHigh[i-2] < inHigh[i-3] && inLow[i-2] > inLow[i-3] &&
+			inHigh[i-1] < inHigh[i-2] && inLow[i-1] > inLow[i-2] &&
+			((inHigh[i] < inHigh[i-1] && inLow[i] < inLow[i-1] &&
+				inClose[i-2] <= inLow[i-2]+c.average(near, i-2)) ||
+				(inHigh[i] > inHigh[i-1] && inLow[i] > inLow[i-1] &&
+					inClose[i-2] >= inHigh[i-2]-c.average(near, i-2)))
+	})
+}
+
+/*
+ * TA_CDLHOMINGPIGEON - Homing Pigeon
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlHoMingPigeOn(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyShort, bodyLong), func(i int) int {
+		if c.color(i-1) == -1 && c.color(i) == -1 &&
+			c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.realBody(i) <= c.average(bodyShort, i) &&
+			inOpen[i] < inOpen[i-1] && inClose[i] > inClose[i-1] {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLIDENTICAL3CROWS - Identical Three Crows
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlIdentical3Crows(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, shadowVeryShort, equal), func(i int) int {
+		if c.color(i-2) == -1 && c.lowerShadow(i-2) < c.average(shadowVeryShort, i-2) &&
+			c.color(i-1) == -1 && c.lowerShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+			c.color(i) == -1 && c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			inClose[i-2] > inClose[i-1] && inClose[i-1] > inClose[i] &&
+			inOpen[i-1] <= inClose[i-2]+c.average(equal, i-2) &&
+			inOpen[i-1] >= inClose[i-2]-c.average(equal, i-2) &&
+			inOpen[i] <= inClose[i-1]+c.average(equal, i-1) &&
+			inOpen[i] >= inClose[i-1]-c.average(equal, i-1) {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLINNECK - In-Neck Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlinNeck(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, equal, bodyLong), func(i int) int {
+		if c.color(i-1) == -1 && c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.color(i) == 1 && inOpen[i] < inLow[i-1] &&
+			inClose[i] <= inClose[i-1]+c.average(equal, i-1) &&
+			inClose[i] >= inClose[i-1] {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLINVERTEDHAMMER - Inverted Hammer
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlinvertedHammer(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyShort, shadowLong, shadowVeryShort), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.upperShadow(i) > c.average(shadowLong, i) &&
+			c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			c.realBodyGapDown(i, i-1) {
+			return 100
+		}
+		return 0
+	})
+}
+
+// isKicking reports two opposite marubozu with a gap in the direction of
+// the second.
+func isKicking(c *candles, i int) bool {
+	return c.color(i-1) == -c.color(i) &&
+		c.realBody(i-1) > c.average(bodyLong, i-1) &&
+		c.upperShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+		c.lowerShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+		c.realBody(i) > c.average(bodyLong, i) &&
+		c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+		c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+		((c.color(i-1) == -1 && c.candleGapUp(i, i-1)) ||
+			(c.color(i-1) == 1 && c.candleGapDown(i, i-1)))
+}
+
+/*
+ * TA_CDLKICKING - Kicking
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlKicking(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, shadowVeryShort, bodyLong), func(i int) int {
+		if isKicking(c, i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLKICKINGBYLENGTH - Kicking - bull/bear determined by the longer marubozu
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlKickingByLength(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, shadowVeryShort, bodyLong), func(i int) int {
+		if !isKicking(c, i) {
+			return 0
+		}
+		if c.realBody(i) > c.realBody(i-1) {
+			return c.color(i) * 100
+		}
+		return c.color(i-1) * 100
+	})
+}
+
+/*
+ * TA_CDLLADDERBOTTOM - Ladder Bottom
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlLadderBottom(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(4, shadowVeryShort), func(i int) int {
+		if c.color(i-4) == -1 && c.color(i-3) == -1 && c.color(i-2) == -1 &&
+			inOpen[i-4] > inOpen[i-3] && inOpen[i-3] > inOpen[i-2] &&
+			inClose[i-4] > inClose[i-3] && inClose[i-3] > inClose[i-2] &&
+			c.color(i-1) == -1 && c.upperShadow(i-1) > c.average(shadowVeryShort, i-1) &&
+			c.color(i) == 1 && inOpen[i] > inOpen[i-1] && inClose[i] > inHigh[i-1] {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLLONGLEGGEDDOJI - Long Legged Doji
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlLOngLeggedDoji(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji, shadowLong), func(i int) int {
+		if c.realBody(i) <= c.average(bodyDoji, i) &&
+			(c.lowerShadow(i) > c.average(shadowLong, i) ||
+				c.upperShadow(i) > c.average(shadowLong, i)) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLLONGLINE - Long Line Candle
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlLOngLine(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyLong, shadowShort), func(i int) int {
+		if c.realBody(i) > c.average(bodyLong, i) &&
+			c.upperShadow(i) < c.average(shadowShort, i) &&
+			c.lowerShadow(i) < c.average(shadowShort, i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLMARUBOZU - Marubozu
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlMarubozu(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyLong, shadowVeryShort), func(i int) int {
+		if c.realBody(i) > c.average(bodyLong, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			c.lowerShadow(i) < c.average(shadowVeryShort, i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLMATCHINGLOW - Matching Low
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlMatchingLow(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, equal), func(i int) int {
+		if c.color(i-1) == -1 && c.color(i) == -1 &&
+			inClose[i] <= inClose[i-1]+c.average(equal, i-1) &&
+			inClose[i] >= inClose[i-1]-c.average(equal, i-1) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLMATHOLD - Mat Hold
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlMatHold(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(4, bodyShort, bodyLong), func(i int) int {
+		floor := inClose[i-4] - c.realBody(i-4)*optInPenetration
+		if c.realBody(i-4) > c.average(bodyLong, i-4) &&
+			c.realBody(i-3) < c.average(bodyShort, i-3) &&
+			c.realBody(i-2) < c.average(bodyShort, i-2) &&
+			c.realBody(i-1) < c.average(bodyShort, i-1) &&
+			c.color(i-4) == 1 && c.color(i-3) == -1 && c.color(i) == 1 &&
+			c.realBodyGapUp(i-3, i-4) &&
+			c.bodyBottom(i-2) < inClose[i-4] && c.bodyBottom(i-1) < inClose[i-4] &&
+			c.bodyBottom(i-2) > floor && c.bodyBottom(i-1) > floor &&
+			c.bodyTop(i-2) < inOpen[i-3] && c.bodyTop(i-1) < c.bodyTop(i-2) &&
+			inOpen[i] > inClose[i-1] &&
+			inClose[i] > math.Max(math.Max(inHigh[i-3], inHigh[i-2]), inHigh[i-1]) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLMORNINGDOJISTAR - Morning Doji Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlMorningDojiStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyDoji, bodyLong, bodyShort), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) && c.color(i-2) == -1 &&
+			c.realBody(i-1) <= c.average(bodyDoji, i-1) && c.realBodyGapDown(i-1, i-2) &&
+			c.realBody(i) > c.average(bodyShort, i) && c.color(i) == 1 &&
+			inClose[i] > inClose[i-2]+c.realBody(i-2)*optInPenetration {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLMORNINGSTAR - Morning Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ * Optional Parameters
+ * -------------------
+ * optInPenetration:(From 0 to TA_REAL_MAX)
+ *    Percentage of penetration of a candle within another candle
+ *
+ *
+ */
+func CdlMorningStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64, optInPenetration float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyShort, bodyLong), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) && c.color(i-2) == -1 &&
+			c.realBody(i-1) <= c.average(bodyShort, i-1) && c.realBodyGapDown(i-1, i-2) &&
+			c.realBody(i) > c.average(bodyShort, i) && c.color(i) == 1 &&
+			inClose[i] > inClose[i-2]+c.realBody(i-2)*optInPenetration {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLONNECK - On-Neck Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlOnNeck(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, equal, bodyLong), func(i int) int {
+		if c.color(i-1) == -1 && c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.color(i) == 1 && inOpen[i] < inLow[i-1] &&
+			inClose[i] <= inLow[i-1]+c.average(equal, i-1) &&
+			inClose[i] >= inLow[i-1]-c.average(equal, i-1) {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLPIERCING - Piercing Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlPiercing(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyLong), func(i int) int {
+		if c.color(i-1) == -1 && c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.color(i) == 1 && c.realBody(i) > c.average(bodyLong, i) &&
+			inOpen[i] < inLow[i-1] && inClose[i] < inOpen[i-1] &&
+			inClose[i] > inClose[i-1]+c.realBody(i-1)*0.5 {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLRICKSHAWMAN - Rickshaw Man
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlRickshawMan(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji, shadowLong, near), func(i int) int {
+		mid := inLow[i] + c.highLow(i)/2
+		if c.realBody(i) <= c.average(bodyDoji, i) &&
+			c.lowerShadow(i) > c.average(shadowLong, i) &&
+			c.upperShadow(i) > c.average(shadowLong, i) &&
+			c.bodyBottom(i) <= mid+c.average(near, i) &&
+			c.bodyTop(i) >= mid-c.average(near, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLRISEFALL3METHODS - Rising/Falling Three Methods
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlRiseFall3Methods(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(4, bodyShort, bodyLong), func(i int) int {
+		// multiplying by the first color turns the falling checks of the
+		// rising pattern into the rising checks of the falling one
+		dir := float64(c.color(i - 4))
+		if c.realBody(i-4) > c.average(bodyLong, i-4) &&
+			c.realBody(i-3) < c.average(bodyShort, i-3) &&
+			c.realBody(i-2) < c.average(bodyShort, i-2) &&
+			c.realBody(i-1) < c.average(bodyShort, i-1) &&
+			c.realBody(i) > c.average(bodyLong, i) &&
+			c.color(i-4) == -c.color(i-3) && c.color(i-3) == c.color(i-2) &&
+			c.color(i-2) == c.color(i-1) && c.color(i-1) == -c.color(i) &&
+			c.bodyBottom(i-3) < inHigh[i-4] && c.bodyTop(i-3) > inLow[i-4] &&
+			c.bodyBottom(i-2) < inHigh[i-4] && c.bodyTop(i-2) > inLow[i-4] &&
+			c.bodyBottom(i-1) < inHigh[i-4] && c.bodyTop(i-1) > inLow[i-4] &&
+			inClose[i-2]*dir < inClose[i-3]*dir &&
+			inClose[i-1]*dir < inClose[i-2]*dir &&
+			inOpen[i]*dir > inClose[i-1]*dir &&
+			inClose[i]*dir > inClose[i-4]*dir {
+			return c.color(i-4) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSEPARATINGLINES - Separating Lines
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlSeparatingLines(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, shadowVeryShort, bodyLong, equal), func(i int) int {
+		if c.color(i-1) == -c.color(i) &&
+			inOpen[i] <= inOpen[i-1]+c.average(equal, i-1) &&
+			inOpen[i] >= inOpen[i-1]-c.average(equal, i-1) &&
+			c.realBody(i) > c.average(bodyLong, i) &&
+			((c.color(i) == 1 && c.lowerShadow(i) < c.average(shadowVeryShort, i)) ||
+				(c.color(i) == -1 && c.upperShadow(i) < c.average(shadowVeryShort, i))) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSHOOTINGSTAR - Shooting Star
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlShootingStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, bodyShort, shadowLong, shadowVeryShort), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.upperShadow(i) > c.average(shadowLong, i) &&
+			c.lowerShadow(i) < c.average(shadowVeryShort, i) &&
+			c.realBodyGapUp(i, i-1) {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSHORTLINE - Short Line Candle
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlShortLine(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyShort, shadowShort), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.upperShadow(i) < c.average(shadowShort, i) &&
+			c.lowerShadow(i) < c.average(shadowShort, i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSPINNINGTOP - Spinning Top
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlSpinningTop(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyShort), func(i int) int {
+		if c.realBody(i) < c.average(bodyShort, i) &&
+			c.upperShadow(i) > c.realBody(i) &&
+			c.lowerShadow(i) > c.realBody(i) {
+			return c.color(i) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSTALLEDPATTERN - Stalled Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlStalledPattern(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyLong, bodyShort, shadowVeryShort, near), func(i int) int {
+		if c.color(i-2) == 1 && c.color(i-1) == 1 && c.color(i) == 1 &&
+			inClose[i] > inClose[i-1] && inClose[i-1] > inClose[i-2] &&
+			c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.upperShadow(i-1) < c.average(shadowVeryShort, i-1) &&
+			inOpen[i-1] > inOpen[i-2] && inOpen[i-1] <= inClose[i-2]+c.average(near, i-2) &&
+			c.realBody(i) < c.average(bodyShort, i) &&
+			inOpen[i] >= inClose[i-1]-c.realBody(i)-c.average(near, i-1) {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLSTICKSANDWICH - Stick Sandwich
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlStickSandwich(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, equal), func(i int) int {
+		if c.color(i-2) == -1 && c.color(i-1) == 1 && c.color(i) == -1 &&
+			inLow[i-1] > inClose[i-2] &&
+			inClose[i] <= inClose[i-2]+c.average(equal, i-2) &&
+			inClose[i] >= inClose[i-2]-c.average(equal, i-2) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLTAKURI - Takuri (Dragonfly Doji with very long lower shadow)
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlTakuri(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(0, bodyDoji, shadowVeryShort, shadowVeryLong), func(i int) int {
+		if c.realBody(i) <= c.average(bodyDoji, i) &&
+			c.upperShadow(i) < c.average(shadowVeryShort, i) &&
+			c.lowerShadow(i) > c.average(shadowVeryLong, i) {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLTASUKIGAP - Tasuki Gap
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlTasukiGap(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, near), func(i int) int {
+		sameSize := math.Abs(c.realBody(i-1)-c.realBody(i)) < c.average(near, i-1)
+		if sameSize &&
+			((c.realBodyGapUp(i-1, i-2) &&
+				c.color(i-1) == 1 && c.color(i) == -1 &&
+				inOpen[i] < inClose[i-1] && inOpen[i] > inOpen[i-1] &&
+				inClose[i] < inOpen[i-1] && inClose[i] > c.bodyTop(i-2)) ||
+				(c.realBodyGapDown(i-1, i-2) &&
+					c.color(i-1) == -1 && c.color(i) == 1 &&
+					inOpen[i] < inOpen[i-1] && inOpen[i] > inClose[i-1] &&
+					inClose[i] > inOpen[i-1] && inClose[i] < c.bodyBottom(i-2))) {
+			return c.color(i-1) * 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLTHRUSTING - Thrusting Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlThrusting(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(1, equal, bodyLong), func(i int) int {
+		if c.color(i-1) == -1 && c.realBody(i-1) > c.average(bodyLong, i-1) &&
+			c.color(i) == 1 && inOpen[i] < inLow[i-1] &&
+			inClose[i] > inClose[i-1]+c.average(equal, i-1) &&
+			inClose[i] <= inClose[i-1]+c.realBody(i-1)*0.5 {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLTRISTAR - Tristar Pattern
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdltriStar(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyDoji), func(i int) int {
+		// all three dojis are measured against the average before the first
+		doji := c.average(bodyDoji, i-2)
+		if c.realBody(i-2) > doji || c.realBody(i-1) > doji || c.realBody(i) > doji {
+			return 0
+		}
+		result := 0
+		if c.realBodyGapUp(i-1, i-2) && c.bodyTop(i) < c.bodyTop(i-1) {
+			result = -100
+		}
+		if c.realBodyGapDown(i-1, i-2) && c.bodyBottom(i) > c.bodyBottom(i-1) {
+			result = 100
+		}
+		return result
+	})
+}
+
+/*
+ * TA_CDLUNIQUE3RIVER - Unique 3 River
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlUnique3River(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyShort, bodyLong), func(i int) int {
+		if c.realBody(i-2) > c.average(bodyLong, i-2) && c.color(i-2) == -1 &&
+			c.color(i-1) == -1 &&
+			inClose[i-1] > inClose[i-2] && inOpen[i-1] <= inOpen[i-2] &&
+			inLow[i-1] < inLow[i-2] &&
+			c.realBody(i) < c.average(bodyShort, i) && c.color(i) == 1 &&
+			inOpen[i] > inLow[i-1] {
+			return 100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLUPSIDEGAP2CROWS - Upside Gap Two Crows
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlupSideGap2Crows(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(candleLookback(2, bodyShort, bodyLong), func(i int) int {
+		if c.color(i-2) == 1 && c.realBody(i-2) > c.average(bodyLong, i-2) &&
+			c.color(i-1) == -1 && c.realBody(i-1) <= c.average(bodyShort, i-1) &&
+			c.realBodyGapUp(i-1, i-2) &&
+			c.color(i) == -1 &&
+			inOpen[i] > inOpen[i-1] && inClose[i] < inClose[i-1] &&
+			inClose[i] > inClose[i-2] {
+			return -100
+		}
+		return 0
+	})
+}
+
+/*
+ * TA_CDLXSIDEGAP3METHODS - Upside/Downside Gap Three Methods
+ *
+ * Input  = Open, High, Low, Close
+ * Output = int
+ *
+ */
+func CdlxSideGap3Methods(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outInteger []int) {
+	c := newCandles(inOpen, inHigh, inLow, inClose)
+	return c.scan(2, func(i int) int {
+		if c.color(i-2) == c.color(i-1) && c.color(i-1) == -c.color(i) &&
+			inOpen[i] < c.bodyTop(i-1) && inOpen[i] > c.bodyBottom(i-1) &&
+			inClose[i] < c.bodyTop(i-2) && inClose[i] > c.bodyBottom(i-2) &&
+			((c.color(i-2) == 1 && c.realBodyGapUp(i-1, i-2)) ||
+				(c.color(i-2) == -1 && c.realBodyGapDown(i-1, i-2))) {
+			return c.color(i-2) * 100
+		}
+		return 0
+	})
+}
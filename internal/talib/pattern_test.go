@@ -0,0 +1,202 @@
+package talib
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// The golden files of testdata/patterns hold candles of the data/ snapshots
+// along with the patterns found on them, with the default penetrations.
+// go test -update writes them anew from the snapshots with the outputs of
+// this package, which testdata/patterns/reference.py replaces by those of
+// the TA-Lib C library. Their generator says which of the two wrote them:
+// only the TA-Lib ones check the port, the others just catch regressions.
+var update = flag.Bool("update", false, "write the golden files of the patterns")
+
+// portGenerator is the generator of golden files written by -update.
+const portGenerator = "cryptoapi/internal/talib"
+
+// goldenCandles is how many of the last candles of each snapshot are kept.
+const goldenCandles = 1000
+
+var goldenSources = []string{
+	"BTCUSDT_1h_old_1578881974.gz",
+	"ETHUSDT_4h_old_1578882099.gz",
+	"XRPUSDT_15m_old_1578882187.gz",
+}
+
+var patterns = map[string]func(o, h, l, c []float64) []int{
+	"CDL2CROWS":           Cdl2Crows,
+	"CDL3BLACKCROWS":      Cdl3BlackCrows,
+	"CDL3INSIDE":          Cdl3InSide,
+	"CDL3LINESTRIKE":      Cdl3LineStrike,
+	"CDL3OUTSIDE":         Cdl3OutSide,
+	"CDL3STARSINSOUTH":    Cdl3StarsInSouth,
+	"CDL3WHITESOLDIERS":   Cdl3WhiteSoldiers,
+	"CDLABANDONEDBABY":    func(o, h, l, c []float64) []int { return CdlAbandOnedBaBy(o, h, l, c, 0.3) },
+	"CDLADVANCEBLOCK":     CdlAdvanceBlock,
+	"CDLBELTHOLD":         CdlBeltHold,
+	"CDLBREAKAWAY":        CdlBreakaway,
+	"CDLCLOSINGMARUBOZU":  CdlCloSingMarubozu,
+	"CDLCONCEALBABYSWALL": CdlCOncealBaBySwall,
+	"CDLCOUNTERATTACK":    CdlCounterattack,
+	"CDLDARKCLOUDCOVER":   func(o, h, l, c []float64) []int { return CdlDarkCloudCover(o, h, l, c, 0.5) },
+	"CDLDOJI":             CdlDoji,
+	"CDLDOJISTAR":         CdlDojiStar,
+	"CDLDRAGONFLYDOJI":    CdlDragOnflyDoji,
+	"CDLENGULFING":        CdlEngulfing,
+	"CDLEVENINGDOJISTAR":  func(o, h, l, c []float64) []int { return CdlEveningDojiStar(o, h, l, c, 0.3) },
+	"CDLEVENINGSTAR":      func(o, h, l, c []float64) []int { return CdlEveningStar(o, h, l, c, 0.3) },
+	"CDLGAPSIDESIDEWHITE": CdlGapSidesideWhite,
+	"CDLGRAVESTONEDOJI":   CdlGravestOneDoji,
+	"CDLHAMMER":           CdlHammer,
+	"CDLHANGINGMAN":       CdlHangingMan,
+	"CDLHARAMI":           CdlHarami,
+	"CDLHARAMICROSS":      CdlHaramiCross,
+	"CDLHIGHWAVE":         CdlHighWave,
+	"CDLHIKKAKE":          CdlHikkake,
+	"CDLHIKKAKEMOD":       CdlHikkakeMod,
+	"CDLHOMINGPIGEON":     CdlHoMingPigeOn,
+	"CDLIDENTICAL3CROWS":  CdlIdentical3Crows,
+	"CDLINNECK":           CdlinNeck,
+	"CDLINVERTEDHAMMER":   CdlinvertedHammer,
+	"CDLKICKING":          CdlKicking,
+	"CDLKICKINGBYLENGTH":  CdlKickingByLength,
+	"CDLLADDERBOTTOM":     CdlLadderBottom,
+	"CDLLONGLEGGEDDOJI":   CdlLOngLeggedDoji,
+	"CDLLONGLINE":         CdlLOngLine,
+	"CDLMARUBOZU":         CdlMarubozu,
+	"CDLMATCHINGLOW":      CdlMatchingLow,
+	"CDLMATHOLD":          func(o, h, l, c []float64) []int { return CdlMatHold(o, h, l, c, 0.5) },
+	"CDLMORNINGDOJISTAR":  func(o, h, l, c []float64) []int { return CdlMorningDojiStar(o, h, l, c, 0.3) },
+	"CDLMORNINGSTAR":      func(o, h, l, c []float64) []int { return CdlMorningStar(o, h, l, c, 0.3) },
+	"CDLONNECK":           CdlOnNeck,
+	"CDLPIERCING":         CdlPiercing,
+	"CDLRICKSHAWMAN":      CdlRickshawMan,
+	"CDLRISEFALL3METHODS": CdlRiseFall3Methods,
+	"CDLSEPARATINGLINES":  CdlSeparatingLines,
+	"CDLSHOOTINGSTAR":     CdlShootingStar,
+	"CDLSHORTLINE":        CdlShortLine,
+	"CDLSPINNINGTOP":      CdlSpinningTop,
+	"CDLSTALLEDPATTERN":   CdlStalledPattern,
+	"CDLSTICKSANDWICH":    CdlStickSandwich,
+	"CDLTAKURI":           CdlTakuri,
+	"CDLTASUKIGAP":        CdlTasukiGap,
+	"CDLTHRUSTING":        CdlThrusting,
+	"CDLTRISTAR":          CdltriStar,
+	"CDLUNIQUE3RIVER":     CdlUnique3River,
+	"CDLUPSIDEGAP2CROWS":  CdlupSideGap2Crows,
+	"CDLXSIDEGAP3METHODS": CdlxSideGap3Methods,
+}
+
+type golden struct {
+	Source    string    `json:"source"`
+	Generator string    `json:"generator"`
+	Open      []float64 `json:"open"`
+	High      []float64 `json:"high"`
+	Low       []float64 `json:"low"`
+	Close     []float64 `json:"close"`
+	// index and value of the non zero outputs of each pattern
+	Patterns map[string][][2]int `json:"patterns"`
+}
+
+func goldenPath(source string) string {
+	return filepath.Join("testdata", "patterns", source[:len(source)-len(filepath.Ext(source))]+".json")
+}
+
+// readSnapshot reads the last candles of a gob+gzip snapshot of data/.
+func readSnapshot(t *testing.T, source string) golden {
+	f, err := os.Open(filepath.Join("..", "..", "data", source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		Open, High, Low, Close []float64
+	}
+	if err := gob.NewDecoder(r).Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+	from := 0
+	if n := len(data.Close); n > goldenCandles {
+		from = n - goldenCandles
+	}
+	return golden{
+		Source: source,
+		Open:   data.Open[from:],
+		High:   data.High[from:],
+		Low:    data.Low[from:],
+		Close:  data.Close[from:],
+	}
+}
+
+func sparse(out []int) [][2]int {
+	res := [][2]int{}
+	for i, v := range out {
+		if v != 0 {
+			res = append(res, [2]int{i, v})
+		}
+	}
+	return res
+}
+
+func TestPatternGolden(t *testing.T) {
+	for _, source := range goldenSources {
+		path := goldenPath(source)
+		if *update {
+			g := readSnapshot(t, source)
+			g.Generator = portGenerator
+			g.Patterns = make(map[string][][2]int, len(patterns))
+			for name, fn := range patterns {
+				g.Patterns[name] = sparse(fn(g.Open, g.High, g.Low, g.Close))
+			}
+			b, err := json.Marshal(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var g golden
+		if err := json.Unmarshal(b, &g); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(g.Generator, "TA-Lib") {
+			t.Logf("%s: written by %s rather than TA-Lib, see testdata/patterns/reference.py", source, g.Generator)
+		}
+		var names []string
+		for name := range patterns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			want, ok := g.Patterns[name]
+			if !ok {
+				t.Errorf("%s: no %s in the golden file", source, name)
+				continue
+			}
+			if got := sparse(patterns[name](g.Open, g.High, g.Low, g.Close)); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %s found %v, want %v", source, name, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package talib
+
+/*
+ * TA_AVGPRICE - Average Price
+ *
+ * Input  = Open, High, Low, Close
+ * Output = double
+ *
+ */
+func AvgPrice(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outReal []float64) {
+	outReal = make([]float64, len(inOpen))
+	for i := range inOpen {
+		outReal[i] = (inOpen[i] + inHigh[i] + inLow[i] + inClose[i]) / 4.0
+	}
+	return outReal
+}
+
+/*
+ * TA_MEDPRICE - Median Price
+ *
+ * Input  = High, Low
+ * Output = double
+ *
+ */
+func MedPrice(inHigh []float64, inLow []float64) (outReal []float64) {
+	outReal = make([]float64, len(inHigh))
+	for i := range inHigh {
+		outReal[i] = (inHigh[i] + inLow[i]) / 2.0
+	}
+	return outReal
+}
+
+/*
+ * TA_TYPPRICE - Typical Price
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ */
+func TypPrice(inHigh []float64, inLow []float64, inClose []float64) (outReal []float64) {
+	outReal = make([]float64, len(inHigh))
+	for i := range inHigh {
+		outReal[i] = (inHigh[i] + inLow[i] + inClose[i]) / 3.0
+	}
+	return outReal
+}
+
+/*
+ * TA_WCLPRICE - Weighted Close Price
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ */
+func WclPrice(inHigh []float64, inLow []float64, inClose []float64) (outReal []float64) {
+	outReal = make([]float64, len(inHigh))
+	for i := range inHigh {
+		outReal[i] = (inHigh[i] + inLow[i] + inClose[i]*2.0) / 4.0
+	}
+	return outReal
+}
@@ -0,0 +1,301 @@
+package talib
+
+import (
+	"math"
+)
+
+// variance is the population variance of the last period values, returned
+// from index period-1 on.
+func variance(in []float64, period int) (int, []float64) {
+	lookback := period - 1
+	if period < 1 || len(in) <= lookback {
+		return 0, nil
+	}
+	p := float64(period)
+	out := make([]float64, len(in)-lookback)
+	var sum, sumSq float64
+	for i := 0; i < lookback; i++ {
+		sum += in[i]
+		sumSq += in[i] * in[i]
+	}
+	for i := lookback; i < len(in); i++ {
+		sum += in[i]
+		sumSq += in[i] * in[i]
+		mean := sum / p
+		out[i-lookback] = sumSq/p - mean*mean
+		trailing := in[i-lookback]
+		sum -= trailing
+		sumSq -= trailing * trailing
+	}
+	return lookback, out
+}
+
+func stdDev(in []float64, period int) (int, []float64) {
+	beg, out := variance(in, period)
+	for i, v := range out {
+		if isZeroOrNeg(v) {
+			out[i] = 0
+		} else {
+			out[i] = math.Sqrt(v)
+		}
+	}
+	return beg, out
+}
+
+/*
+ * TA_STDDEV - Standard Deviation
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ * optInNbDev:(From TA_REAL_MIN to TA_REAL_MAX)
+ *    Nb of deviations
+ *
+ *
+ */
+func StdDev(inReal []float64, optInTimePeriod int, optInNbDev float64) (outReal []float64) {
+	beg, out := stdDev(inReal, optInTimePeriod)
+	for i := range out {
+		out[i] *= optInNbDev
+	}
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_VAR - Variance
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ * optInNbDev:(From TA_REAL_MIN to TA_REAL_MAX)
+ *    Nb of deviations
+ *
+ *
+ */
+func Var(inReal []float64, optInTimePeriod int, optInNbDev float64) (outReal []float64) {
+	// TA_VAR ignores optInNbDev as well.
+	beg, out := variance(inReal, optInTimePeriod)
+	return pad(len(inReal), beg, out)
+}
+
+/*
+ * TA_BETA - Beta
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Beta(inReal0 []float64, inReal1 []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inReal0)
+	outReal = make([]float64, n)
+	if optInTimePeriod < 1 || n <= optInTimePeriod {
+		return outReal
+	}
+	ret := func(in []float64, i int) float64 {
+		if in[i-1] == 0 {
+			return 0
+		}
+		return (in[i] - in[i-1]) / in[i-1]
+	}
+	p := float64(optInTimePeriod)
+	for today := optInTimePeriod; today < n; today++ {
+		var sxx, sxy, sx, sy float64
+		for i := today - optInTimePeriod + 1; i <= today; i++ {
+			x, y := ret(inReal0, i), ret(inReal1, i)
+			sxx += x * x
+			sxy += x * y
+			sx += x
+			sy += y
+		}
+		if d := p*sxx - sx*sx; !isZero(d) {
+			outReal[today] = (p*sxy - sx*sy) / d
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_CORREL - Pearson's Correlation Coefficient (r)
+ *
+ * Input  = double, double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Correl(inReal0 []float64, inReal1 []float64, optInTimePeriod int) (outReal []float64) {
+	n := len(inReal0)
+	outReal = make([]float64, n)
+	lookback := optInTimePeriod - 1
+	if optInTimePeriod < 1 || n <= lookback {
+		return outReal
+	}
+	p := float64(optInTimePeriod)
+	var sumX, sumY, sumX2, sumY2, sumXY float64
+	for today := 0; today < n; today++ {
+		x, y := inReal0[today], inReal1[today]
+		sumX += x
+		sumY += y
+		sumX2 += x * x
+		sumY2 += y * y
+		sumXY += x * y
+		if today < lookback {
+			continue
+		}
+		if d := (sumX2 - (sumX*sumX)/p) * (sumY2 - (sumY*sumY)/p); !isZeroOrNeg(d) {
+			outReal[today] = (sumXY - (sumX*sumY)/p) / math.Sqrt(d)
+		}
+		x, y = inReal0[today-lookback], inReal1[today-lookback]
+		sumX -= x
+		sumY -= y
+		sumX2 -= x * x
+		sumY2 -= y * y
+		sumXY -= x * y
+	}
+	return outReal
+}
+
+// linearReg fits a least squares line over the last period values and hands
+// the slope and intercept to fn. The x axis counts bars backwards from the
+// current one, as TA-Lib does.
+func linearReg(inReal []float64, period int, fn func(m, b float64) float64) []float64 {
+	n := len(inReal)
+	out := make([]float64, n)
+	lookback := period - 1
+	if period < 2 || n <= lookback {
+		return out
+	}
+	p := float64(period)
+	sumX := p * (p - 1) * 0.5
+	sumXSqr := p * (p - 1) * (2*p - 1) / 6
+	divisor := sumX*sumX - p*sumXSqr
+	for today := lookback; today < n; today++ {
+		var sumXY, sumY float64
+		for i := 0; i < period; i++ {
+			v := inReal[today-i]
+			sumY += v
+			sumXY += float64(i) * v
+		}
+		m := (p*sumXY - sumX*sumY) / divisor
+		b := (sumY - m*sumX) / p
+		out[today] = fn(m, b)
+	}
+	return out
+}
+
+/*
+ * TA_LINEARREG - Linear Regression
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func LinearReg(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	p := float64(optInTimePeriod)
+	return linearReg(inReal, optInTimePeriod, func(m, b float64) float64 {
+		return b + m*(p-1)
+	})
+}
+
+/*
+ * TA_LINEARREG_ANGLE - Linear Regression Angle
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func LinearRegAngle(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return linearReg(inReal, optInTimePeriod, func(m, b float64) float64 {
+		return math.Atan(m) * rad2Deg
+	})
+}
+
+/*
+ * TA_LINEARREG_INTERCEPT - Linear Regression Intercept
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func LinearRegIntercept(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return linearReg(inReal, optInTimePeriod, func(m, b float64) float64 {
+		return b
+	})
+}
+
+/*
+ * TA_LINEARREG_SLOPE - Linear Regression Slope
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func LinearRegSlope(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	return linearReg(inReal, optInTimePeriod, func(m, b float64) float64 {
+		return m
+	})
+}
+
+/*
+ * TA_TSF - Time Series Forecast
+ *
+ * Input  = double
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 2 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Tsf(inReal []float64, optInTimePeriod int) (outReal []float64) {
+	p := float64(optInTimePeriod)
+	return linearReg(inReal, optInTimePeriod, func(m, b float64) float64 {
+		return b + m*p
+	})
+}
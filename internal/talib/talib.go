@@ -0,0 +1,88 @@
+package talib
+
+import (
+	"math"
+)
+
+// Moving average types accepted by every optIn*MAType parameter. The values
+// match TA_MAType so existing call sites keep passing the same integers.
+const (
+	SMA = iota
+	EMA
+	WMA
+	DEMA
+	TEMA
+	TRIMA
+	KAMA
+	MAMA
+	T3MA
+)
+
+const (
+	rad2Deg = 180.0 / math.Pi
+	deg2Rad = math.Pi / 180.0
+)
+
+/* TA_Initialize() initialize the ressources used by TA-Lib. This
+ * function must be called once prior to any other functions declared in
+ * this file.
+ *
+ * The native implementation holds no global resources, it is kept so
+ * callers written against the DLL keep compiling.
+ */
+func Initialize() error {
+	return nil
+}
+
+/* TA_Shutdown() allows to free all ressources used by TA-Lib. Following
+ * a shutdown, TA_Initialize() must be called again for re-using TA-Lib.
+ *
+ * TA_Shutdown() should be called prior to exiting the application code.
+ */
+func Shutdown() error {
+	return nil
+}
+
+// pad expands a result that starts at begIdx into a slice as long as the
+// input, leaving the lookback period zeroed like the DLL wrappers did.
+func pad(n, begIdx int, out []float64) []float64 {
+	res := make([]float64, n)
+	if len(out) > 0 {
+		copy(res[begIdx:], out)
+	}
+	return res
+}
+
+// ZeroEpsilon is TA-Lib's TA_IS_ZERO threshold: divisors within it of zero
+// count as zero, leaving the output at its neutral value. Setting it to 0
+// compares against exact zeros instead, for symbols quoted in fractions of
+// a cent, whose price moves fall under 1e-8. It is read on every call, so
+// set it before running the functions rather than concurrently with them.
+var ZeroEpsilon = 1e-8
+
+// isZero and isZeroOrNeg are TA_IS_ZERO and TA_IS_ZERO_OR_NEG, holding for
+// an exact zero too when ZeroEpsilon is 0.
+func isZero(v float64) bool {
+	return v == 0 || -ZeroEpsilon < v && v < ZeroEpsilon
+}
+
+func isZeroOrNeg(v float64) bool {
+	return v <= 0 || v < ZeroEpsilon
+}
+
+func perToK(period int) float64 {
+	return 2.0 / (float64(period) + 1.0)
+}
+
+// trueRange is the greatest of the current range and the distances from the
+// previous close to the current high and low.
+func trueRange(high, low, prevClose float64) float64 {
+	tr := high - low
+	if v := math.Abs(prevClose - high); v > tr {
+		tr = v
+	}
+	if v := math.Abs(prevClose - low); v > tr {
+		tr = v
+	}
+	return tr
+}
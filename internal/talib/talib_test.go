@@ -0,0 +1,25 @@
+package talib
+
+import "testing"
+
+func TestZeroEpsilon(t *testing.T) {
+	// a coin quoted in millionths, rising by 1e-10 then 2e-10
+	in := make([]float64, 20)
+	for i := range in {
+		in[i] = 1e-6 + float64(i)*1e-10
+		if i%2 == 1 {
+			in[i] += 1e-10
+		}
+	}
+	if v := Rsi(in, 14)[19]; v != 0 {
+		t.Errorf("rsi %v, want 0 with moves under the epsilon", v)
+	}
+	defer func(eps float64) { ZeroEpsilon = eps }(ZeroEpsilon)
+	ZeroEpsilon = 0
+	if v := Rsi(in, 14)[19]; v != 100 {
+		t.Errorf("rsi %v, want 100 against exact zeros", v)
+	}
+	if !isZero(0) || isZero(1e-12) || !isZeroOrNeg(0) || isZeroOrNeg(1e-12) {
+		t.Error("exact zeros are not zero")
+	}
+}
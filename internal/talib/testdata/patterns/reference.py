@@ -0,0 +1,24 @@
+#!/usr/bin/env python3
+"""Replaces the outputs of the golden files given, or of all of them, by the
+patterns the TA-Lib C library finds on their candles. Needs the TA-Lib
+Python wrapper (pip install TA-Lib) over the C library."""
+import glob
+import json
+import os
+import sys
+
+import numpy
+import talib
+
+here = os.path.dirname(os.path.abspath(__file__))
+for path in sys.argv[1:] or sorted(glob.glob(os.path.join(here, "*.json"))):
+    with open(path) as f:
+        g = json.load(f)
+    g["generator"] = "TA-Lib " + talib.__ta_version__
+    prices = [numpy.array(g[k], dtype=float) for k in ("open", "high", "low", "close")]
+    for name in g["patterns"]:
+        out = getattr(talib, name)(*prices)
+        g["patterns"][name] = [[i, int(v)] for i, v in enumerate(out) if v != 0]
+    with open(path, "w") as f:
+        json.dump(g, f, separators=(",", ":"))
+        f.write("\n")
@@ -0,0 +1,92 @@
+package talib
+
+/*
+ * TA_TRANGE - True Range
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ */
+func Trange(inHigh []float64, inLow []float64, inClose []float64) (outReal []float64) {
+	outReal = make([]float64, len(inHigh))
+	for today := 1; today < len(inHigh); today++ {
+		outReal[today] = trueRange(inHigh[today], inLow[today], inClose[today-1])
+	}
+	return outReal
+}
+
+// atr returns Wilder's average true range from index period on, seeded with
+// the simple average of the first period true ranges.
+func atr(inHigh, inLow, inClose []float64, period int) []float64 {
+	n := len(inHigh)
+	out := make([]float64, n)
+	if n <= period {
+		return out
+	}
+	tr := Trange(inHigh, inLow, inClose)
+	p := float64(period)
+	prevATR := 0.0
+	for today := 1; today <= period; today++ {
+		prevATR += tr[today]
+	}
+	prevATR /= p
+	out[period] = prevATR
+	for today := period + 1; today < n; today++ {
+		prevATR = (prevATR*(p-1) + tr[today]) / p
+		out[today] = prevATR
+	}
+	return out
+}
+
+/*
+ * TA_ATR - Average True Range
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Atr(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	if optInTimePeriod <= 1 {
+		return Trange(inHigh, inLow, inClose)
+	}
+	return atr(inHigh, inLow, inClose, optInTimePeriod)
+}
+
+/*
+ * TA_NATR - Normalized Average True Range
+ *
+ * Input  = High, Low, Close
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInTimePeriod:(From 1 to 100000)
+ *    Number of period
+ *
+ *
+ */
+func Natr(inHigh []float64, inLow []float64, inClose []float64, optInTimePeriod int) (outReal []float64) {
+	if optInTimePeriod <= 1 {
+		outReal = Trange(inHigh, inLow, inClose)
+	} else {
+		outReal = atr(inHigh, inLow, inClose, optInTimePeriod)
+	}
+	start := optInTimePeriod
+	if start < 1 {
+		start = 1
+	}
+	for today := start; today < len(outReal); today++ {
+		if inClose[today] != 0 {
+			outReal[today] = (outReal[today] / inClose[today]) * 100.0
+		} else {
+			outReal[today] = 0
+		}
+	}
+	return outReal
+}
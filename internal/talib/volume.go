@@ -0,0 +1,92 @@
+package talib
+
+// adStep returns the money flow volume a single bar adds to the A/D line.
+func adStep(high, low, close, volume float64) float64 {
+	if r := high - low; r > 0 {
+		return (((close - low) - (high - close)) / r) * volume
+	}
+	return 0
+}
+
+/*
+ * TA_AD - Chaikin A/D Line
+ *
+ * Input  = High, Low, Close, Volume
+ * Output = double
+ *
+ */
+func Ad(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64) (outReal []float64) {
+	outReal = make([]float64, len(inHigh))
+	ad := 0.0
+	for i := range inHigh {
+		ad += adStep(inHigh[i], inLow[i], inClose[i], inVolume[i])
+		outReal[i] = ad
+	}
+	return outReal
+}
+
+/*
+ * TA_ADOSC - Chaikin A/D Oscillator
+ *
+ * Input  = High, Low, Close, Volume
+ * Output = double
+ *
+ * Optional Parameters
+ * -------------------
+ * optInFastPeriod:(From 2 to 100000)
+ *    Number of period for the fast MA
+ *
+ * optInSlowPeriod:(From 2 to 100000)
+ *    Number of period for the slow MA
+ *
+ *
+ */
+func AdOsc(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64, optInFastPeriod int, optInSlowPeriod int) (outReal []float64) {
+	n := len(inHigh)
+	outReal = make([]float64, n)
+	slowest := optInFastPeriod
+	if optInSlowPeriod > slowest {
+		slowest = optInSlowPeriod
+	}
+	lookback := slowest - 1
+	if n == 0 || n <= lookback {
+		return outReal
+	}
+	fastK, slowK := perToK(optInFastPeriod), perToK(optInSlowPeriod)
+	ad := adStep(inHigh[0], inLow[0], inClose[0], inVolume[0])
+	fastEMA, slowEMA := ad, ad
+	for today := 1; today < n; today++ {
+		ad += adStep(inHigh[today], inLow[today], inClose[today], inVolume[today])
+		fastEMA = fastK*ad + (1-fastK)*fastEMA
+		slowEMA = slowK*ad + (1-slowK)*slowEMA
+		if today >= lookback {
+			outReal[today] = fastEMA - slowEMA
+		}
+	}
+	return outReal
+}
+
+/*
+ * TA_OBV - On Balance Volume
+ *
+ * Input  = double, Volume
+ * Output = double
+ *
+ */
+func Obv(inReal []float64, inVolume []float64) (outReal []float64) {
+	outReal = make([]float64, len(inReal))
+	if len(inReal) == 0 {
+		return outReal
+	}
+	obv := inVolume[0]
+	outReal[0] = obv
+	for i := 1; i < len(inReal); i++ {
+		if inReal[i] > inReal[i-1] {
+			obv += inVolume[i]
+		} else if inReal[i] < inReal[i-1] {
+			obv -= inVolume[i]
+		}
+		outReal[i] = obv
+	}
+	return outReal
+}
@@ -0,0 +1,121 @@
+//go:build ignore
+// +build ignore
+
+// gen writes indicators.go, a typed wrapper around every indicator of the
+// library, from the metadata in ti_indicators.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"cryptoapi/internal/tulip"
+)
+
+// goNames spells out the indicator names that are several words glued
+// together; the rest are simply capitalized.
+var goNames = map[string]string{
+	"adosc":           "AdOsc",
+	"aroonosc":        "AroonOsc",
+	"avgprice":        "AvgPrice",
+	"crossany":        "CrossAny",
+	"crossover":       "CrossOver",
+	"edecay":          "EDecay",
+	"linregintercept": "LinRegIntercept",
+	"linregslope":     "LinRegSlope",
+	"linreg":          "LinReg",
+	"marketfi":        "MarketFi",
+	"medprice":        "MedPrice",
+	"stddev":          "StdDev",
+	"stderr":          "StdErr",
+	"stochrsi":        "StochRsi",
+	"todeg":           "ToDeg",
+	"torad":           "ToRad",
+	"typprice":        "TypPrice",
+	"ultosc":          "UltOsc",
+	"wcprice":         "WcPrice",
+}
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage tulip\n")
+	for _, ind := range tulip.Indicators() {
+		writeIndicator(&buf, ind)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("indicators.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeIndicator(buf *bytes.Buffer, ind *tulip.Indicator) {
+	name, ok := goNames[ind.Name]
+	if !ok {
+		name = camel(ind.Name)
+	}
+
+	var params, inputs, options, results, returns []string
+	for i, in := range ind.Inputs {
+		v := "in" + camel(in)
+		if countOf(ind.Inputs, in) > 1 {
+			v = fmt.Sprintf("%s%d", v, i)
+		}
+		params = append(params, v+" []float64")
+		inputs = append(inputs, v)
+	}
+	for _, opt := range ind.Options {
+		v := "optIn" + camel(opt)
+		if strings.Contains(opt, "period") {
+			params = append(params, v+" int")
+			options = append(options, "float64("+v+")")
+		} else {
+			params = append(params, v+" float64")
+			options = append(options, v)
+		}
+	}
+	for i, out := range ind.Outputs {
+		results = append(results, "out"+camel(out)+" []float64")
+		returns = append(returns, fmt.Sprintf("out[%d]", i))
+	}
+	nils := strings.Repeat("nil, ", len(ind.Outputs))
+
+	fmt.Fprintf(buf, "\n/*\n * %s - %s\n *\n", ind.Name, ind.FullName)
+	fmt.Fprintf(buf, " * Input  = %s\n", strings.Join(ind.Inputs, ", "))
+	if len(ind.Options) > 0 {
+		fmt.Fprintf(buf, " * Option = %s\n", strings.Join(ind.Options, ", "))
+	}
+	fmt.Fprintf(buf, " * Output = %s\n *\n */\n", strings.Join(ind.Outputs, ", "))
+	fmt.Fprintf(buf, "func %s(%s) (%s, err error) {\n", name, strings.Join(params, ", "), strings.Join(results, ", "))
+	fmt.Fprintf(buf, "\tout, err := Run(%q, [][]float64{%s}, []float64{%s})\n", ind.Name, strings.Join(inputs, ", "), strings.Join(options, ", "))
+	fmt.Fprintf(buf, "\tif err != nil {\n\t\treturn %serr\n\t}\n", nils)
+	fmt.Fprintf(buf, "\treturn %s, nil\n}\n", strings.Join(returns, ", "))
+}
+
+// camel turns "short period" or "bbands_lower" into ShortPeriod and
+// BbandsLower.
+func camel(s string) string {
+	var res strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '%'
+	}) {
+		res.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return res.String()
+}
+
+func countOf(values []string, v string) int {
+	n := 0
+	for _, value := range values {
+		if value == v {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,136 @@
+/*
+ * Builds the bundled Tulip Indicators sources as a single translation unit so
+ * cgo picks them up without a separate make step. A few indicators define
+ * helper macros with clashing names (and trend.h only defines INIT and FINAL
+ * when the including file has not), so those are undefined again right after
+ * the file that uses them.
+ */
+
+#include "../../indicatorsc/indicators_index.c"
+#include "../../indicatorsc/utils/buffer.c"
+
+#include "../../indicatorsc/indicators/abs.c"
+#include "../../indicatorsc/indicators/acos.c"
+#include "../../indicatorsc/indicators/ad.c"
+#include "../../indicatorsc/indicators/add.c"
+#include "../../indicatorsc/indicators/adosc.c"
+#include "../../indicatorsc/indicators/adx.c"
+#include "../../indicatorsc/indicators/adxr.c"
+#include "../../indicatorsc/indicators/ao.c"
+#include "../../indicatorsc/indicators/apo.c"
+#include "../../indicatorsc/indicators/aroon.c"
+#include "../../indicatorsc/indicators/aroonosc.c"
+#include "../../indicatorsc/indicators/asin.c"
+#include "../../indicatorsc/indicators/atan.c"
+#include "../../indicatorsc/indicators/atr.c"
+#include "../../indicatorsc/indicators/avgprice.c"
+#include "../../indicatorsc/indicators/bbands.c"
+#include "../../indicatorsc/indicators/bop.c"
+#include "../../indicatorsc/indicators/cci.c"
+#undef TYPPRICE
+#include "../../indicatorsc/indicators/ceil.c"
+#include "../../indicatorsc/indicators/cmo.c"
+#undef UPWARD
+#undef DOWNWARD
+#include "../../indicatorsc/indicators/cos.c"
+#include "../../indicatorsc/indicators/cosh.c"
+#include "../../indicatorsc/indicators/crossany.c"
+#include "../../indicatorsc/indicators/crossover.c"
+#include "../../indicatorsc/indicators/cvi.c"
+#include "../../indicatorsc/indicators/decay.c"
+#include "../../indicatorsc/indicators/dema.c"
+#include "../../indicatorsc/indicators/di.c"
+#include "../../indicatorsc/indicators/div.c"
+#include "../../indicatorsc/indicators/dm.c"
+#include "../../indicatorsc/indicators/dpo.c"
+#include "../../indicatorsc/indicators/dx.c"
+#include "../../indicatorsc/indicators/edecay.c"
+#include "../../indicatorsc/indicators/ema.c"
+#include "../../indicatorsc/indicators/emv.c"
+#include "../../indicatorsc/indicators/exp.c"
+#include "../../indicatorsc/indicators/fisher.c"
+#undef HL
+#include "../../indicatorsc/indicators/floor.c"
+#include "../../indicatorsc/indicators/fosc.c"
+#undef FINAL
+#undef INIT
+#undef POSTPROC
+#include "../../indicatorsc/indicators/hma.c"
+#include "../../indicatorsc/indicators/kama.c"
+#include "../../indicatorsc/indicators/kvo.c"
+#include "../../indicatorsc/indicators/lag.c"
+#include "../../indicatorsc/indicators/linreg.c"
+#undef FINAL
+#undef INIT
+#undef POSTPROC
+#include "../../indicatorsc/indicators/linregintercept.c"
+#undef FINAL
+#undef INIT
+#undef POSTPROC
+#include "../../indicatorsc/indicators/linregslope.c"
+#undef FINAL
+#undef INIT
+#undef POSTPROC
+#include "../../indicatorsc/indicators/ln.c"
+#include "../../indicatorsc/indicators/log10.c"
+#include "../../indicatorsc/indicators/macd.c"
+#include "../../indicatorsc/indicators/marketfi.c"
+#include "../../indicatorsc/indicators/mass.c"
+#include "../../indicatorsc/indicators/max.c"
+#include "../../indicatorsc/indicators/md.c"
+#include "../../indicatorsc/indicators/medprice.c"
+#include "../../indicatorsc/indicators/mfi.c"
+#undef TYPPRICE
+#include "../../indicatorsc/indicators/min.c"
+#include "../../indicatorsc/indicators/mom.c"
+#include "../../indicatorsc/indicators/msw.c"
+#include "../../indicatorsc/indicators/mul.c"
+#include "../../indicatorsc/indicators/natr.c"
+#include "../../indicatorsc/indicators/nvi.c"
+#include "../../indicatorsc/indicators/obv.c"
+#include "../../indicatorsc/indicators/ppo.c"
+#include "../../indicatorsc/indicators/psar.c"
+#include "../../indicatorsc/indicators/pvi.c"
+#include "../../indicatorsc/indicators/qstick.c"
+#include "../../indicatorsc/indicators/roc.c"
+#include "../../indicatorsc/indicators/rocr.c"
+#include "../../indicatorsc/indicators/round.c"
+#include "../../indicatorsc/indicators/rsi.c"
+#include "../../indicatorsc/indicators/sin.c"
+#include "../../indicatorsc/indicators/sinh.c"
+#include "../../indicatorsc/indicators/sma.c"
+#include "../../indicatorsc/indicators/sqrt.c"
+#include "../../indicatorsc/indicators/stddev.c"
+#include "../../indicatorsc/indicators/stderr.c"
+#include "../../indicatorsc/indicators/stoch.c"
+#include "../../indicatorsc/indicators/stochrsi.c"
+#include "../../indicatorsc/indicators/sub.c"
+#include "../../indicatorsc/indicators/sum.c"
+#include "../../indicatorsc/indicators/tan.c"
+#include "../../indicatorsc/indicators/tanh.c"
+#include "../../indicatorsc/indicators/tema.c"
+#include "../../indicatorsc/indicators/todeg.c"
+#include "../../indicatorsc/indicators/torad.c"
+#include "../../indicatorsc/indicators/tr.c"
+#include "../../indicatorsc/indicators/trima.c"
+#include "../../indicatorsc/indicators/trix.c"
+#include "../../indicatorsc/indicators/trunc.c"
+#include "../../indicatorsc/indicators/tsf.c"
+#undef FINAL
+#undef INIT
+#undef POSTPROC
+#include "../../indicatorsc/indicators/typprice.c"
+#include "../../indicatorsc/indicators/ultosc.c"
+#include "../../indicatorsc/indicators/var.c"
+#include "../../indicatorsc/indicators/vhf.c"
+#include "../../indicatorsc/indicators/vidya.c"
+#include "../../indicatorsc/indicators/volatility.c"
+#undef CHANGE
+#include "../../indicatorsc/indicators/vosc.c"
+#include "../../indicatorsc/indicators/vwma.c"
+#include "../../indicatorsc/indicators/wad.c"
+#include "../../indicatorsc/indicators/wcprice.c"
+#include "../../indicatorsc/indicators/wilders.c"
+#include "../../indicatorsc/indicators/willr.c"
+#include "../../indicatorsc/indicators/wma.c"
+#include "../../indicatorsc/indicators/zlema.c"
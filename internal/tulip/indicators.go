@@ -0,0 +1,1627 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package tulip
+
+/*
+ * abs - Vector Absolute Value
+ *
+ * Input  = real
+ * Output = abs
+ *
+ */
+func Abs(inReal []float64) (outAbs []float64, err error) {
+	out, err := Run("abs", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * acos - Vector Arccosine
+ *
+ * Input  = real
+ * Output = acos
+ *
+ */
+func Acos(inReal []float64) (outAcos []float64, err error) {
+	out, err := Run("acos", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ad - Accumulation/Distribution Line
+ *
+ * Input  = high, low, close, volume
+ * Output = ad
+ *
+ */
+func Ad(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64) (outAd []float64, err error) {
+	out, err := Run("ad", [][]float64{inHigh, inLow, inClose, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * add - Vector Addition
+ *
+ * Input  = real, real
+ * Output = add
+ *
+ */
+func Add(inReal0 []float64, inReal1 []float64) (outAdd []float64, err error) {
+	out, err := Run("add", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * adosc - Accumulation/Distribution Oscillator
+ *
+ * Input  = high, low, close, volume
+ * Option = short period, long period
+ * Output = adosc
+ *
+ */
+func AdOsc(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64, optInShortPeriod int, optInLongPeriod int) (outAdosc []float64, err error) {
+	out, err := Run("adosc", [][]float64{inHigh, inLow, inClose, inVolume}, []float64{float64(optInShortPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * adx - Average Directional Movement Index
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = dx
+ *
+ */
+func Adx(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outDx []float64, err error) {
+	out, err := Run("adx", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * adxr - Average Directional Movement Rating
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = dx
+ *
+ */
+func Adxr(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outDx []float64, err error) {
+	out, err := Run("adxr", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ao - Awesome Oscillator
+ *
+ * Input  = high, low
+ * Output = ao
+ *
+ */
+func Ao(inHigh []float64, inLow []float64) (outAo []float64, err error) {
+	out, err := Run("ao", [][]float64{inHigh, inLow}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * apo - Absolute Price Oscillator
+ *
+ * Input  = real
+ * Option = short period, long period
+ * Output = apo
+ *
+ */
+func Apo(inReal []float64, optInShortPeriod int, optInLongPeriod int) (outApo []float64, err error) {
+	out, err := Run("apo", [][]float64{inReal}, []float64{float64(optInShortPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * aroon - Aroon
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = aroon_down, aroon_up
+ *
+ */
+func Aroon(inHigh []float64, inLow []float64, optInPeriod int) (outAroonDown []float64, outAroonUp []float64, err error) {
+	out, err := Run("aroon", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * aroonosc - Aroon Oscillator
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = aroonosc
+ *
+ */
+func AroonOsc(inHigh []float64, inLow []float64, optInPeriod int) (outAroonosc []float64, err error) {
+	out, err := Run("aroonosc", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * asin - Vector Arcsine
+ *
+ * Input  = real
+ * Output = asin
+ *
+ */
+func Asin(inReal []float64) (outAsin []float64, err error) {
+	out, err := Run("asin", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * atan - Vector Arctangent
+ *
+ * Input  = real
+ * Output = atan
+ *
+ */
+func Atan(inReal []float64) (outAtan []float64, err error) {
+	out, err := Run("atan", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * atr - Average True Range
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = atr
+ *
+ */
+func Atr(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outAtr []float64, err error) {
+	out, err := Run("atr", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * avgprice - Average Price
+ *
+ * Input  = open, high, low, close
+ * Output = avgprice
+ *
+ */
+func AvgPrice(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outAvgprice []float64, err error) {
+	out, err := Run("avgprice", [][]float64{inOpen, inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * bbands - Bollinger Bands
+ *
+ * Input  = real
+ * Option = period, stddev
+ * Output = bbands_lower, bbands_middle, bbands_upper
+ *
+ */
+func Bbands(inReal []float64, optInPeriod int, optInStddev float64) (outBbandsLower []float64, outBbandsMiddle []float64, outBbandsUpper []float64, err error) {
+	out, err := Run("bbands", [][]float64{inReal}, []float64{float64(optInPeriod), optInStddev})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return out[0], out[1], out[2], nil
+}
+
+/*
+ * bop - Balance of Power
+ *
+ * Input  = open, high, low, close
+ * Output = bop
+ *
+ */
+func Bop(inOpen []float64, inHigh []float64, inLow []float64, inClose []float64) (outBop []float64, err error) {
+	out, err := Run("bop", [][]float64{inOpen, inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * cci - Commodity Channel Index
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = cci
+ *
+ */
+func Cci(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outCci []float64, err error) {
+	out, err := Run("cci", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ceil - Vector Ceiling
+ *
+ * Input  = real
+ * Output = ceil
+ *
+ */
+func Ceil(inReal []float64) (outCeil []float64, err error) {
+	out, err := Run("ceil", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * cmo - Chande Momentum Oscillator
+ *
+ * Input  = real
+ * Option = period
+ * Output = cmo
+ *
+ */
+func Cmo(inReal []float64, optInPeriod int) (outCmo []float64, err error) {
+	out, err := Run("cmo", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * cos - Vector Cosine
+ *
+ * Input  = real
+ * Output = cos
+ *
+ */
+func Cos(inReal []float64) (outCos []float64, err error) {
+	out, err := Run("cos", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * cosh - Vector Hyperbolic Cosine
+ *
+ * Input  = real
+ * Output = cosh
+ *
+ */
+func Cosh(inReal []float64) (outCosh []float64, err error) {
+	out, err := Run("cosh", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * crossany - Crossany
+ *
+ * Input  = real, real
+ * Output = crossany
+ *
+ */
+func CrossAny(inReal0 []float64, inReal1 []float64) (outCrossany []float64, err error) {
+	out, err := Run("crossany", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * crossover - Crossover
+ *
+ * Input  = real, real
+ * Output = crossover
+ *
+ */
+func CrossOver(inReal0 []float64, inReal1 []float64) (outCrossover []float64, err error) {
+	out, err := Run("crossover", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * cvi - Chaikins Volatility
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = cvi
+ *
+ */
+func Cvi(inHigh []float64, inLow []float64, optInPeriod int) (outCvi []float64, err error) {
+	out, err := Run("cvi", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * decay - Linear Decay
+ *
+ * Input  = real
+ * Option = period
+ * Output = decay
+ *
+ */
+func Decay(inReal []float64, optInPeriod int) (outDecay []float64, err error) {
+	out, err := Run("decay", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * dema - Double Exponential Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = dema
+ *
+ */
+func Dema(inReal []float64, optInPeriod int) (outDema []float64, err error) {
+	out, err := Run("dema", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * di - Directional Indicator
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = plus_di, minus_di
+ *
+ */
+func Di(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outPlusDi []float64, outMinusDi []float64, err error) {
+	out, err := Run("di", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * div - Vector Division
+ *
+ * Input  = real, real
+ * Output = div
+ *
+ */
+func Div(inReal0 []float64, inReal1 []float64) (outDiv []float64, err error) {
+	out, err := Run("div", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * dm - Directional Movement
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = plus_dm, minus_dm
+ *
+ */
+func Dm(inHigh []float64, inLow []float64, optInPeriod int) (outPlusDm []float64, outMinusDm []float64, err error) {
+	out, err := Run("dm", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * dpo - Detrended Price Oscillator
+ *
+ * Input  = real
+ * Option = period
+ * Output = dpo
+ *
+ */
+func Dpo(inReal []float64, optInPeriod int) (outDpo []float64, err error) {
+	out, err := Run("dpo", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * dx - Directional Movement Index
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = dx
+ *
+ */
+func Dx(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outDx []float64, err error) {
+	out, err := Run("dx", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * edecay - Exponential Decay
+ *
+ * Input  = real
+ * Option = period
+ * Output = edecay
+ *
+ */
+func EDecay(inReal []float64, optInPeriod int) (outEdecay []float64, err error) {
+	out, err := Run("edecay", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ema - Exponential Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = ema
+ *
+ */
+func Ema(inReal []float64, optInPeriod int) (outEma []float64, err error) {
+	out, err := Run("ema", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * emv - Ease of Movement
+ *
+ * Input  = high, low, volume
+ * Output = emv
+ *
+ */
+func Emv(inHigh []float64, inLow []float64, inVolume []float64) (outEmv []float64, err error) {
+	out, err := Run("emv", [][]float64{inHigh, inLow, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * exp - Vector Exponential
+ *
+ * Input  = real
+ * Output = exp
+ *
+ */
+func Exp(inReal []float64) (outExp []float64, err error) {
+	out, err := Run("exp", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * fisher - Fisher Transform
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = fisher, fisher_signal
+ *
+ */
+func Fisher(inHigh []float64, inLow []float64, optInPeriod int) (outFisher []float64, outFisherSignal []float64, err error) {
+	out, err := Run("fisher", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * floor - Vector Floor
+ *
+ * Input  = real
+ * Output = floor
+ *
+ */
+func Floor(inReal []float64) (outFloor []float64, err error) {
+	out, err := Run("floor", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * fosc - Forecast Oscillator
+ *
+ * Input  = real
+ * Option = period
+ * Output = fosc
+ *
+ */
+func Fosc(inReal []float64, optInPeriod int) (outFosc []float64, err error) {
+	out, err := Run("fosc", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * hma - Hull Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = hma
+ *
+ */
+func Hma(inReal []float64, optInPeriod int) (outHma []float64, err error) {
+	out, err := Run("hma", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * kama - Kaufman Adaptive Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = kama
+ *
+ */
+func Kama(inReal []float64, optInPeriod int) (outKama []float64, err error) {
+	out, err := Run("kama", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * kvo - Klinger Volume Oscillator
+ *
+ * Input  = high, low, close, volume
+ * Option = short period, long period
+ * Output = kvo
+ *
+ */
+func Kvo(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64, optInShortPeriod int, optInLongPeriod int) (outKvo []float64, err error) {
+	out, err := Run("kvo", [][]float64{inHigh, inLow, inClose, inVolume}, []float64{float64(optInShortPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * lag - Lag
+ *
+ * Input  = real
+ * Option = period
+ * Output = lag
+ *
+ */
+func Lag(inReal []float64, optInPeriod int) (outLag []float64, err error) {
+	out, err := Run("lag", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * linreg - Linear Regression
+ *
+ * Input  = real
+ * Option = period
+ * Output = linreg
+ *
+ */
+func LinReg(inReal []float64, optInPeriod int) (outLinreg []float64, err error) {
+	out, err := Run("linreg", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * linregintercept - Linear Regression Intercept
+ *
+ * Input  = real
+ * Option = period
+ * Output = linregintercept
+ *
+ */
+func LinRegIntercept(inReal []float64, optInPeriod int) (outLinregintercept []float64, err error) {
+	out, err := Run("linregintercept", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * linregslope - Linear Regression Slope
+ *
+ * Input  = real
+ * Option = period
+ * Output = linregslope
+ *
+ */
+func LinRegSlope(inReal []float64, optInPeriod int) (outLinregslope []float64, err error) {
+	out, err := Run("linregslope", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ln - Vector Natural Log
+ *
+ * Input  = real
+ * Output = ln
+ *
+ */
+func Ln(inReal []float64) (outLn []float64, err error) {
+	out, err := Run("ln", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * log10 - Vector Base-10 Log
+ *
+ * Input  = real
+ * Output = log10
+ *
+ */
+func Log10(inReal []float64) (outLog10 []float64, err error) {
+	out, err := Run("log10", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * macd - Moving Average Convergence/Divergence
+ *
+ * Input  = real
+ * Option = short period, long period, signal period
+ * Output = macd, macd_signal, macd_histogram
+ *
+ */
+func Macd(inReal []float64, optInShortPeriod int, optInLongPeriod int, optInSignalPeriod int) (outMacd []float64, outMacdSignal []float64, outMacdHistogram []float64, err error) {
+	out, err := Run("macd", [][]float64{inReal}, []float64{float64(optInShortPeriod), float64(optInLongPeriod), float64(optInSignalPeriod)})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return out[0], out[1], out[2], nil
+}
+
+/*
+ * marketfi - Market Facilitation Index
+ *
+ * Input  = high, low, volume
+ * Output = marketfi
+ *
+ */
+func MarketFi(inHigh []float64, inLow []float64, inVolume []float64) (outMarketfi []float64, err error) {
+	out, err := Run("marketfi", [][]float64{inHigh, inLow, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * mass - Mass Index
+ *
+ * Input  = high, low
+ * Option = period
+ * Output = mass
+ *
+ */
+func Mass(inHigh []float64, inLow []float64, optInPeriod int) (outMass []float64, err error) {
+	out, err := Run("mass", [][]float64{inHigh, inLow}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * max - Maximum In Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = max
+ *
+ */
+func Max(inReal []float64, optInPeriod int) (outMax []float64, err error) {
+	out, err := Run("max", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * md - Mean Deviation Over Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = md
+ *
+ */
+func Md(inReal []float64, optInPeriod int) (outMd []float64, err error) {
+	out, err := Run("md", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * medprice - Median Price
+ *
+ * Input  = high, low
+ * Output = medprice
+ *
+ */
+func MedPrice(inHigh []float64, inLow []float64) (outMedprice []float64, err error) {
+	out, err := Run("medprice", [][]float64{inHigh, inLow}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * mfi - Money Flow Index
+ *
+ * Input  = high, low, close, volume
+ * Option = period
+ * Output = mfi
+ *
+ */
+func Mfi(inHigh []float64, inLow []float64, inClose []float64, inVolume []float64, optInPeriod int) (outMfi []float64, err error) {
+	out, err := Run("mfi", [][]float64{inHigh, inLow, inClose, inVolume}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * min - Minimum In Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = min
+ *
+ */
+func Min(inReal []float64, optInPeriod int) (outMin []float64, err error) {
+	out, err := Run("min", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * mom - Momentum
+ *
+ * Input  = real
+ * Option = period
+ * Output = mom
+ *
+ */
+func Mom(inReal []float64, optInPeriod int) (outMom []float64, err error) {
+	out, err := Run("mom", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * msw - Mesa Sine Wave
+ *
+ * Input  = real
+ * Option = period
+ * Output = msw_sine, msw_lead
+ *
+ */
+func Msw(inReal []float64, optInPeriod int) (outMswSine []float64, outMswLead []float64, err error) {
+	out, err := Run("msw", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * mul - Vector Multiplication
+ *
+ * Input  = real, real
+ * Output = mul
+ *
+ */
+func Mul(inReal0 []float64, inReal1 []float64) (outMul []float64, err error) {
+	out, err := Run("mul", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * natr - Normalized Average True Range
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = natr
+ *
+ */
+func Natr(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outNatr []float64, err error) {
+	out, err := Run("natr", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * nvi - Negative Volume Index
+ *
+ * Input  = close, volume
+ * Output = nvi
+ *
+ */
+func Nvi(inClose []float64, inVolume []float64) (outNvi []float64, err error) {
+	out, err := Run("nvi", [][]float64{inClose, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * obv - On Balance Volume
+ *
+ * Input  = close, volume
+ * Output = obv
+ *
+ */
+func Obv(inClose []float64, inVolume []float64) (outObv []float64, err error) {
+	out, err := Run("obv", [][]float64{inClose, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ppo - Percentage Price Oscillator
+ *
+ * Input  = real
+ * Option = short period, long period
+ * Output = ppo
+ *
+ */
+func Ppo(inReal []float64, optInShortPeriod int, optInLongPeriod int) (outPpo []float64, err error) {
+	out, err := Run("ppo", [][]float64{inReal}, []float64{float64(optInShortPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * psar - Parabolic SAR
+ *
+ * Input  = high, low
+ * Option = acceleration factor step, acceleration factor maximum
+ * Output = psar
+ *
+ */
+func Psar(inHigh []float64, inLow []float64, optInAccelerationFactorStep float64, optInAccelerationFactorMaximum float64) (outPsar []float64, err error) {
+	out, err := Run("psar", [][]float64{inHigh, inLow}, []float64{optInAccelerationFactorStep, optInAccelerationFactorMaximum})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * pvi - Positive Volume Index
+ *
+ * Input  = close, volume
+ * Output = pvi
+ *
+ */
+func Pvi(inClose []float64, inVolume []float64) (outPvi []float64, err error) {
+	out, err := Run("pvi", [][]float64{inClose, inVolume}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * qstick - Qstick
+ *
+ * Input  = open, close
+ * Option = period
+ * Output = qstick
+ *
+ */
+func Qstick(inOpen []float64, inClose []float64, optInPeriod int) (outQstick []float64, err error) {
+	out, err := Run("qstick", [][]float64{inOpen, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * roc - Rate of Change
+ *
+ * Input  = real
+ * Option = period
+ * Output = roc
+ *
+ */
+func Roc(inReal []float64, optInPeriod int) (outRoc []float64, err error) {
+	out, err := Run("roc", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * rocr - Rate of Change Ratio
+ *
+ * Input  = real
+ * Option = period
+ * Output = rocr
+ *
+ */
+func Rocr(inReal []float64, optInPeriod int) (outRocr []float64, err error) {
+	out, err := Run("rocr", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * round - Vector Round
+ *
+ * Input  = real
+ * Output = round
+ *
+ */
+func Round(inReal []float64) (outRound []float64, err error) {
+	out, err := Run("round", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * rsi - Relative Strength Index
+ *
+ * Input  = real
+ * Option = period
+ * Output = rsi
+ *
+ */
+func Rsi(inReal []float64, optInPeriod int) (outRsi []float64, err error) {
+	out, err := Run("rsi", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sin - Vector Sine
+ *
+ * Input  = real
+ * Output = sin
+ *
+ */
+func Sin(inReal []float64) (outSin []float64, err error) {
+	out, err := Run("sin", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sinh - Vector Hyperbolic Sine
+ *
+ * Input  = real
+ * Output = sinh
+ *
+ */
+func Sinh(inReal []float64) (outSinh []float64, err error) {
+	out, err := Run("sinh", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sma - Simple Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = sma
+ *
+ */
+func Sma(inReal []float64, optInPeriod int) (outSma []float64, err error) {
+	out, err := Run("sma", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sqrt - Vector Square Root
+ *
+ * Input  = real
+ * Output = sqrt
+ *
+ */
+func Sqrt(inReal []float64) (outSqrt []float64, err error) {
+	out, err := Run("sqrt", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * stddev - Standard Deviation Over Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = stddev
+ *
+ */
+func StdDev(inReal []float64, optInPeriod int) (outStddev []float64, err error) {
+	out, err := Run("stddev", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * stderr - Standard Error Over Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = stderr
+ *
+ */
+func StdErr(inReal []float64, optInPeriod int) (outStderr []float64, err error) {
+	out, err := Run("stderr", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * stoch - Stochastic Oscillator
+ *
+ * Input  = high, low, close
+ * Option = %k period, %k slowing period, %d period
+ * Output = stoch_k, stoch_d
+ *
+ */
+func Stoch(inHigh []float64, inLow []float64, inClose []float64, optInKPeriod int, optInKSlowingPeriod int, optInDPeriod int) (outStochK []float64, outStochD []float64, err error) {
+	out, err := Run("stoch", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInKPeriod), float64(optInKSlowingPeriod), float64(optInDPeriod)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[0], out[1], nil
+}
+
+/*
+ * stochrsi - Stochastic RSI
+ *
+ * Input  = real
+ * Option = period
+ * Output = stochrsi
+ *
+ */
+func StochRsi(inReal []float64, optInPeriod int) (outStochrsi []float64, err error) {
+	out, err := Run("stochrsi", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sub - Vector Subtraction
+ *
+ * Input  = real, real
+ * Output = sub
+ *
+ */
+func Sub(inReal0 []float64, inReal1 []float64) (outSub []float64, err error) {
+	out, err := Run("sub", [][]float64{inReal0, inReal1}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * sum - Sum Over Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = sum
+ *
+ */
+func Sum(inReal []float64, optInPeriod int) (outSum []float64, err error) {
+	out, err := Run("sum", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * tan - Vector Tangent
+ *
+ * Input  = real
+ * Output = tan
+ *
+ */
+func Tan(inReal []float64) (outTan []float64, err error) {
+	out, err := Run("tan", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * tanh - Vector Hyperbolic Tangent
+ *
+ * Input  = real
+ * Output = tanh
+ *
+ */
+func Tanh(inReal []float64) (outTanh []float64, err error) {
+	out, err := Run("tanh", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * tema - Triple Exponential Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = tema
+ *
+ */
+func Tema(inReal []float64, optInPeriod int) (outTema []float64, err error) {
+	out, err := Run("tema", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * todeg - Vector Degree Conversion
+ *
+ * Input  = real
+ * Output = degrees
+ *
+ */
+func ToDeg(inReal []float64) (outDegrees []float64, err error) {
+	out, err := Run("todeg", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * torad - Vector Radian Conversion
+ *
+ * Input  = real
+ * Output = radians
+ *
+ */
+func ToRad(inReal []float64) (outRadians []float64, err error) {
+	out, err := Run("torad", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * tr - True Range
+ *
+ * Input  = high, low, close
+ * Output = tr
+ *
+ */
+func Tr(inHigh []float64, inLow []float64, inClose []float64) (outTr []float64, err error) {
+	out, err := Run("tr", [][]float64{inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * trima - Triangular Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = trima
+ *
+ */
+func Trima(inReal []float64, optInPeriod int) (outTrima []float64, err error) {
+	out, err := Run("trima", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * trix - Trix
+ *
+ * Input  = real
+ * Option = period
+ * Output = trix
+ *
+ */
+func Trix(inReal []float64, optInPeriod int) (outTrix []float64, err error) {
+	out, err := Run("trix", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * trunc - Vector Truncate
+ *
+ * Input  = real
+ * Output = trunc
+ *
+ */
+func Trunc(inReal []float64) (outTrunc []float64, err error) {
+	out, err := Run("trunc", [][]float64{inReal}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * tsf - Time Series Forecast
+ *
+ * Input  = real
+ * Option = period
+ * Output = tsf
+ *
+ */
+func Tsf(inReal []float64, optInPeriod int) (outTsf []float64, err error) {
+	out, err := Run("tsf", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * typprice - Typical Price
+ *
+ * Input  = high, low, close
+ * Output = typprice
+ *
+ */
+func TypPrice(inHigh []float64, inLow []float64, inClose []float64) (outTypprice []float64, err error) {
+	out, err := Run("typprice", [][]float64{inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * ultosc - Ultimate Oscillator
+ *
+ * Input  = high, low, close
+ * Option = short period, medium period, long period
+ * Output = ultosc
+ *
+ */
+func UltOsc(inHigh []float64, inLow []float64, inClose []float64, optInShortPeriod int, optInMediumPeriod int, optInLongPeriod int) (outUltosc []float64, err error) {
+	out, err := Run("ultosc", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInShortPeriod), float64(optInMediumPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * var - Variance Over Period
+ *
+ * Input  = real
+ * Option = period
+ * Output = var
+ *
+ */
+func Var(inReal []float64, optInPeriod int) (outVar []float64, err error) {
+	out, err := Run("var", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * vhf - Vertical Horizontal Filter
+ *
+ * Input  = real
+ * Option = period
+ * Output = vhf
+ *
+ */
+func Vhf(inReal []float64, optInPeriod int) (outVhf []float64, err error) {
+	out, err := Run("vhf", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * vidya - Variable Index Dynamic Average
+ *
+ * Input  = real
+ * Option = short period, long period, alpha
+ * Output = vidya
+ *
+ */
+func Vidya(inReal []float64, optInShortPeriod int, optInLongPeriod int, optInAlpha float64) (outVidya []float64, err error) {
+	out, err := Run("vidya", [][]float64{inReal}, []float64{float64(optInShortPeriod), float64(optInLongPeriod), optInAlpha})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * volatility - Annualized Historical Volatility
+ *
+ * Input  = real
+ * Option = period
+ * Output = volatility
+ *
+ */
+func Volatility(inReal []float64, optInPeriod int) (outVolatility []float64, err error) {
+	out, err := Run("volatility", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * vosc - Volume Oscillator
+ *
+ * Input  = volume
+ * Option = short period, long period
+ * Output = vosc
+ *
+ */
+func Vosc(inVolume []float64, optInShortPeriod int, optInLongPeriod int) (outVosc []float64, err error) {
+	out, err := Run("vosc", [][]float64{inVolume}, []float64{float64(optInShortPeriod), float64(optInLongPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * vwma - Volume Weighted Moving Average
+ *
+ * Input  = close, volume
+ * Option = period
+ * Output = vwma
+ *
+ */
+func Vwma(inClose []float64, inVolume []float64, optInPeriod int) (outVwma []float64, err error) {
+	out, err := Run("vwma", [][]float64{inClose, inVolume}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * wad - Williams Accumulation/Distribution
+ *
+ * Input  = high, low, close
+ * Output = wad
+ *
+ */
+func Wad(inHigh []float64, inLow []float64, inClose []float64) (outWad []float64, err error) {
+	out, err := Run("wad", [][]float64{inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * wcprice - Weighted Close Price
+ *
+ * Input  = high, low, close
+ * Output = wcprice
+ *
+ */
+func WcPrice(inHigh []float64, inLow []float64, inClose []float64) (outWcprice []float64, err error) {
+	out, err := Run("wcprice", [][]float64{inHigh, inLow, inClose}, []float64{})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * wilders - Wilders Smoothing
+ *
+ * Input  = real
+ * Option = period
+ * Output = wilders
+ *
+ */
+func Wilders(inReal []float64, optInPeriod int) (outWilders []float64, err error) {
+	out, err := Run("wilders", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * willr - Williams %R
+ *
+ * Input  = high, low, close
+ * Option = period
+ * Output = willr
+ *
+ */
+func Willr(inHigh []float64, inLow []float64, inClose []float64, optInPeriod int) (outWillr []float64, err error) {
+	out, err := Run("willr", [][]float64{inHigh, inLow, inClose}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * wma - Weighted Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = wma
+ *
+ */
+func Wma(inReal []float64, optInPeriod int) (outWma []float64, err error) {
+	out, err := Run("wma", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
+
+/*
+ * zlema - Zero-Lag Exponential Moving Average
+ *
+ * Input  = real
+ * Option = period
+ * Output = zlema
+ *
+ */
+func Zlema(inReal []float64, optInPeriod int) (outZlema []float64, err error) {
+	out, err := Run("zlema", [][]float64{inReal}, []float64{float64(optInPeriod)})
+	if err != nil {
+		return nil, err
+	}
+	return out[0], nil
+}
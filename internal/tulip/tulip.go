@@ -0,0 +1,246 @@
+// Package tulip exposes the Tulip Indicators library bundled in indicatorsc.
+//
+// Every indicator can be looked up by name and run through the metadata in
+// ti_indicators, and indicators.go wraps each one in a typed function. Like
+// the talib package, outputs are as long as the inputs with the lookback
+// period left zeroed, so indexes line up with the candles they came from.
+package tulip
+
+//go:generate go run gen.go
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../indicatorsc
+#cgo LDFLAGS: -lm
+#include <stdlib.h>
+#include "indicators.h"
+
+static const ti_indicator_info *ti_indicator_at(int i) {
+	return &ti_indicators[i];
+}
+
+static int ti_start(const ti_indicator_info *info, TI_REAL const *options) {
+	return info->start(options);
+}
+
+static int ti_run(const ti_indicator_info *info, int size, TI_REAL const *const *inputs, TI_REAL const *options, TI_REAL *const *outputs) {
+	return info->indicator(size, inputs, options, outputs);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// Type groups indicators the way ti_indicator_info.type does.
+type Type int
+
+const (
+	Overlay     Type = C.TI_TYPE_OVERLAY
+	Oscillator  Type = C.TI_TYPE_INDICATOR
+	Math        Type = C.TI_TYPE_MATH
+	Simple      Type = C.TI_TYPE_SIMPLE
+	Comparative Type = C.TI_TYPE_COMPARATIVE
+)
+
+func (t Type) String() string {
+	switch t {
+	case Overlay:
+		return "overlay"
+	case Oscillator:
+		return "indicator"
+	case Math:
+		return "math"
+	case Simple:
+		return "simple"
+	case Comparative:
+		return "comparative"
+	}
+	return fmt.Sprintf("type(%d)", int(t))
+}
+
+// Version is the version of the bundled library.
+const Version = C.TI_VERSION
+
+// ErrInvalidOption is returned when the library rejects the options passed
+// to an indicator.
+var ErrInvalidOption = errors.New("invalid indicator option")
+
+// Indicator describes one entry of ti_indicators.
+type Indicator struct {
+	Name     string
+	FullName string
+	Type     Type
+	Inputs   []string
+	Options  []string
+	Outputs  []string
+
+	info *C.ti_indicator_info
+}
+
+var (
+	indicators []*Indicator
+	byName     = make(map[string]*Indicator)
+)
+
+func init() {
+	for i := 0; i < C.TI_INDICATOR_COUNT; i++ {
+		info := C.ti_indicator_at(C.int(i))
+		ind := &Indicator{
+			Name:     C.GoString(info.name),
+			FullName: C.GoString(info.full_name),
+			Type:     Type(info._type),
+			Inputs:   goStrings(info.input_names[:info.inputs]),
+			Options:  goStrings(info.option_names[:info.options]),
+			Outputs:  goStrings(info.output_names[:info.outputs]),
+			info:     info,
+		}
+		indicators = append(indicators, ind)
+		byName[ind.Name] = ind
+	}
+}
+
+func goStrings(names []*C.char) []string {
+	res := make([]string, len(names))
+	for i, name := range names {
+		res[i] = C.GoString(name)
+	}
+	return res
+}
+
+// Indicators lists every indicator in the library, sorted by name.
+func Indicators() []*Indicator {
+	res := make([]*Indicator, len(indicators))
+	copy(res, indicators)
+	return res
+}
+
+// Find looks an indicator up by its short name, e.g. "vidya".
+func Find(name string) (*Indicator, error) {
+	ind, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown indicator %q", name)
+	}
+	return ind, nil
+}
+
+// Run looks the indicator up by name and runs it.
+func Run(name string, inputs [][]float64, options []float64) ([][]float64, error) {
+	ind, err := Find(name)
+	if err != nil {
+		return nil, err
+	}
+	return ind.Run(inputs, options)
+}
+
+// Start returns the number of leading candles the indicator needs before
+// its first output for the given options, or -1 if they are invalid.
+func (ind *Indicator) Start(options []float64) int {
+	if len(options) != len(ind.Options) {
+		return -1
+	}
+	opts := cDoubles(len(options))
+	defer C.free(unsafe.Pointer(opts))
+	fill(opts, options)
+	return int(C.ti_start(ind.info, opts))
+}
+
+// Run computes the indicator over inputs, which must all have the same
+// length. It returns one slice per output, each as long as the inputs.
+func (ind *Indicator) Run(inputs [][]float64, options []float64) ([][]float64, error) {
+	if len(inputs) != len(ind.Inputs) {
+		return nil, fmt.Errorf("%s takes %d inputs, got %d", ind.Name, len(ind.Inputs), len(inputs))
+	}
+	if len(options) != len(ind.Options) {
+		return nil, fmt.Errorf("%s takes %d options, got %d", ind.Name, len(ind.Options), len(options))
+	}
+	size := 0
+	if len(inputs) > 0 {
+		size = len(inputs[0])
+	}
+	for i, in := range inputs {
+		if len(in) != size {
+			return nil, fmt.Errorf("%s input %s has %d values, expected %d", ind.Name, ind.Inputs[i], len(in), size)
+		}
+	}
+	start := ind.Start(options)
+	if start < 0 {
+		return nil, ErrInvalidOption
+	}
+
+	outputs := make([][]float64, len(ind.Outputs))
+	for i := range outputs {
+		outputs[i] = make([]float64, size)
+	}
+	if start >= size {
+		return outputs, nil
+	}
+	outSize := size - start
+
+	// The library reads and writes through arrays of pointers, which cgo does
+	// not allow to point into Go memory, so everything is staged in C memory.
+	opts := cDoubles(len(options))
+	defer C.free(unsafe.Pointer(opts))
+	fill(opts, options)
+
+	ins := cPointers(len(inputs))
+	defer C.free(unsafe.Pointer(ins))
+	inPtrs := pointers(ins, len(inputs))
+	for i, in := range inputs {
+		inPtrs[i] = cDoubles(size)
+		defer C.free(unsafe.Pointer(inPtrs[i]))
+		fill(inPtrs[i], in)
+	}
+
+	outs := cPointers(len(outputs))
+	defer C.free(unsafe.Pointer(outs))
+	outPtrs := pointers(outs, len(outputs))
+	for i := range outputs {
+		outPtrs[i] = cDoubles(outSize)
+		defer C.free(unsafe.Pointer(outPtrs[i]))
+	}
+
+	if C.ti_run(ind.info, C.int(size), ins, opts, outs) != C.TI_OKAY {
+		return nil, ErrInvalidOption
+	}
+	for i, out := range outputs {
+		for j, v := range doubles(outPtrs[i], outSize) {
+			out[start+j] = float64(v)
+		}
+	}
+	return outputs, nil
+}
+
+// maxLen bounds the array types used to view C memory as Go slices.
+const maxLen = 1 << 28
+
+func cDoubles(n int) *C.double {
+	if n == 0 {
+		n = 1
+	}
+	return (*C.double)(C.malloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.double(0)))))
+}
+
+func cPointers(n int) **C.double {
+	if n == 0 {
+		n = 1
+	}
+	return (**C.double)(C.malloc(C.size_t(n) * C.size_t(unsafe.Sizeof((*C.double)(nil)))))
+}
+
+func doubles(p *C.double, n int) []C.double {
+	return (*[maxLen]C.double)(unsafe.Pointer(p))[:n:n]
+}
+
+func pointers(p **C.double, n int) []*C.double {
+	return (*[maxLen]*C.double)(unsafe.Pointer(p))[:n:n]
+}
+
+func fill(p *C.double, values []float64) {
+	dst := doubles(p, len(values))
+	for i, v := range values {
+		dst[i] = C.double(v)
+	}
+}
@@ -0,0 +1,121 @@
+package tulip
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	ind, err := Find("sma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ind.FullName != "Simple Moving Average" || ind.Type != Overlay ||
+		!reflect.DeepEqual(ind.Inputs, []string{"real"}) ||
+		!reflect.DeepEqual(ind.Options, []string{"period"}) ||
+		!reflect.DeepEqual(ind.Outputs, []string{"sma"}) {
+		t.Errorf("got %+v", ind)
+	}
+	if _, err := Find("SMA"); err == nil {
+		t.Error("found an indicator by its upper case name")
+	}
+
+	all := Indicators()
+	if len(all) != len(byName) {
+		t.Errorf("%d indicators, %d names", len(all), len(byName))
+	}
+	if !sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Name < all[j].Name }) {
+		t.Error("indicators not sorted by name")
+	}
+	all[0] = nil
+	if Indicators()[0] == nil {
+		t.Error("Indicators returned the list itself")
+	}
+}
+
+func TestStart(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		options []float64
+		want    int
+	}{
+		{"sma", []float64{5}, 4},
+		{"macd", []float64{12, 26, 9}, 25},
+		{"bbands", []float64{20, 2}, 19},
+		{"sma", nil, -1},
+		{"sma", []float64{5, 1}, -1},
+	} {
+		ind, err := Find(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ind.Start(tt.options); got != tt.want {
+			t.Errorf("%s%v: start %d, want %d", tt.name, tt.options, got, tt.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	in := []float64{1, 2, 3, 4, 5}
+	for _, tt := range []struct {
+		name    string
+		inputs  [][]float64
+		options []float64
+	}{
+		{"nope", [][]float64{in}, []float64{3}},
+		{"sma", nil, []float64{3}},
+		{"sma", [][]float64{in}, nil},
+		{"sma", [][]float64{in}, []float64{3, 3}},
+		{"atr", [][]float64{in, in, in[1:]}, []float64{3}},
+	} {
+		if _, err := Run(tt.name, tt.inputs, tt.options); err == nil || err == ErrInvalidOption {
+			t.Errorf("%s with %d inputs and options %v: got %v", tt.name, len(tt.inputs), tt.options, err)
+		}
+	}
+	// a period the library turns down
+	if _, err := Run("sma", [][]float64{in}, []float64{0}); err != ErrInvalidOption {
+		t.Errorf("period 0: got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	in := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	out, err := Sma(in, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0, 0, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(out, want) {
+		t.Errorf("sma got %v, want %v", out, want)
+	}
+
+	// every output lines up with the inputs
+	lower, middle, upper, err := Bbands(in, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := 2 * math.Sqrt(2.0/3)
+	for i := range in {
+		want := [3]float64{}
+		if i >= 2 {
+			want = [3]float64{in[i] - 1 - dev, in[i] - 1, in[i] - 1 + dev}
+		}
+		got := [3]float64{lower[i], middle[i], upper[i]}
+		for j := range got {
+			if math.Abs(got[j]-want[j]) > 1e-9 {
+				t.Errorf("bbands %d: got %v, want %v", i, got, want)
+				break
+			}
+		}
+	}
+
+	// inputs shorter than the lookback give zeroed outputs of their length
+	out, err = Sma(in[:2], 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0, 0}; !reflect.DeepEqual(out, want) {
+		t.Errorf("short sma got %v, want %v", out, want)
+	}
+}
@@ -0,0 +1,187 @@
+// Package universe decides which symbols are followed, from the symbols
+// Binance lists and their 24h volume, and tells when the set changes.
+package universe
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cryptoapi/internal/binance"
+	"cryptoapi/internal/logging"
+
+	"github.com/spf13/viper"
+)
+
+// Filter selects the symbols of the universe.
+type Filter struct {
+	// quote assets accepted, e.g. USDT; any when empty
+	QuoteAssets []string
+	// minimum 24h volume in the quote asset
+	MinQuoteVolume float64
+	// always followed while trading, whatever their quote asset, volume and
+	// rank
+	Include []string
+	// never followed
+	Exclude []string
+	// keep only the N most traded, the included ones aside; all when zero
+	Top int
+}
+
+// FilterFromConfig reads the filter from the universe section of the config.
+func FilterFromConfig() Filter {
+	return Filter{
+		QuoteAssets:    upper(viper.GetStringSlice("universe.quote")),
+		MinQuoteVolume: viper.GetFloat64("universe.min-quote-volume"),
+		Include:        upper(viper.GetStringSlice("universe.include")),
+		Exclude:        upper(viper.GetStringSlice("universe.exclude")),
+		Top:            viper.GetInt("universe.top"),
+	}
+}
+
+func upper(values []string) []string {
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i] = strings.ToUpper(v)
+	}
+	return res
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Select applies the filter to the exchange symbols and their 24h tickers.
+func (f Filter) Select(symbols []binance.Symbol, tickers []binance.Ticker24h) []binance.Symbol {
+	volumes := make(map[string]float64, len(tickers))
+	for _, t := range tickers {
+		volumes[t.Symbol] = t.QuoteVolume
+	}
+	var included, ranked []binance.Symbol
+	for _, s := range symbols {
+		switch {
+		case s.Status != "TRADING" || contains(f.Exclude, s.Symbol):
+		case contains(f.Include, s.Symbol):
+			included = append(included, s)
+		case len(f.QuoteAssets) > 0 && !contains(f.QuoteAssets, s.QuoteAsset):
+		case volumes[s.Symbol] < f.MinQuoteVolume:
+		default:
+			ranked = append(ranked, s)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return volumes[ranked[i].Symbol] > volumes[ranked[j].Symbol]
+	})
+	if f.Top > 0 && len(ranked) > f.Top {
+		ranked = ranked[:f.Top]
+	}
+	return append(included, ranked...)
+}
+
+// ChangeFunc is told which symbols joined and left the universe.
+type ChangeFunc func(added, removed []string)
+
+type Universe struct {
+	client *binance.Client
+	filter Filter
+	*logging.Logger
+
+	mu       sync.Mutex
+	symbols  map[string]binance.Symbol
+	onChange ChangeFunc
+}
+
+func New(client *binance.Client, filter Filter, logger *logging.Logger, onChange ChangeFunc) *Universe {
+	return &Universe{
+		client:   client,
+		filter:   filter,
+		Logger:   logger,
+		symbols:  make(map[string]binance.Symbol),
+		onChange: onChange,
+	}
+}
+
+// Refresh rebuilds the universe and reports the changes to onChange.
+func (u *Universe) Refresh(ctx context.Context) error {
+	symbols, err := u.client.ExchangeInfo(ctx)
+	if err != nil {
+		return err
+	}
+	tickers, err := u.client.Tickers24h(ctx)
+	if err != nil {
+		return err
+	}
+	selected := make(map[string]binance.Symbol)
+	for _, s := range u.filter.Select(symbols, tickers) {
+		selected[s.Symbol] = s
+	}
+
+	var added, removed []string
+	u.mu.Lock()
+	for name := range selected {
+		if _, ok := u.symbols[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for name := range u.symbols {
+		if _, ok := selected[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	u.symbols = selected
+	u.mu.Unlock()
+
+	if len(added) > 0 || len(removed) > 0 {
+		sort.Strings(added)
+		sort.Strings(removed)
+		u.Debugf("universe changed: added %v, removed %v", added, removed)
+		if u.onChange != nil {
+			u.onChange(added, removed)
+		}
+	}
+	return nil
+}
+
+// Run refreshes the universe every interval until ctx is done. Failed
+// refreshes keep the previous universe.
+func (u *Universe) Run(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := u.Refresh(ctx); err != nil {
+				u.WithError(err).Debug("failed refreshing the symbol universe")
+			}
+		}
+	}
+}
+
+// Symbols lists the symbols of the universe, sorted.
+func (u *Universe) Symbols() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	res := make([]string, 0, len(u.symbols))
+	for name := range u.symbols {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Info returns the trading rules of a symbol of the universe.
+func (u *Universe) Info(symbol string) (binance.Symbol, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	s, ok := u.symbols[symbol]
+	return s, ok
+}
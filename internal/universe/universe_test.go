@@ -0,0 +1,58 @@
+package universe
+
+import (
+	"reflect"
+	"testing"
+
+	"cryptoapi/internal/binance"
+)
+
+func TestSelect(t *testing.T) {
+	symbols := []binance.Symbol{
+		{Symbol: "BTCUSDT", Status: "TRADING", QuoteAsset: "USDT"},
+		{Symbol: "ETHUSDT", Status: "TRADING", QuoteAsset: "USDT"},
+		{Symbol: "XRPUSDT", Status: "TRADING", QuoteAsset: "USDT"},
+		{Symbol: "DOGEUSDT", Status: "TRADING", QuoteAsset: "USDT"},
+		{Symbol: "LUNAUSDT", Status: "BREAK", QuoteAsset: "USDT"},
+		{Symbol: "ETHBTC", Status: "TRADING", QuoteAsset: "BTC"},
+	}
+	tickers := []binance.Ticker24h{
+		{Symbol: "BTCUSDT", QuoteVolume: 1000},
+		{Symbol: "ETHUSDT", QuoteVolume: 500},
+		{Symbol: "XRPUSDT", QuoteVolume: 100},
+		{Symbol: "DOGEUSDT", QuoteVolume: 800},
+		{Symbol: "LUNAUSDT", QuoteVolume: 5000},
+		{Symbol: "ETHBTC", QuoteVolume: 2000},
+	}
+	for _, tt := range []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"everything trading, most traded first", Filter{},
+			[]string{"ETHBTC", "BTCUSDT", "DOGEUSDT", "ETHUSDT", "XRPUSDT"}},
+		{"quote asset", Filter{QuoteAssets: []string{"USDT"}},
+			[]string{"BTCUSDT", "DOGEUSDT", "ETHUSDT", "XRPUSDT"}},
+		{"min volume", Filter{QuoteAssets: []string{"USDT"}, MinQuoteVolume: 500},
+			[]string{"BTCUSDT", "DOGEUSDT", "ETHUSDT"}},
+		{"top", Filter{QuoteAssets: []string{"USDT"}, Top: 2},
+			[]string{"BTCUSDT", "DOGEUSDT"}},
+		{"exclude", Filter{QuoteAssets: []string{"USDT"}, Exclude: []string{"BTCUSDT"}, Top: 2},
+			[]string{"DOGEUSDT", "ETHUSDT"}},
+		// included symbols come first, outside the top and whatever their
+		// volume or quote asset, as long as they trade
+		{"include", Filter{QuoteAssets: []string{"USDT"}, MinQuoteVolume: 500, Top: 1,
+			Include: []string{"XRPUSDT", "ETHBTC", "LUNAUSDT"}},
+			[]string{"XRPUSDT", "ETHBTC", "BTCUSDT"}},
+		{"exclude wins over include", Filter{Include: []string{"XRPUSDT"}, Exclude: []string{"XRPUSDT"}, Top: 1},
+			[]string{"ETHBTC"}},
+	} {
+		var got []string
+		for _, s := range tt.filter.Select(symbols, tickers) {
+			got = append(got, s.Symbol)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}